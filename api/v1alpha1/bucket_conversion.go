@@ -0,0 +1,356 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// ConversionDataAnnotation stores the v1beta1 spec of a Bucket served as v1alpha1
+// when it holds fields that v1alpha1 cannot represent, so that no data is lost on round trips.
+const ConversionDataAnnotation = "objectstorage.giantswarm.io/conversion-data"
+
+// legacyGrafanaPostgresqlServiceAccounts are the service accounts that the operator used to trust for every access role
+// of a grafana-postgresql service account. They are listed explicitly on conversion so that upgraded roles keep trusting them.
+var legacyGrafanaPostgresqlServiceAccounts = []v1beta1.ServiceAccountReference{
+	{Name: "grafana-postgresql-recovery-test", Namespace: "monitoring"},
+	{Name: "plugin-barman-cloud", Namespace: "monitoring"},
+}
+
+// ConvertTo converts this Bucket to the Hub version (v1beta1).
+func (src *Bucket) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Bucket)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertSpecToHub(src.Spec)
+	dst.Status = convertStatusToHub(src.Status)
+
+	data, ok := dst.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(dst.Annotations, ConversionDataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	var restored v1beta1.BucketSpec
+	if err := json.Unmarshal([]byte(data), &restored); err != nil {
+		return fmt.Errorf("failed to unmarshal %s annotation of bucket %s/%s: %w", ConversionDataAnnotation, src.Namespace, src.Name, err)
+	}
+	restoreHubOnlyFields(&dst.Spec, restored)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *Bucket) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Bucket)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertSpecFromHub(src.Spec)
+	dst.Status = convertStatusFromHub(src.Status)
+
+	// Keep the fields v1alpha1 cannot represent.
+	if !equality.Semantic.DeepEqual(convertSpecToHub(dst.Spec), src.Spec) {
+		data, err := json.Marshal(src.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to marshal spec of bucket %s/%s: %w", src.Namespace, src.Name, err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[ConversionDataAnnotation] = string(data)
+	}
+	return nil
+}
+
+func convertSpecToHub(src BucketSpec) v1beta1.BucketSpec {
+	dst := v1beta1.BucketSpec{
+		Name:          src.Name,
+		ReclaimPolicy: v1beta1.ReclaimPolicy(src.ReclaimPolicy),
+	}
+
+	if src.ExpirationPolicy != nil {
+		dst.Lifecycle = &v1beta1.BucketLifecycle{
+			Expiration: &v1beta1.BucketExpiration{
+				Days: src.ExpirationPolicy.Days,
+			},
+		}
+	}
+
+	if src.AccessRole != nil {
+		dst.Access = &v1beta1.BucketAccess{
+			Role: &v1beta1.BucketAccessRole{
+				Name: src.AccessRole.RoleName,
+				ServiceAccount: v1beta1.ServiceAccountReference{
+					Name:      src.AccessRole.ServiceAccountName,
+					Namespace: src.AccessRole.ServiceAccountNamespace,
+				},
+				ExtraBucketNames: append([]string(nil), src.AccessRole.ExtraBucketNames...),
+			},
+		}
+		if strings.Contains(src.AccessRole.ServiceAccountName, "grafana-postgresql") {
+			dst.Access.Role.ServiceAccounts = append([]v1beta1.ServiceAccountReference(nil), legacyGrafanaPostgresqlServiceAccounts...)
+		}
+	}
+
+	for _, tag := range src.Tags {
+		dst.Tags = append(dst.Tags, v1beta1.BucketTag(tag))
+	}
+	return dst
+}
+
+func convertSpecFromHub(src v1beta1.BucketSpec) BucketSpec {
+	dst := BucketSpec{
+		Name:          src.Name,
+		ReclaimPolicy: string(src.ReclaimPolicy),
+	}
+
+	if src.Lifecycle != nil && src.Lifecycle.Expiration != nil {
+		dst.ExpirationPolicy = &BucketExpirationPolicy{
+			Days: src.Lifecycle.Expiration.Days,
+		}
+	}
+
+	if src.Access != nil && src.Access.Role != nil {
+		dst.AccessRole = &BucketAccessRole{
+			RoleName:                src.Access.Role.Name,
+			ExtraBucketNames:        append([]string(nil), src.Access.Role.ExtraBucketNames...),
+			ServiceAccountName:      src.Access.Role.ServiceAccount.Name,
+			ServiceAccountNamespace: src.Access.Role.ServiceAccount.Namespace,
+		}
+	}
+
+	for _, tag := range src.Tags {
+		dst.Tags = append(dst.Tags, BucketTag(tag))
+	}
+	return dst
+}
+
+func convertStatusToHub(src BucketStatus) v1beta1.BucketStatus {
+	dst := v1beta1.BucketStatus{
+		BucketReady:        src.BucketReady,
+		BucketID:           src.BucketID,
+		BucketClassName:    src.BucketClassName,
+		ObservedGeneration: src.ObservedGeneration,
+		Conditions:         append([]metav1.Condition(nil), src.Conditions...),
+	}
+
+	if src.Adoption != nil {
+		dst.Adoption = &v1beta1.BucketAdoption{AdoptedAt: src.Adoption.AdoptedAt}
+		for _, tag := range src.Adoption.Tags {
+			dst.Adoption.Tags = append(dst.Adoption.Tags, v1beta1.BucketTag(tag))
+		}
+	}
+
+	if src.ConnectionSecretRef != nil {
+		dst.ConnectionSecretRef = &v1beta1.SecretReference{Name: src.ConnectionSecretRef.Name, Namespace: src.ConnectionSecretRef.Namespace}
+	}
+
+	if src.Lifecycle != nil {
+		dst.Lifecycle = &v1beta1.BucketLifecycleStatus{AbortIncompleteMultipartUploadDays: src.Lifecycle.AbortIncompleteMultipartUploadDays}
+	}
+
+	if src.ObjectLock != nil {
+		dst.ObjectLock = &v1beta1.BucketObjectLockStatus{Mode: v1beta1.ObjectLockMode(src.ObjectLock.Mode), RetentionDays: src.ObjectLock.RetentionDays}
+	}
+
+	if src.Encryption != nil {
+		dst.Encryption = &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionMode(src.Encryption.Mode), KeyID: src.Encryption.KeyID}
+	}
+
+	if src.PublicAccess != nil {
+		dst.PublicAccess = &v1beta1.BucketPublicAccessStatus{
+			BlockPublicAcls:         src.PublicAccess.BlockPublicAcls,
+			IgnorePublicAcls:        src.PublicAccess.IgnorePublicAcls,
+			BlockPublicPolicy:       src.PublicAccess.BlockPublicPolicy,
+			RestrictPublicBuckets:   src.PublicAccess.RestrictPublicBuckets,
+			ObjectOwnership:         v1beta1.ObjectOwnership(src.PublicAccess.ObjectOwnership),
+			LastDriftCorrectionTime: src.PublicAccess.LastDriftCorrectionTime,
+		}
+	}
+
+	if src.AccessRole != nil {
+		dst.AccessRole = &v1beta1.BucketAccessRoleStatus{
+			ARN:         src.AccessRole.ARN,
+			Mode:        v1beta1.AccessRoleMode(src.AccessRole.Mode),
+			ClientID:    src.AccessRole.ClientID,
+			PrincipalID: src.AccessRole.PrincipalID,
+			TenantID:    src.AccessRole.TenantID,
+		}
+		dst.AccessRole.ManagedPolicies = append([]string(nil), src.AccessRole.ManagedPolicies...)
+		for _, association := range src.AccessRole.PodIdentityAssociations {
+			dst.AccessRole.PodIdentityAssociations = append(dst.AccessRole.PodIdentityAssociations, v1beta1.PodIdentityAssociation{
+				ID: association.ID,
+				ServiceAccount: v1beta1.ServiceAccountReference{
+					Name:      association.ServiceAccountName,
+					Namespace: association.ServiceAccountNamespace,
+				},
+			})
+		}
+	}
+
+	for _, grant := range src.AccessGrants {
+		dst.AccessGrants = append(dst.AccessGrants, v1beta1.BucketAccessGrantStatus{
+			PrincipalID:      grant.PrincipalID,
+			Role:             v1beta1.AccessGrantRole(grant.Role),
+			RoleAssignmentID: grant.RoleAssignmentID,
+		})
+	}
+
+	if src.Credentials != nil {
+		dst.Credentials = &v1beta1.BucketCredentialsStatus{
+			Type:      v1beta1.AzureCredentialsType(src.Credentials.Type),
+			ExpiresAt: src.Credentials.ExpiresAt,
+			RenewAt:   src.Credentials.RenewAt,
+		}
+	}
+	return dst
+}
+
+func convertStatusFromHub(src v1beta1.BucketStatus) BucketStatus {
+	dst := BucketStatus{
+		BucketReady:        src.BucketReady,
+		BucketID:           src.BucketID,
+		BucketClassName:    src.BucketClassName,
+		ObservedGeneration: src.ObservedGeneration,
+		Conditions:         append([]metav1.Condition(nil), src.Conditions...),
+	}
+
+	if src.Adoption != nil {
+		dst.Adoption = &BucketAdoption{AdoptedAt: src.Adoption.AdoptedAt}
+		for _, tag := range src.Adoption.Tags {
+			dst.Adoption.Tags = append(dst.Adoption.Tags, BucketTag(tag))
+		}
+	}
+
+	if src.ConnectionSecretRef != nil {
+		dst.ConnectionSecretRef = &SecretReference{Name: src.ConnectionSecretRef.Name, Namespace: src.ConnectionSecretRef.Namespace}
+	}
+
+	if src.Lifecycle != nil {
+		dst.Lifecycle = &BucketLifecycleStatus{AbortIncompleteMultipartUploadDays: src.Lifecycle.AbortIncompleteMultipartUploadDays}
+	}
+
+	if src.ObjectLock != nil {
+		dst.ObjectLock = &BucketObjectLockStatus{Mode: string(src.ObjectLock.Mode), RetentionDays: src.ObjectLock.RetentionDays}
+	}
+
+	if src.Encryption != nil {
+		dst.Encryption = &BucketEncryptionStatus{Mode: string(src.Encryption.Mode), KeyID: src.Encryption.KeyID}
+	}
+
+	if src.PublicAccess != nil {
+		dst.PublicAccess = &BucketPublicAccessStatus{
+			BlockPublicAcls:         src.PublicAccess.BlockPublicAcls,
+			IgnorePublicAcls:        src.PublicAccess.IgnorePublicAcls,
+			BlockPublicPolicy:       src.PublicAccess.BlockPublicPolicy,
+			RestrictPublicBuckets:   src.PublicAccess.RestrictPublicBuckets,
+			ObjectOwnership:         string(src.PublicAccess.ObjectOwnership),
+			LastDriftCorrectionTime: src.PublicAccess.LastDriftCorrectionTime,
+		}
+	}
+
+	if src.AccessRole != nil {
+		dst.AccessRole = &BucketAccessRoleStatus{
+			ARN:         src.AccessRole.ARN,
+			Mode:        string(src.AccessRole.Mode),
+			ClientID:    src.AccessRole.ClientID,
+			PrincipalID: src.AccessRole.PrincipalID,
+			TenantID:    src.AccessRole.TenantID,
+		}
+		dst.AccessRole.ManagedPolicies = append([]string(nil), src.AccessRole.ManagedPolicies...)
+		for _, association := range src.AccessRole.PodIdentityAssociations {
+			dst.AccessRole.PodIdentityAssociations = append(dst.AccessRole.PodIdentityAssociations, PodIdentityAssociation{
+				ID:                      association.ID,
+				ServiceAccountName:      association.ServiceAccount.Name,
+				ServiceAccountNamespace: association.ServiceAccount.Namespace,
+			})
+		}
+	}
+
+	for _, grant := range src.AccessGrants {
+		dst.AccessGrants = append(dst.AccessGrants, BucketAccessGrantStatus{
+			PrincipalID:      grant.PrincipalID,
+			Role:             string(grant.Role),
+			RoleAssignmentID: grant.RoleAssignmentID,
+		})
+	}
+
+	if src.Credentials != nil {
+		dst.Credentials = &BucketCredentialsStatus{
+			Type:      string(src.Credentials.Type),
+			ExpiresAt: src.Credentials.ExpiresAt,
+			RenewAt:   src.Credentials.RenewAt,
+		}
+	}
+	return dst
+}
+
+// restoreHubOnlyFields sets the fields of the hub that v1alpha1 cannot represent from their saved value.
+// Fields that v1alpha1 represents are left untouched so that changes made through v1alpha1 win.
+func restoreHubOnlyFields(dst *v1beta1.BucketSpec, restored v1beta1.BucketSpec) {
+	dst.BucketClassName = restored.BucketClassName
+	dst.AdoptionPolicy = restored.AdoptionPolicy
+	dst.AllowAdoptedBucketDeletion = restored.AllowAdoptedBucketDeletion
+	dst.Versioning = restored.Versioning
+	dst.ObjectLock = restored.ObjectLock
+	dst.Encryption = restored.Encryption
+	dst.BucketPolicy = restored.BucketPolicy
+	dst.Parameters = restored.Parameters
+	dst.WriteConnectionSecretToRef = restored.WriteConnectionSecretToRef
+	dst.AccessGrants = restored.AccessGrants
+
+	// Only the expiration of the lifecycle is represented in v1alpha1.
+	if restored.Lifecycle != nil {
+		lifecycle := restored.Lifecycle.DeepCopy()
+		lifecycle.Expiration = nil
+		if dst.Lifecycle != nil {
+			lifecycle.Expiration = dst.Lifecycle.Expiration
+		}
+		if dst.Lifecycle != nil || restored.Lifecycle.Expiration == nil || !equality.Semantic.DeepEqual(lifecycle, &v1beta1.BucketLifecycle{}) {
+			dst.Lifecycle = lifecycle
+		}
+	}
+
+	if dst.Access == nil && restored.Access != nil && restored.Access.Role == nil {
+		dst.Access = restored.Access
+	}
+
+	// Only the name, service account and extra bucket names of the access role are represented in v1alpha1.
+	if dst.Access != nil && dst.Access.Role != nil && restored.Access != nil && restored.Access.Role != nil {
+		role := restored.Access.Role.DeepCopy()
+		dst.Access.Role.Mode = role.Mode
+		dst.Access.Role.ServiceAccounts = role.ServiceAccounts
+		dst.Access.Role.OIDCProviders = role.OIDCProviders
+		dst.Access.Role.Description = role.Description
+		dst.Access.Role.Path = role.Path
+		dst.Access.Role.PermissionsBoundary = role.PermissionsBoundary
+		dst.Access.Role.MaxSessionDuration = role.MaxSessionDuration
+		dst.Access.Role.ManagedPolicies = role.ManagedPolicies
+		dst.Access.Role.BucketPermissions = role.BucketPermissions
+		dst.Access.Role.ExtraBuckets = role.ExtraBuckets
+	}
+}
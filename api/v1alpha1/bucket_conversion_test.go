@@ -0,0 +1,639 @@
+package v1alpha1
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_ConvertSpokeRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		bucket *Bucket
+	}{
+		{
+			name: "case 0: minimal bucket",
+			bucket: &Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec:       BucketSpec{Name: "giantswarm-glippy-loki"},
+			},
+		},
+		{
+			name: "case 1: complete bucket",
+			bucket: &Bucket{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "loki",
+					Namespace:   "loki",
+					Labels:      map[string]string{"app": "loki"},
+					Annotations: map[string]string{"owner": "atlas"},
+					Finalizers:  []string{BucketFinalizer},
+				},
+				Spec: BucketSpec{
+					Name:             "giantswarm-glippy-loki",
+					ExpirationPolicy: &BucketExpirationPolicy{Days: 100},
+					ReclaimPolicy:    ReclaimPolicyDelete,
+					AccessRole: &BucketAccessRole{
+						RoleName:                "giantswarm-glippy-loki",
+						ExtraBucketNames:        []string{"giantswarm-glippy-loki-ruler"},
+						ServiceAccountName:      "loki",
+						ServiceAccountNamespace: "loki",
+					},
+					Tags: []BucketTag{{Key: "app", Value: "loki"}},
+				},
+				Status: BucketStatus{
+					BucketReady:        true,
+					BucketID:           "giantswarm-glippy-loki",
+					ObservedGeneration: 2,
+					Adoption: &BucketAdoption{
+						AdoptedAt: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+						Tags:      []BucketTag{{Key: "team", Value: "atlas"}},
+					},
+					ConnectionSecretRef: &SecretReference{Name: "giantswarm-glippy-loki", Namespace: "loki"},
+					Conditions: []metav1.Condition{
+						{Type: v1beta1.ConditionReady, Status: metav1.ConditionTrue, Reason: v1beta1.ReasonReady, ObservedGeneration: 2},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			hub := &v1beta1.Bucket{}
+			if err := tc.bucket.ConvertTo(hub); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := hub.Annotations[ConversionDataAnnotation]; ok {
+				t.Fatalf("unexpected %s annotation", ConversionDataAnnotation)
+			}
+
+			spoke := &Bucket{}
+			if err := spoke.ConvertFrom(hub); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(spoke, tc.bucket) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.bucket, spoke))
+			}
+		})
+	}
+}
+
+func Test_ConvertHubRoundTrip(t *testing.T) {
+	blockPublicAccess := false
+	driftCorrectedAt := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name               string
+		bucket             *v1beta1.Bucket
+		expectedAnnotation bool
+	}{
+		{
+			name: "case 0: bucket representable in v1alpha1",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name:          "giantswarm-glippy-loki",
+					ReclaimPolicy: v1beta1.ReclaimPolicyRetain,
+					Lifecycle:     &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}},
+					Access: &v1beta1.BucketAccess{
+						Role: &v1beta1.BucketAccessRole{
+							Name:           "giantswarm-glippy-loki",
+							ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						},
+					},
+				},
+			},
+			expectedAnnotation: false,
+		},
+		{
+			name: "case 1: bucket with encryption",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki", Annotations: map[string]string{"owner": "atlas"}},
+				Spec: v1beta1.BucketSpec{
+					Name:       "giantswarm-glippy-loki",
+					Encryption: &v1beta1.BucketEncryption{Mode: v1beta1.EncryptionModeProviderManaged},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 2: adopted bucket",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name:                       "giantswarm-glippy-loki",
+					ReclaimPolicy:              v1beta1.ReclaimPolicyDelete,
+					AdoptionPolicy:             v1beta1.AdoptionPolicyAdopt,
+					AllowAdoptedBucketDeletion: true,
+				},
+				Status: v1beta1.BucketStatus{
+					BucketID: "giantswarm-glippy-loki",
+					Adoption: &v1beta1.BucketAdoption{AdoptedAt: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 3: bucket with a class and parameters",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name:            "giantswarm-glippy-loki",
+					BucketClassName: "logs",
+					Parameters: &v1beta1.BucketParameters{
+						Azure: &v1beta1.AzureBucketParameters{SKU: v1beta1.AzureSKUStandardGRS, AccessTier: v1beta1.AzureAccessTierCool},
+					},
+				},
+				Status: v1beta1.BucketStatus{BucketClassName: "logs"},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 4: bucket with a connection secret reference",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					WriteConnectionSecretToRef: &v1beta1.ConnectionSecretReference{
+						Name:      "loki-object-store",
+						Namespace: "monitoring",
+						Labels:    map[string]string{"app": "loki"},
+						Templates: map[string]string{"config.yaml": "bucket_name: {{ .bucketName }}"},
+					},
+				},
+				Status: v1beta1.BucketStatus{
+					ConnectionSecretRef: &v1beta1.SecretReference{Name: "loki-object-store", Namespace: "monitoring"},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 5: bucket with empty lifecycle and access",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name:      "giantswarm-glippy-loki",
+					Lifecycle: &v1beta1.BucketLifecycle{},
+					Access:    &v1beta1.BucketAccess{},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 6: bucket with an object lock",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "audit"},
+				Spec: v1beta1.BucketSpec{
+					Name:       "giantswarm-glippy-audit",
+					Versioning: v1beta1.VersioningEnabled,
+					ObjectLock: &v1beta1.BucketObjectLock{Mode: v1beta1.ObjectLockModeCompliance, RetentionDays: 365},
+				},
+				Status: v1beta1.BucketStatus{
+					ObjectLock: &v1beta1.BucketObjectLockStatus{Mode: v1beta1.ObjectLockModeCompliance, RetentionDays: 365},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 7: versioned bucket with noncurrent version expiration",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name:       "giantswarm-glippy-loki",
+					Versioning: v1beta1.VersioningEnabled,
+					Lifecycle: &v1beta1.BucketLifecycle{
+						Expiration: &v1beta1.BucketExpiration{Days: 30},
+						Rules: []v1beta1.BucketLifecycleRule{
+							{
+								ID:                          "versions",
+								NoncurrentVersionExpiration: &v1beta1.BucketNoncurrentVersionExpiration{Days: 7, NewerNoncurrentVersions: 3},
+								ExpiredObjectDeleteMarker:   true,
+							},
+						},
+					},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 8: bucket encrypted with a customer-managed key",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Encryption: &v1beta1.BucketEncryption{
+						Mode: v1beta1.EncryptionModeCustomerManaged,
+						KMS:  &v1beta1.KMSEncryption{KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", BucketKeyEnabled: true},
+					},
+				},
+				Status: v1beta1.BucketStatus{
+					Encryption: &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeCustomerManaged, KeyID: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 9: S3 bucket with public access allowed",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Parameters: &v1beta1.BucketParameters{
+						AWS: &v1beta1.AWSBucketParameters{BlockPublicAccess: &blockPublicAccess, ObjectOwnership: v1beta1.ObjectOwnershipBucketOwnerPreferred},
+					},
+				},
+				Status: v1beta1.BucketStatus{
+					PublicAccess: &v1beta1.BucketPublicAccessStatus{
+						ObjectOwnership:         v1beta1.ObjectOwnershipBucketOwnerPreferred,
+						LastDriftCorrectionTime: &driftCorrectedAt,
+					},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 10: bucket with bucket policy statements",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					BucketPolicy: &v1beta1.BucketPolicy{
+						Statements: []v1beta1.BucketPolicyStatement{{
+							Sid:        "DenyOutsideVpce",
+							Effect:     v1beta1.PolicyEffectDeny,
+							Principal:  v1beta1.BucketPolicyPrincipal{AWS: []string{"*"}},
+							Actions:    []string{"s3:*"},
+							Conditions: []v1beta1.BucketPolicyCondition{{Operator: "StringNotEquals", Key: "aws:SourceVpce", Values: []string{"vpce-1a2b3c4d"}}},
+						}},
+						RawStatements: `[{"Effect": "Allow", "Principal": {"Service": "logging.s3.amazonaws.com"}, "Action": "s3:PutObject"}]`,
+					},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 11: access role with permission levels",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:              "giantswarm-glippy-loki",
+						ServiceAccount:    v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						BucketPermissions: v1beta1.BucketPermissions{AccessLevel: v1beta1.AccessLevelReadOnly, Prefixes: []string{"chunks/"}},
+						ExtraBucketNames:  []string{"giantswarm-glippy-mimir"},
+						ExtraBuckets: []v1beta1.ExtraBucketPermissions{{
+							Name:              "giantswarm-glippy-archive",
+							BucketPermissions: v1beta1.BucketPermissions{ExtraActions: []string{"s3:ListBucketMultipartUploads"}},
+						}},
+					}},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 12: access role with additional service accounts",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "grafana-postgresql", Namespace: "monitoring"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-grafana-postgresql",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:           "giantswarm-glippy-grafana-postgresql",
+						ServiceAccount: v1beta1.ServiceAccountReference{Name: "grafana-postgresql", Namespace: "monitoring"},
+						ServiceAccounts: []v1beta1.ServiceAccountReference{
+							{Name: "grafana-postgresql-recovery-test", Namespace: "monitoring"},
+							{Name: "plugin-barman-*", Namespace: "monitoring"},
+						},
+					}},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 13: access role with OIDC providers",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:           "giantswarm-glippy-loki",
+						ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						OIDCProviders: []v1beta1.OIDCProvider{
+							{Issuer: "https://oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLE", Audiences: []string{"sts.amazonaws.com"}},
+						},
+					}},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 14: access role in the PodIdentity mode",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:           "giantswarm-glippy-loki",
+						Mode:           v1beta1.AccessRoleModePodIdentity,
+						ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					}},
+				},
+				Status: v1beta1.BucketStatus{
+					AccessRole: &v1beta1.BucketAccessRoleStatus{
+						ARN:  "arn:aws:iam::123456789012:role/giantswarm-glippy-loki",
+						Mode: v1beta1.AccessRoleModePodIdentity,
+						PodIdentityAssociations: []v1beta1.PodIdentityAssociation{{
+							ID:             "a-abcdefghijklmnop1",
+							ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						}},
+					},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 15: access role with IAM settings",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:                "giantswarm-glippy-loki",
+						ServiceAccount:      v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						Description:         "Loki chunks and index",
+						Path:                "/giantswarm/",
+						PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+						MaxSessionDuration:  &metav1.Duration{Duration: 4 * time.Hour},
+						ManagedPolicies:     []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+					}},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 16: Azure managed identity of the access role",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:           "giantswarm-glippy-loki",
+						ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					}},
+				},
+				Status: v1beta1.BucketStatus{
+					AccessRole: &v1beta1.BucketAccessRoleStatus{
+						ClientID:    "00000000-0000-0000-0000-000000000001",
+						PrincipalID: "00000000-0000-0000-0000-000000000002",
+						TenantID:    "00000000-0000-0000-0000-000000000003",
+					},
+				},
+			},
+		},
+		{
+			name: "case 17: access grants",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					AccessGrants: []v1beta1.BucketAccessGrant{{
+						PrincipalID:   "00000000-0000-0000-0000-000000000001",
+						PrincipalType: v1beta1.PrincipalTypeGroup,
+						Role:          v1beta1.AccessGrantRoleReader,
+					}},
+				},
+				Status: v1beta1.BucketStatus{
+					AccessGrants: []v1beta1.BucketAccessGrantStatus{{
+						PrincipalID:      "00000000-0000-0000-0000-000000000001",
+						Role:             v1beta1.AccessGrantRoleReader,
+						RoleAssignmentID: "/subscriptions/subscriptionID/resourceGroups/glippy/providers/Microsoft.Storage/storageAccounts/giantswarmglippyloki/blobServices/default/containers/giantswarm-glippy-loki/providers/Microsoft.Authorization/roleAssignments/00000000-0000-0000-0000-000000000002",
+					}},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 18: SAS token credentials",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Parameters: &v1beta1.BucketParameters{
+						Azure: &v1beta1.AzureBucketParameters{Credentials: &v1beta1.AzureBucketCredentials{
+							Type:        v1beta1.AzureCredentialsTypeUserDelegationSAS,
+							AccessLevel: v1beta1.AccessLevelReadOnly,
+							Lifetime:    &metav1.Duration{Duration: 12 * time.Hour},
+						}},
+					},
+				},
+				Status: v1beta1.BucketStatus{
+					Credentials: &v1beta1.BucketCredentialsStatus{
+						Type:      v1beta1.AzureCredentialsTypeUserDelegationSAS,
+						ExpiresAt: metav1.NewTime(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)),
+						RenewAt:   metav1.NewTime(time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "case 19: Azure bucket discarding uncommitted blocks",
+			bucket: &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+				},
+				Status: v1beta1.BucketStatus{
+					Lifecycle: &v1beta1.BucketLifecycleStatus{AbortIncompleteMultipartUploadDays: 7},
+				},
+			},
+			expectedAnnotation: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			spoke := &Bucket{}
+			if err := spoke.ConvertFrom(tc.bucket); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := spoke.Annotations[ConversionDataAnnotation]; ok != tc.expectedAnnotation {
+				t.Fatalf("%s annotation set: %t, expected %t", ConversionDataAnnotation, ok, tc.expectedAnnotation)
+			}
+
+			hub := &v1beta1.Bucket{}
+			if err := spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(hub, tc.bucket) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.bucket, hub))
+			}
+		})
+	}
+}
+
+func Test_ConvertToKeepsSpokeChanges(t *testing.T) {
+	hub := &v1beta1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+		Spec: v1beta1.BucketSpec{
+			Name:       "giantswarm-glippy-loki",
+			Encryption: &v1beta1.BucketEncryption{Mode: v1beta1.EncryptionModeProviderManaged},
+			Lifecycle:  &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}},
+		},
+	}
+
+	spoke := &Bucket{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A v1alpha1 client updates the expiration policy and keeps the annotation it read.
+	spoke.Spec.ExpirationPolicy.Days = 60
+
+	converted := &v1beta1.Bucket{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := hub.DeepCopy()
+	expected.Spec.Lifecycle.Expiration.Days = 60
+	if !cmp.Equal(converted, expected) {
+		t.Fatalf("\n\n%s\n", cmp.Diff(expected, converted))
+	}
+}
+
+func Test_ConvertToKeepsLifecycleRules(t *testing.T) {
+	rules := []v1beta1.BucketLifecycleRule{
+		{ID: "chunks", Filter: &v1beta1.BucketLifecycleFilter{Prefix: "chunks/"}, Expiration: &v1beta1.BucketLifecycleExpiration{Days: 7}},
+	}
+
+	testCases := []struct {
+		name              string
+		lifecycle         *v1beta1.BucketLifecycle
+		updateSpoke       func(*Bucket)
+		expectedLifecycle *v1beta1.BucketLifecycle
+	}{
+		{
+			name:              "case 0: expiration updated",
+			lifecycle:         &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}, Rules: rules},
+			updateSpoke:       func(b *Bucket) { b.Spec.ExpirationPolicy.Days = 60 },
+			expectedLifecycle: &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 60}, Rules: rules},
+		},
+		{
+			name:              "case 1: expiration removed",
+			lifecycle:         &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}, Rules: rules},
+			updateSpoke:       func(b *Bucket) { b.Spec.ExpirationPolicy = nil },
+			expectedLifecycle: &v1beta1.BucketLifecycle{Rules: rules},
+		},
+		{
+			name:              "case 2: expiration added",
+			lifecycle:         &v1beta1.BucketLifecycle{Rules: rules},
+			updateSpoke:       func(b *Bucket) { b.Spec.ExpirationPolicy = &BucketExpirationPolicy{Days: 60} },
+			expectedLifecycle: &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 60}, Rules: rules},
+		},
+		{
+			name:              "case 3: expiration removed without rules",
+			lifecycle:         &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}},
+			updateSpoke:       func(b *Bucket) { b.Spec.ExpirationPolicy = nil },
+			expectedLifecycle: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			hub := &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"},
+				Spec: v1beta1.BucketSpec{
+					Name:       "giantswarm-glippy-loki",
+					Encryption: &v1beta1.BucketEncryption{Mode: v1beta1.EncryptionModeProviderManaged},
+					Lifecycle:  tc.lifecycle,
+				},
+			}
+
+			spoke := &Bucket{}
+			if err := spoke.ConvertFrom(hub); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tc.updateSpoke(spoke)
+
+			converted := &v1beta1.Bucket{}
+			if err := spoke.ConvertTo(converted); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(converted.Spec.Lifecycle, tc.expectedLifecycle) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedLifecycle, converted.Spec.Lifecycle))
+			}
+		})
+	}
+}
+
+func Test_ConvertToTrustsLegacyGrafanaPostgresqlServiceAccounts(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		serviceAccountName      string
+		expectedServiceAccounts []v1beta1.ServiceAccountReference
+	}{
+		{
+			name:               "case 0: grafana-postgresql service account",
+			serviceAccountName: "grafana-postgresql",
+			expectedServiceAccounts: []v1beta1.ServiceAccountReference{
+				{Name: "grafana-postgresql-recovery-test", Namespace: "monitoring"},
+				{Name: "plugin-barman-cloud", Namespace: "monitoring"},
+			},
+		},
+		{
+			name:                    "case 1: other service account",
+			serviceAccountName:      "loki",
+			expectedServiceAccounts: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			spoke := &Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "grafana-postgresql", Namespace: "monitoring"},
+				Spec: BucketSpec{
+					Name: "giantswarm-glippy-grafana-postgresql",
+					AccessRole: &BucketAccessRole{
+						RoleName:                "giantswarm-glippy-grafana-postgresql",
+						ServiceAccountName:      tc.serviceAccountName,
+						ServiceAccountNamespace: "monitoring",
+					},
+				},
+			}
+
+			hub := &v1beta1.Bucket{}
+			if err := spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(hub.Spec.Access.Role.ServiceAccounts, tc.expectedServiceAccounts) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedServiceAccounts, hub.Spec.Access.Role.ServiceAccounts))
+			}
+
+			// Served back as v1alpha1, the bucket does not need the conversion annotation.
+			converted := &Bucket{}
+			if err := converted.ConvertFrom(hub); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(converted, spoke) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(spoke, converted))
+			}
+		})
+	}
+}
@@ -76,7 +76,7 @@ type BucketTag struct {
 	// Key is the key of the bucket tag to add to the bucket.
 	Key string `json:"key"`
 
-	// Key is the key of the bucket tag to add to the bucket.
+	// Value is the value of the bucket tag to add to the bucket.
 	Value string `json:"value"`
 }
 
@@ -84,15 +84,214 @@ type BucketTag struct {
 type BucketStatus struct {
 	// BucketReady is a boolean condition to reflect the successful creation
 	// of a bucket.
+	// Deprecated: use the Ready condition instead.
 	BucketReady bool `json:"bucketReady,omitempty"`
 
 	// BucketID is the unique id of the bucket.
 	// +optional
 	BucketID string `json:"bucketID,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// BucketClassName is the name of the BucketClass applied to the bucket.
+	// +optional
+	BucketClassName string `json:"bucketClassName,omitempty"`
+
+	// Adoption records the state of the bucket when it was adopted. It is only set for buckets that existed before
+	// the operator managed them.
+	// +optional
+	Adoption *BucketAdoption `json:"adoption,omitempty"`
+
+	// ConnectionSecretRef references the Secret the connection details of the bucket are published in.
+	// +optional
+	ConnectionSecretRef *SecretReference `json:"connectionSecretRef,omitempty"`
+
+	// Lifecycle is the cleanup applied to the bucket by the cloud provider.
+	// +optional
+	Lifecycle *BucketLifecycleStatus `json:"lifecycle,omitempty"`
+
+	// ObjectLock is the retention applied to the objects of the bucket.
+	// +optional
+	ObjectLock *BucketObjectLockStatus `json:"objectLock,omitempty"`
+
+	// Encryption is the encryption at rest applied to the bucket.
+	// +optional
+	Encryption *BucketEncryptionStatus `json:"encryption,omitempty"`
+
+	// PublicAccess is the public access protection applied to the bucket. It is only set for S3 buckets.
+	// +optional
+	PublicAccess *BucketPublicAccessStatus `json:"publicAccess,omitempty"`
+
+	// AccessRole is the access role configured for the bucket.
+	// +optional
+	AccessRole *BucketAccessRoleStatus `json:"accessRole,omitempty"`
+
+	// AccessGrants are the role assignments made on the Azure container for the access grants of the bucket.
+	// +optional
+	AccessGrants []BucketAccessGrantStatus `json:"accessGrants,omitempty"`
+
+	// Credentials records the expiring credentials published in the connection Secret.
+	// +optional
+	Credentials *BucketCredentialsStatus `json:"credentials,omitempty"`
+
+	// Conditions represent the latest available observations of the bucket state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// BucketLifecycleStatus records the cleanup applied to a bucket.
+type BucketLifecycleStatus struct {
+	// AbortIncompleteMultipartUploadDays is the number of days after which incomplete multipart uploads are discarded.
+	// +optional
+	AbortIncompleteMultipartUploadDays int32 `json:"abortIncompleteMultipartUploadDays,omitempty"`
+}
+
+// BucketObjectLockStatus records the retention applied to the objects of a bucket.
+type BucketObjectLockStatus struct {
+	// Mode of the default retention. It is empty when objects are only retained on demand.
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// RetentionDays is the default number of days objects are retained for after their creation.
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+}
+
+// BucketEncryptionStatus records the encryption at rest applied to a bucket.
+type BucketEncryptionStatus struct {
+	// Mode of the encryption.
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// KeyID identifies the customer-managed key: the ARN of the KMS key on AWS, or the URI of the Key Vault key on Azure.
+	// +optional
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// BucketAccessRoleStatus records the access role configured for a bucket.
+type BucketAccessRoleStatus struct {
+	// ARN of the IAM role.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// Mode of the role.
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// PodIdentityAssociations are the EKS Pod Identity associations of the service accounts with the role.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
+
+	// ManagedPolicies are the ARNs of the managed policies the operator attached to the IAM role. Policies attached
+	// outside of the Bucket are not listed and are left untouched.
+	// +optional
+	ManagedPolicies []string `json:"managedPolicies,omitempty"`
+
+	// ClientID of the Azure managed identity, which workloads use to authenticate.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// PrincipalID of the Azure managed identity, which the role assignments are granted to.
+	// +optional
+	PrincipalID string `json:"principalID,omitempty"`
+
+	// TenantID of the Azure managed identity.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// BucketAccessGrantStatus records the role assignment made for an access grant.
+type BucketAccessGrantStatus struct {
+	// PrincipalID is the object ID of the principal.
+	PrincipalID string `json:"principalID"`
+
+	// Role granted on the container.
+	Role string `json:"role"`
+
+	// RoleAssignmentID is the resource ID of the role assignment made by the operator. It is empty when the principal
+	// already had the role on the container, in which case the assignment is left untouched.
+	// +optional
+	RoleAssignmentID string `json:"roleAssignmentID,omitempty"`
+}
+
+// BucketCredentialsStatus records the expiring credentials published in the connection Secret of the bucket.
+type BucketCredentialsStatus struct {
+	// Type of the credentials.
+	Type string `json:"type"`
+
+	// ExpiresAt is the time the credentials expire.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// RenewAt is the time the operator renews the credentials.
+	RenewAt metav1.Time `json:"renewAt"`
+}
+
+// PodIdentityAssociation records the EKS Pod Identity association of a service account with an access role.
+type PodIdentityAssociation struct {
+	// ID of the association.
+	ID string `json:"id"`
+
+	// ServiceAccountName is the name of the service account associated with the role.
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// ServiceAccountNamespace is the namespace of the service account associated with the role.
+	ServiceAccountNamespace string `json:"serviceAccountNamespace"`
+}
+
+// BucketPublicAccessStatus records the S3 Block Public Access settings and the object ownership of a bucket.
+type BucketPublicAccessStatus struct {
+	// BlockPublicAcls rejects the requests setting public ACLs.
+	BlockPublicAcls bool `json:"blockPublicAcls"`
+
+	// IgnorePublicAcls ignores the public ACLs of the bucket and its objects.
+	IgnorePublicAcls bool `json:"ignorePublicAcls"`
+
+	// BlockPublicPolicy rejects the bucket policies granting public access.
+	BlockPublicPolicy bool `json:"blockPublicPolicy"`
+
+	// RestrictPublicBuckets restricts the access to a bucket with a public policy to AWS services and the bucket owner.
+	RestrictPublicBuckets bool `json:"restrictPublicBuckets"`
+
+	// ObjectOwnership of the objects uploaded to the bucket.
+	// +optional
+	ObjectOwnership string `json:"objectOwnership,omitempty"`
+
+	// LastDriftCorrectionTime is the last time the operator reverted a change made to these settings outside of the
+	// Bucket.
+	// +optional
+	LastDriftCorrectionTime *metav1.Time `json:"lastDriftCorrectionTime,omitempty"`
+}
+
+// BucketAdoption records what pre-existed when the operator adopted a bucket.
+type BucketAdoption struct {
+	// AdoptedAt is the time the operator adopted the bucket.
+	AdoptedAt metav1.Time `json:"adoptedAt"`
+
+	// Tags the bucket had when it was adopted.
+	// +optional
+	Tags []BucketTag `json:"tags,omitempty"`
+}
+
+// SecretReference references a Secret.
+type SecretReference struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Namespace of the Secret.
+	Namespace string `json:"namespace"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:deprecatedversion:warning="objectstorage.giantswarm.io/v1alpha1 Bucket is deprecated, use objectstorage.giantswarm.io/v1beta1 Bucket"
+//+kubebuilder:printcolumn:name="Bucket",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Bucket is the Schema for the buckets API
 type Bucket struct {
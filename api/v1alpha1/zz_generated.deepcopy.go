@@ -1,5 +1,4 @@
 //go:build !ignore_autogenerated
-// +build !ignore_autogenerated
 
 /*
 Copyright 2023.
@@ -22,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -31,7 +31,7 @@ func (in *Bucket) DeepCopyInto(out *Bucket) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
@@ -52,6 +52,21 @@ func (in *Bucket) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccessGrantStatus) DeepCopyInto(out *BucketAccessGrantStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccessGrantStatus.
+func (in *BucketAccessGrantStatus) DeepCopy() *BucketAccessGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccessGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketAccessRole) DeepCopyInto(out *BucketAccessRole) {
 	*out = *in
@@ -72,6 +87,84 @@ func (in *BucketAccessRole) DeepCopy() *BucketAccessRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccessRoleStatus) DeepCopyInto(out *BucketAccessRoleStatus) {
+	*out = *in
+	if in.PodIdentityAssociations != nil {
+		in, out := &in.PodIdentityAssociations, &out.PodIdentityAssociations
+		*out = make([]PodIdentityAssociation, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedPolicies != nil {
+		in, out := &in.ManagedPolicies, &out.ManagedPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccessRoleStatus.
+func (in *BucketAccessRoleStatus) DeepCopy() *BucketAccessRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccessRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAdoption) DeepCopyInto(out *BucketAdoption) {
+	*out = *in
+	in.AdoptedAt.DeepCopyInto(&out.AdoptedAt)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]BucketTag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAdoption.
+func (in *BucketAdoption) DeepCopy() *BucketAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketCredentialsStatus) DeepCopyInto(out *BucketCredentialsStatus) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	in.RenewAt.DeepCopyInto(&out.RenewAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketCredentialsStatus.
+func (in *BucketCredentialsStatus) DeepCopy() *BucketCredentialsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketCredentialsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryptionStatus) DeepCopyInto(out *BucketEncryptionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryptionStatus.
+func (in *BucketEncryptionStatus) DeepCopy() *BucketEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketExpirationPolicy) DeepCopyInto(out *BucketExpirationPolicy) {
 	*out = *in
@@ -87,6 +180,21 @@ func (in *BucketExpirationPolicy) DeepCopy() *BucketExpirationPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketLifecycleStatus) DeepCopyInto(out *BucketLifecycleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketLifecycleStatus.
+func (in *BucketLifecycleStatus) DeepCopy() *BucketLifecycleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketLifecycleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketList) DeepCopyInto(out *BucketList) {
 	*out = *in
@@ -119,6 +227,40 @@ func (in *BucketList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObjectLockStatus) DeepCopyInto(out *BucketObjectLockStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObjectLockStatus.
+func (in *BucketObjectLockStatus) DeepCopy() *BucketObjectLockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObjectLockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPublicAccessStatus) DeepCopyInto(out *BucketPublicAccessStatus) {
+	*out = *in
+	if in.LastDriftCorrectionTime != nil {
+		in, out := &in.LastDriftCorrectionTime, &out.LastDriftCorrectionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPublicAccessStatus.
+func (in *BucketPublicAccessStatus) DeepCopy() *BucketPublicAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPublicAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 	*out = *in
@@ -152,6 +294,58 @@ func (in *BucketSpec) DeepCopy() *BucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 	*out = *in
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(BucketAdoption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionSecretRef != nil {
+		in, out := &in.ConnectionSecretRef, &out.ConnectionSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(BucketLifecycleStatus)
+		**out = **in
+	}
+	if in.ObjectLock != nil {
+		in, out := &in.ObjectLock, &out.ObjectLock
+		*out = new(BucketObjectLockStatus)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryptionStatus)
+		**out = **in
+	}
+	if in.PublicAccess != nil {
+		in, out := &in.PublicAccess, &out.PublicAccess
+		*out = new(BucketPublicAccessStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessRole != nil {
+		in, out := &in.AccessRole, &out.AccessRole
+		*out = new(BucketAccessRoleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessGrants != nil {
+		in, out := &in.AccessGrants, &out.AccessGrants
+		*out = make([]BucketAccessGrantStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(BucketCredentialsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
@@ -178,3 +372,33 @@ func (in *BucketTag) DeepCopy() *BucketTag {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIdentityAssociation) DeepCopyInto(out *PodIdentityAssociation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIdentityAssociation.
+func (in *PodIdentityAssociation) DeepCopy() *PodIdentityAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIdentityAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
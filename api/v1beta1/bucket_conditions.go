@@ -0,0 +1,137 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types reported on a Bucket.
+const (
+	// ConditionReady summarizes all other conditions. It is true once every step of the
+	// reconciliation succeeded for the current generation.
+	ConditionReady = "Ready"
+	// ConditionBucketProvisioned reflects the creation or update of the bucket in the cloud provider.
+	ConditionBucketProvisioned = "BucketProvisioned"
+	// ConditionConfigured reflects the configuration of the bucket settings (lifecycle, policy, tags...).
+	ConditionConfigured = "Configured"
+	// ConditionAccessRoleReady reflects the configuration of the bucket access role.
+	ConditionAccessRoleReady = "AccessRoleReady"
+	// ConditionCredentialsPublished reflects the publication of the bucket credentials in a Secret.
+	ConditionCredentialsPublished = "CredentialsPublished"
+	// ConditionPrivateEndpointReady reflects the configuration of the private endpoint of the bucket.
+	ConditionPrivateEndpointReady = "PrivateEndpointReady"
+	// ConditionAccessGrantsReady reflects the role assignments of the access grants of the bucket.
+	ConditionAccessGrantsReady = "AccessGrantsReady"
+	// ConditionConflict is set to True when the bucket exists in the cloud provider but is owned by someone else,
+	// or is not managed by the operator and the adoption policy does not allow to adopt it.
+	// Unlike the other conditions, it is only set when something is wrong. It is terminal: the reconciliation
+	// stops without retrying until the bucket changes.
+	ConditionConflict = "Conflict"
+	// ConditionDeletionBlocked is set to True when the Bucket is being deleted with the Delete reclaim policy but
+	// the operator cannot delete the bucket, e.g. because of its object lock. The finalizer is kept meanwhile.
+	ConditionDeletionBlocked = "DeletionBlocked"
+)
+
+// Condition reasons reported on a Bucket.
+const (
+	ReasonReady                     = "Ready"
+	ReasonProvisioning              = "Provisioning"
+	ReasonProvisioned               = "Provisioned"
+	ReasonProvisioningFailed        = "ProvisioningFailed"
+	ReasonConfigured                = "Configured"
+	ReasonConfigurationFailed       = "ConfigurationFailed"
+	ReasonAccessRoleConfigured      = "AccessRoleConfigured"
+	ReasonAccessRoleFailed          = "AccessRoleFailed"
+	ReasonCredentialsPublished      = "CredentialsPublished"
+	ReasonCredentialsPublishFailed  = "CredentialsPublishFailed"
+	ReasonPrivateEndpointConfigured = "PrivateEndpointConfigured"
+	ReasonPrivateEndpointFailed     = "PrivateEndpointFailed"
+	ReasonAccessGrantsConfigured    = "AccessGrantsConfigured"
+	ReasonAccessGrantsFailed        = "AccessGrantsFailed"
+	ReasonConflict                  = "Conflict"
+	ReasonAdoptionRequired          = "AdoptionRequired"
+	ReasonAdopted                   = "Adopted"
+	ReasonBucketClassNotFound       = "BucketClassNotFound"
+	ReasonObjectLocked              = "ObjectLocked"
+)
+
+// MarkConditionTrue sets the given condition to True for the current generation of the bucket.
+func (b *Bucket) MarkConditionTrue(conditionType string, reason string, message string) {
+	b.setCondition(conditionType, metav1.ConditionTrue, reason, message)
+}
+
+// MarkConditionFalse sets the given condition to False for the current generation of the bucket.
+func (b *Bucket) MarkConditionFalse(conditionType string, reason string, message string) {
+	b.setCondition(conditionType, metav1.ConditionFalse, reason, message)
+}
+
+// RemoveCondition removes the given condition, typically when the step it reflects does not apply anymore.
+func (b *Bucket) RemoveCondition(conditionType string) {
+	meta.RemoveStatusCondition(&b.Status.Conditions, conditionType)
+}
+
+// GetCondition returns the condition with the given type, or nil if it is not set.
+func (b *Bucket) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(b.Status.Conditions, conditionType)
+}
+
+// IsConditionTrue returns true if the given condition is set to True.
+func (b *Bucket) IsConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(b.Status.Conditions, conditionType)
+}
+
+// SetReadyCondition computes the Ready condition out of all the other conditions.
+// The bucket is ready when every other condition is True and there is no conflict. Otherwise, the Ready
+// condition carries the reason and message of the conflict or of the first condition that is not True.
+func (b *Bucket) SetReadyCondition() {
+	if conflict := b.GetCondition(ConditionConflict); conflict != nil && conflict.Status == metav1.ConditionTrue {
+		b.MarkConditionFalse(ConditionReady, conflict.Reason, conflict.Message)
+		b.Status.BucketReady = false
+		return
+	}
+
+	for _, condition := range b.Status.Conditions {
+		if condition.Type == ConditionReady || condition.Type == ConditionConflict || condition.Status == metav1.ConditionTrue {
+			continue
+		}
+		b.MarkConditionFalse(ConditionReady, condition.Reason, condition.Message)
+		b.Status.BucketReady = false
+		return
+	}
+
+	if !b.IsConditionTrue(ConditionBucketProvisioned) {
+		// Nothing was provisioned yet, we cannot be ready.
+		b.MarkConditionFalse(ConditionReady, ReasonProvisioning, "Bucket is not provisioned yet")
+		b.Status.BucketReady = false
+		return
+	}
+
+	b.MarkConditionTrue(ConditionReady, ReasonReady, "Bucket is ready")
+	b.Status.BucketReady = true
+}
+
+func (b *Bucket) setCondition(conditionType string, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&b.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: b.Generation,
+	})
+}
@@ -0,0 +1,1132 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Finalizer needs to follow the format "domain name, a forward slash and the name of the finalizer"
+	// See https://kubernetes.io/docs/tasks/extend-kubernetes/custom-resource-definitions/#finalizers
+	BucketFinalizer      = "objectstorage.giantswarm.io/bucket"
+	AzureSecretFinalizer = "objectstorage.giantswarm.io/secret" // #nosec G101
+)
+
+// ReclaimPolicy defines what happens to the cloud provider bucket when the Bucket is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type ReclaimPolicy string
+
+const (
+	// ReclaimPolicyRetain keeps the bucket and its data in the cloud provider.
+	ReclaimPolicyRetain ReclaimPolicy = "Retain"
+	// ReclaimPolicyDelete deletes the bucket and all its data from the cloud provider.
+	ReclaimPolicyDelete ReclaimPolicy = "Delete"
+)
+
+// AdoptionPolicy defines what happens when the bucket already exists in the cloud provider but is not managed by the operator.
+// +kubebuilder:validation:Enum=Adopt;FailIfExists
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyAdopt imports the existing bucket and manages it from then on.
+	AdoptionPolicyAdopt AdoptionPolicy = "Adopt"
+	// AdoptionPolicyFailIfExists leaves the existing bucket untouched and reports a conflict.
+	AdoptionPolicyFailIfExists AdoptionPolicy = "FailIfExists"
+)
+
+// EncryptionMode defines who manages the keys used to encrypt the bucket data at rest.
+// +kubebuilder:validation:Enum=ProviderManaged;CustomerManaged
+type EncryptionMode string
+
+const (
+	// EncryptionModeProviderManaged encrypts data with keys managed by the cloud provider (SSE-S3 on AWS, Microsoft-managed keys on Azure).
+	EncryptionModeProviderManaged EncryptionMode = "ProviderManaged"
+	// EncryptionModeCustomerManaged encrypts data with a key of the customer (SSE-KMS on AWS, Key Vault keys on Azure).
+	EncryptionModeCustomerManaged EncryptionMode = "CustomerManaged"
+)
+
+// VersioningStatus defines whether the bucket keeps the previous versions of its objects.
+// +kubebuilder:validation:Enum=Enabled;Suspended
+type VersioningStatus string
+
+const (
+	// VersioningEnabled keeps the previous versions of overwritten and deleted objects.
+	VersioningEnabled VersioningStatus = "Enabled"
+	// VersioningSuspended stops creating new versions. Existing versions are kept.
+	VersioningSuspended VersioningStatus = "Suspended"
+)
+
+// AccessLevel defines the actions an access role is allowed on a bucket.
+// +kubebuilder:validation:Enum=ReadOnly;ReadWriteNoDelete;ReadWrite;Admin
+type AccessLevel string
+
+const (
+	// AccessLevelReadOnly lists and reads objects.
+	AccessLevelReadOnly AccessLevel = "ReadOnly"
+	// AccessLevelReadWriteNoDelete lists, reads and writes objects, but cannot delete them.
+	AccessLevelReadWriteNoDelete AccessLevel = "ReadWriteNoDelete"
+	// AccessLevelReadWrite lists, reads, writes and deletes objects.
+	AccessLevelReadWrite AccessLevel = "ReadWrite"
+	// AccessLevelAdmin manages objects, including their versions, tags, retention and multipart uploads. The
+	// configuration of the bucket is still managed by the operator.
+	AccessLevelAdmin AccessLevel = "Admin"
+)
+
+// AccessRoleMode defines how service accounts assume an access role.
+// +kubebuilder:validation:Enum=IRSA;PodIdentity
+type AccessRoleMode string
+
+const (
+	// AccessRoleModeIRSA trusts the service account tokens of the OIDC providers of the cluster.
+	AccessRoleModeIRSA AccessRoleMode = "IRSA"
+	// AccessRoleModePodIdentity trusts EKS Pod Identity, and associates the service accounts with the role.
+	AccessRoleModePodIdentity AccessRoleMode = "PodIdentity"
+)
+
+// PrincipalType defines the type of a Microsoft Entra ID principal.
+// +kubebuilder:validation:Enum=User;Group;ServicePrincipal
+type PrincipalType string
+
+const (
+	PrincipalTypeUser             PrincipalType = "User"
+	PrincipalTypeGroup            PrincipalType = "Group"
+	PrincipalTypeServicePrincipal PrincipalType = "ServicePrincipal"
+)
+
+// AccessGrantRole defines the Storage Blob Data role granted on an Azure container.
+// +kubebuilder:validation:Enum=Reader;Contributor;Owner
+type AccessGrantRole string
+
+const (
+	// AccessGrantRoleReader grants the Storage Blob Data Reader role, to list and read blobs.
+	AccessGrantRoleReader AccessGrantRole = "Reader"
+	// AccessGrantRoleContributor grants the Storage Blob Data Contributor role, to read, write and delete blobs.
+	AccessGrantRoleContributor AccessGrantRole = "Contributor"
+	// AccessGrantRoleOwner grants the Storage Blob Data Owner role, which also manages the ACLs of the blobs.
+	AccessGrantRoleOwner AccessGrantRole = "Owner"
+)
+
+// ObjectOwnership defines who owns the objects uploaded to an S3 bucket, and whether ACLs are enabled.
+// +kubebuilder:validation:Enum=BucketOwnerEnforced;BucketOwnerPreferred;ObjectWriter
+type ObjectOwnership string
+
+const (
+	// ObjectOwnershipBucketOwnerEnforced disables ACLs: the bucket owner owns all the objects of the bucket.
+	ObjectOwnershipBucketOwnerEnforced ObjectOwnership = "BucketOwnerEnforced"
+	// ObjectOwnershipBucketOwnerPreferred enables ACLs and gives the bucket owner the objects uploaded with the
+	// bucket-owner-full-control ACL.
+	ObjectOwnershipBucketOwnerPreferred ObjectOwnership = "BucketOwnerPreferred"
+	// ObjectOwnershipObjectWriter enables ACLs and leaves the objects to the account that uploaded them.
+	ObjectOwnershipObjectWriter ObjectOwnership = "ObjectWriter"
+)
+
+// ObjectLockMode defines how strictly the retention of the objects is enforced. GOVERNANCE and COMPLIANCE are S3
+// object lock modes, Unlocked and Locked are the states of Azure container immutability policies.
+// +kubebuilder:validation:Enum=GOVERNANCE;COMPLIANCE;Unlocked;Locked
+type ObjectLockMode string
+
+const (
+	// ObjectLockModeGovernance lets users with the s3:BypassGovernanceRetention permission delete retained objects.
+	ObjectLockModeGovernance ObjectLockMode = "GOVERNANCE"
+	// ObjectLockModeCompliance prevents anyone, including the root user of the account, from deleting retained objects.
+	ObjectLockModeCompliance ObjectLockMode = "COMPLIANCE"
+	// ObjectLockModeUnlocked applies a time-based retention policy that can still be shortened or removed.
+	ObjectLockModeUnlocked ObjectLockMode = "Unlocked"
+	// ObjectLockModeLocked applies a time-based retention policy that can only be extended.
+	ObjectLockModeLocked ObjectLockMode = "Locked"
+)
+
+// BucketObjectLock defines the write-once-read-many (WORM) retention of the objects in the bucket.
+type BucketObjectLock struct {
+	// Mode of the retention.
+	Mode ObjectLockMode `json:"mode"`
+
+	// RetentionDays is the default number of days objects are retained for after their creation.
+	// +kubebuilder:validation:Minimum=1
+	RetentionDays int32 `json:"retentionDays"`
+}
+
+// BucketLifecycleStatus records the cleanup applied to a bucket.
+type BucketLifecycleStatus struct {
+	// AbortIncompleteMultipartUploadDays is the number of days after which incomplete multipart uploads are discarded.
+	// On Azure, uncommitted blocks are always discarded after 7 days, whatever the spec says. It is empty when
+	// incomplete multipart uploads are kept forever.
+	// +optional
+	AbortIncompleteMultipartUploadDays int32 `json:"abortIncompleteMultipartUploadDays,omitempty"`
+}
+
+// BucketObjectLockStatus records the retention applied to the objects of a bucket.
+type BucketObjectLockStatus struct {
+	// Mode of the default retention. It is empty when objects are only retained on demand.
+	// +optional
+	Mode ObjectLockMode `json:"mode,omitempty"`
+
+	// RetentionDays is the default number of days objects are retained for after their creation.
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+}
+
+// BucketSpec defines the desired state of Bucket
+type BucketSpec struct {
+	// Name is the name of the bucket to create in the cloud provider.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// ReclaimPolicy defines what happens to the cloud provider bucket when the Bucket is deleted.
+	// Defaults to Retain.
+	// +optional
+	ReclaimPolicy ReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// BucketClassName is the name of the BucketClass holding the defaults of the bucket.
+	// Defaults to the BucketClass annotated as default, if any.
+	// +optional
+	BucketClassName string `json:"bucketClassName,omitempty"`
+
+	// AdoptionPolicy defines what happens when the bucket already exists in the cloud provider but was not
+	// created by the operator. Defaults to FailIfExists.
+	// +optional
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// AllowAdoptedBucketDeletion allows the Delete reclaim policy to delete an adopted bucket, including the data
+	// it held before it was adopted. Adopted buckets are retained otherwise.
+	// +optional
+	AllowAdoptedBucketDeletion bool `json:"allowAdoptedBucketDeletion,omitempty"`
+
+	// Versioning of the objects in the bucket. Versioning is left as it is when not set.
+	// +optional
+	Versioning VersioningStatus `json:"versioning,omitempty"`
+
+	// ObjectLock retains the objects of the bucket so that they cannot be overwritten nor deleted. Buckets with an object
+	// lock are never deleted by the operator. On AWS, it requires versioning and the bucket must have been created with it.
+	// +optional
+	ObjectLock *BucketObjectLock `json:"objectLock,omitempty"`
+
+	// Lifecycle of the objects in the bucket.
+	// +optional
+	Lifecycle *BucketLifecycle `json:"lifecycle,omitempty"`
+
+	// Encryption at rest of the objects in the bucket.
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// BucketPolicy defines statements added to the policy of S3 buckets, next to the statements of the operator.
+	// +optional
+	BucketPolicy *BucketPolicy `json:"bucketPolicy,omitempty"`
+
+	// Access to the bucket granted to workloads.
+	// +optional
+	Access *BucketAccess `json:"access,omitempty"`
+
+	// AccessGrants grant roles on the Azure container of the bucket to existing Microsoft Entra ID principals, e.g. a
+	// group of users browsing the logs. They are ignored on AWS.
+	// +optional
+	// +listType=map
+	// +listMapKey=principalID
+	// +kubebuilder:validation:MaxItems=100
+	AccessGrants []BucketAccessGrant `json:"accessGrants,omitempty"`
+
+	// Tags to add to the bucket.
+	// +optional
+	Tags []BucketTag `json:"tags,omitempty"`
+
+	// Parameters specific to the cloud provider.
+	// +optional
+	Parameters *BucketParameters `json:"parameters,omitempty"`
+
+	// WriteConnectionSecretToRef defines where and how the connection details of the bucket are published.
+	// Defaults to a Secret named after the bucket in the namespace of the Bucket.
+	// +optional
+	WriteConnectionSecretToRef *ConnectionSecretReference `json:"writeConnectionSecretToRef,omitempty"`
+}
+
+// ConnectionSecretReference defines the Secret holding the connection details of the bucket.
+type ConnectionSecretReference struct {
+	// Name of the Secret. Defaults to the name of the bucket.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the Secret. Defaults to the namespace of the Bucket.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Labels to add to the Secret.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to add to the Secret.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Templates are Go templates rendered into the keys of the Secret, alongside the connection details.
+	// The connection details are available by key, e.g. {{ .bucketName }}.
+	// +optional
+	Templates map[string]string `json:"templates,omitempty"`
+}
+
+// BucketLifecycle defines the lifecycle of the objects contained in the bucket.
+type BucketLifecycle struct {
+	// Expiration of all objects in the bucket.
+	// +optional
+	Expiration *BucketExpiration `json:"expiration,omitempty"`
+
+	// Rules applying to the objects matching their filter, in addition to the expiration of all objects.
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	// +kubebuilder:validation:MaxItems=100
+	Rules []BucketLifecycleRule `json:"rules,omitempty"`
+
+	// AbortIncompleteMultipartUploadDays sets a number of days after which incomplete multipart uploads are aborted.
+	// 0 keeps them forever. Defaults to the configuration of the operator. Only supported on AWS: Azure discards
+	// uncommitted blocks after 7 days, as shown in status.lifecycle.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AbortIncompleteMultipartUploadDays *int32 `json:"abortIncompleteMultipartUploadDays,omitempty"`
+}
+
+// BucketLifecycleRule defines the lifecycle of the objects matching a filter.
+type BucketLifecycleRule struct {
+	// ID of the rule, unique within the bucket. It must be alphanumeric on Azure.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	ID string `json:"id"`
+
+	// Enabled defines whether the rule is applied. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Filter selecting the objects the rule applies to. The rule applies to all objects without filter.
+	// +optional
+	Filter *BucketLifecycleFilter `json:"filter,omitempty"`
+
+	// Expiration of the objects matching the filter.
+	// +optional
+	Expiration *BucketLifecycleExpiration `json:"expiration,omitempty"`
+
+	// Transitions of the objects matching the filter to cheaper storage classes.
+	// +optional
+	// +kubebuilder:validation:MaxItems=4
+	Transitions []BucketLifecycleTransition `json:"transitions,omitempty"`
+
+	// NoncurrentVersionExpiration of the previous versions of the objects matching the filter.
+	// +optional
+	NoncurrentVersionExpiration *BucketNoncurrentVersionExpiration `json:"noncurrentVersionExpiration,omitempty"`
+
+	// ExpiredObjectDeleteMarker removes the delete markers left without any previous version. Only supported on AWS,
+	// where it cannot be combined with an expiration.
+	// +optional
+	ExpiredObjectDeleteMarker bool `json:"expiredObjectDeleteMarker,omitempty"`
+}
+
+// BucketNoncurrentVersionExpiration defines when the previous versions of objects expire.
+type BucketNoncurrentVersionExpiration struct {
+	// Days sets a number of days after they became previous versions before they expire.
+	// +kubebuilder:validation:Minimum=1
+	Days int32 `json:"days"`
+
+	// NewerNoncurrentVersions is the number of previous versions to keep whatever their age. Only supported on AWS.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	NewerNoncurrentVersions int32 `json:"newerNoncurrentVersions,omitempty"`
+}
+
+// StorageClass is the storage class, or access tier on Azure, objects transition to.
+// STANDARD_IA, INTELLIGENT_TIERING, GLACIER_IR and DEEP_ARCHIVE are supported on AWS, Cool, Cold and Archive on Azure.
+// +kubebuilder:validation:Enum=STANDARD_IA;INTELLIGENT_TIERING;GLACIER_IR;DEEP_ARCHIVE;Cool;Cold;Archive
+type StorageClass string
+
+const (
+	StorageClassStandardIA         StorageClass = "STANDARD_IA"
+	StorageClassIntelligentTiering StorageClass = "INTELLIGENT_TIERING"
+	StorageClassGlacierIR          StorageClass = "GLACIER_IR"
+	StorageClassDeepArchive        StorageClass = "DEEP_ARCHIVE"
+	StorageClassCool               StorageClass = "Cool"
+	StorageClassCold               StorageClass = "Cold"
+	StorageClassArchive            StorageClass = "Archive"
+)
+
+// BucketLifecycleTransition defines when the objects matching a rule move to another storage class.
+type BucketLifecycleTransition struct {
+	// Days sets a number of days after their creation before the objects transition.
+	// +kubebuilder:validation:Minimum=1
+	Days int32 `json:"days"`
+
+	// StorageClass the objects transition to.
+	StorageClass StorageClass `json:"storageClass"`
+}
+
+// BucketLifecycleFilter selects objects by prefix and tags. Objects must match all of them.
+type BucketLifecycleFilter struct {
+	// Prefix of the keys of the objects.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Tags of the objects (blob index tags on Azure).
+	// +optional
+	Tags []BucketTag `json:"tags,omitempty"`
+}
+
+// BucketLifecycleExpiration defines when the objects matching a rule expire, either after a number of days or on a date.
+type BucketLifecycleExpiration struct {
+	// Days sets a number of days after their creation before the objects expire.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Days int32 `json:"days,omitempty"`
+
+	// Date on which the objects expire, formatted as YYYY-MM-DD. Only supported on AWS.
+	// +kubebuilder:validation:Format=date
+	// +optional
+	Date string `json:"date,omitempty"`
+}
+
+// BucketExpiration defines when objects contained in the bucket expire.
+type BucketExpiration struct {
+	// Days sets a number of days before the data expires.
+	// +kubebuilder:validation:Minimum=1
+	Days int32 `json:"days"`
+}
+
+// BucketEncryption defines the encryption at rest of the bucket.
+type BucketEncryption struct {
+	// Mode of the encryption.
+	// +kubebuilder:default=ProviderManaged
+	// +optional
+	Mode EncryptionMode `json:"mode,omitempty"`
+
+	// KMS key encrypting the objects with the CustomerManaged mode on AWS.
+	// +optional
+	KMS *KMSEncryption `json:"kms,omitempty"`
+
+	// KeyVault key encrypting the storage account with the CustomerManaged mode on Azure.
+	// +optional
+	KeyVault *KeyVaultEncryption `json:"keyVault,omitempty"`
+}
+
+// KMSEncryption defines the AWS KMS key of SSE-KMS encryption.
+type KMSEncryption struct {
+	// KeyARN is the ARN of the KMS key. Access roles of the bucket are allowed to use it.
+	KeyARN string `json:"keyArn"`
+
+	// BucketKeyEnabled uses an S3 Bucket Key to reduce the number of requests to KMS.
+	// +optional
+	BucketKeyEnabled bool `json:"bucketKeyEnabled,omitempty"`
+}
+
+// KeyVaultEncryption defines the Azure Key Vault key of customer-managed key encryption.
+type KeyVaultEncryption struct {
+	// KeyURI is the URI of the key, e.g. https://my-vault.vault.azure.net/keys/my-key. The latest version of the key is
+	// used when the URI does not end with a version.
+	KeyURI string `json:"keyUri"`
+
+	// UserAssignedIdentityID is the resource ID of the user-assigned managed identity the storage account reads the key
+	// with. It needs the Key Vault Crypto Service Encryption User role on the key.
+	UserAssignedIdentityID string `json:"userAssignedIdentityId"`
+}
+
+// BucketEncryptionStatus records the encryption at rest applied to a bucket.
+type BucketEncryptionStatus struct {
+	// Mode of the encryption.
+	// +optional
+	Mode EncryptionMode `json:"mode,omitempty"`
+
+	// KeyID identifies the customer-managed key: the ARN of the KMS key on AWS, or the URI of the Key Vault key on Azure.
+	// +optional
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// BucketAccess defines how workloads access the bucket.
+type BucketAccess struct {
+	// Role that can be assumed by workloads to access the bucket.
+	// +optional
+	Role *BucketAccessRole `json:"role,omitempty"`
+}
+
+// BucketAccessRole defines the bucket access role to create in the cloud account.
+type BucketAccessRole struct {
+	// Name of the role to create.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Mode of the role: IRSA or PodIdentity. Defaults to IRSA.
+	// +optional
+	Mode AccessRoleMode `json:"mode,omitempty"`
+
+	// ServiceAccount allowed to assume the role.
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+
+	// ServiceAccounts are additional service accounts allowed to assume the role. Their namespace and name may contain
+	// the * and ? wildcards, e.g. to trust all the service accounts of a namespace, except in the PodIdentity mode.
+	// +optional
+	ServiceAccounts []ServiceAccountReference `json:"serviceAccounts,omitempty"`
+
+	// OIDCProviders whose tokens the service accounts use to assume the role in the IRSA mode. They override the OIDC
+	// providers of the management cluster, and all of them are trusted, e.g. the previous and the new issuer during an
+	// issuer migration.
+	// +optional
+	// +listType=map
+	// +listMapKey=issuer
+	OIDCProviders []OIDCProvider `json:"oidcProviders,omitempty"`
+
+	// Description of the role. Defaults to the description set on the operator.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1000
+	Description string `json:"description,omitempty"`
+
+	// Path of the role, e.g. /giantswarm/. Defaults to the path set on the operator. It only applies to new roles, as
+	// the path of an existing role cannot change.
+	// +optional
+	// +kubebuilder:validation:MaxLength=512
+	Path string `json:"path,omitempty"`
+
+	// PermissionsBoundary is the ARN of the managed policy setting the maximum permissions of the role. Defaults to the
+	// permissions boundary set on the operator, and must be one of the permissions boundaries allowed by the operator.
+	// +optional
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+
+	// MaxSessionDuration of the sessions of the role, between 1h and 12h. Defaults to the maximum session duration set
+	// on the operator.
+	// +optional
+	MaxSessionDuration *metav1.Duration `json:"maxSessionDuration,omitempty"`
+
+	// ManagedPolicies are the ARNs of the managed policies attached to the role, e.g.
+	// arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess. They replace the managed policies set on the operator, and must be
+	// among them or among the managed policies allowed by the operator.
+	// +optional
+	// +listType=set
+	ManagedPolicies []string `json:"managedPolicies,omitempty"`
+
+	// Permissions of the role on the bucket.
+	BucketPermissions `json:",inline"`
+
+	// ExtraBucketNames is a list of bucket names to add to the role policy in case the role needs to be able to access multiple buckets.
+	// The role has the ReadWrite access level on them.
+	// +optional
+	ExtraBucketNames []string `json:"extraBucketNames,omitempty"`
+
+	// ExtraBuckets are other buckets the role can access, with their own permissions.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ExtraBuckets []ExtraBucketPermissions `json:"extraBuckets,omitempty"`
+}
+
+// BucketPermissions defines what an access role can do on a bucket.
+type BucketPermissions struct {
+	// AccessLevel of the role on the bucket. Defaults to ReadWrite.
+	// +optional
+	AccessLevel AccessLevel `json:"accessLevel,omitempty"`
+
+	// Prefixes restricts the access to the objects whose key starts with one of them, e.g. logs/. Defaults to all the
+	// objects of the bucket.
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// ExtraActions are S3 actions allowed on top of the access level, e.g. s3:ListBucketMultipartUploads.
+	// +optional
+	ExtraActions []string `json:"extraActions,omitempty"`
+}
+
+// ExtraBucketPermissions defines what an access role can do on another bucket.
+type ExtraBucketPermissions struct {
+	// Name of the bucket.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Permissions of the role on the bucket.
+	BucketPermissions `json:",inline"`
+}
+
+// BucketAccessGrant grants a role on the container of the bucket to a Microsoft Entra ID principal.
+type BucketAccessGrant struct {
+	// PrincipalID is the object ID of the user, group or service principal.
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	PrincipalID string `json:"principalID"`
+
+	// PrincipalType is the type of the principal: User, Group or ServicePrincipal.
+	PrincipalType PrincipalType `json:"principalType"`
+
+	// Role granted on the container: Reader, Contributor or Owner.
+	Role AccessGrantRole `json:"role"`
+}
+
+// OIDCProvider is an OpenID Connect provider issuing service account tokens.
+type OIDCProvider struct {
+	// Issuer URL of the provider, e.g. https://oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE.
+	// An IAM OIDC provider must exist for it in the account of the management cluster.
+	// +kubebuilder:validation:Pattern=`^https://`
+	Issuer string `json:"issuer"`
+
+	// Audiences the tokens must be issued for, e.g. sts.amazonaws.com. Tokens of any audience are accepted when empty.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// ServiceAccountReference references a Kubernetes service account.
+type ServiceAccountReference struct {
+	// Name of the service account.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the service account.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// PolicyEffect defines whether a policy statement allows or denies the access.
+// +kubebuilder:validation:Enum=Allow;Deny
+type PolicyEffect string
+
+const (
+	PolicyEffectAllow PolicyEffect = "Allow"
+	PolicyEffectDeny  PolicyEffect = "Deny"
+)
+
+// BucketPolicy defines statements added to the policy of an S3 bucket. The statements of the operator, like
+// EnforceSSLOnly, are always kept: custom statements are appended to them and cannot replace them.
+type BucketPolicy struct {
+	// Statements added to the bucket policy.
+	// +optional
+	// +listType=map
+	// +listMapKey=sid
+	Statements []BucketPolicyStatement `json:"statements,omitempty"`
+
+	// RawStatements is a JSON array of IAM policy statements added to the bucket policy, for the statements the
+	// structured ones cannot express. It is validated at admission.
+	// +optional
+	RawStatements string `json:"rawStatements,omitempty"`
+}
+
+// BucketPolicyStatement defines a statement of an S3 bucket policy.
+type BucketPolicyStatement struct {
+	// Sid identifies the statement in the bucket policy.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]+$`
+	Sid string `json:"sid"`
+
+	// Effect of the statement.
+	Effect PolicyEffect `json:"effect"`
+
+	// Principal the statement applies to.
+	Principal BucketPolicyPrincipal `json:"principal"`
+
+	// Actions of the statement, e.g. s3:GetObject.
+	// +kubebuilder:validation:MinItems=1
+	Actions []string `json:"actions"`
+
+	// Resources of the statement. They must be the bucket or objects of the bucket. Defaults to the bucket and all
+	// its objects.
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+
+	// Conditions of the statement.
+	// +optional
+	Conditions []BucketPolicyCondition `json:"conditions,omitempty"`
+}
+
+// BucketPolicyPrincipal defines the principals of a bucket policy statement. At least one principal must be set.
+type BucketPolicyPrincipal struct {
+	// AWS principals: account IDs, ARNs of accounts, roles or users, or * for everyone.
+	// +optional
+	AWS []string `json:"aws,omitempty"`
+
+	// Service principals, e.g. logging.s3.amazonaws.com.
+	// +optional
+	Service []string `json:"service,omitempty"`
+}
+
+// BucketPolicyCondition defines a condition of a bucket policy statement.
+type BucketPolicyCondition struct {
+	// Operator of the condition, e.g. StringEquals or Bool.
+	Operator string `json:"operator"`
+
+	// Key of the condition, e.g. aws:SourceVpce.
+	Key string `json:"key"`
+
+	// Values the key is compared to.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// BucketParameters defines the settings of the bucket that are specific to a cloud provider.
+// Parameters of another provider than the one of the management cluster are ignored.
+type BucketParameters struct {
+	// AWS parameters.
+	// +optional
+	AWS *AWSBucketParameters `json:"aws,omitempty"`
+
+	// Azure parameters.
+	// +optional
+	Azure *AzureBucketParameters `json:"azure,omitempty"`
+}
+
+// AWSBucketParameters defines the settings of S3 buckets.
+type AWSBucketParameters struct {
+	// EnforceSSLOnly denies the requests to the bucket that are not sent over TLS. Defaults to true.
+	// +optional
+	EnforceSSLOnly *bool `json:"enforceSSLOnly,omitempty"`
+
+	// BlockPublicAccess enables the four S3 Block Public Access settings of the bucket, so that neither ACLs nor
+	// bucket policies can make it public. Defaults to true.
+	// +optional
+	BlockPublicAccess *bool `json:"blockPublicAccess,omitempty"`
+
+	// ObjectOwnership of the objects uploaded to the bucket. Defaults to BucketOwnerEnforced, which disables ACLs.
+	// +optional
+	ObjectOwnership ObjectOwnership `json:"objectOwnership,omitempty"`
+}
+
+// AzureSKU is the SKU of an Azure storage account.
+// +kubebuilder:validation:Enum=Standard_LRS;Standard_GRS;Standard_RAGRS
+type AzureSKU string
+
+const (
+	AzureSKUStandardLRS   AzureSKU = "Standard_LRS"
+	AzureSKUStandardGRS   AzureSKU = "Standard_GRS"
+	AzureSKUStandardRAGRS AzureSKU = "Standard_RAGRS"
+)
+
+// AzureAccessTier is the default access tier of the blobs of an Azure storage account.
+// +kubebuilder:validation:Enum=Hot;Cool
+type AzureAccessTier string
+
+const (
+	AzureAccessTierHot  AzureAccessTier = "Hot"
+	AzureAccessTierCool AzureAccessTier = "Cool"
+)
+
+// AzureCredentialsType is the type of the credentials published in the connection Secret of an Azure bucket.
+// +kubebuilder:validation:Enum=AccountKey;ServiceSAS;UserDelegationSAS
+type AzureCredentialsType string
+
+const (
+	// AzureCredentialsTypeAccountKey publishes the key of the storage account, which grants full access to the storage
+	// account and never expires.
+	AzureCredentialsTypeAccountKey AzureCredentialsType = "AccountKey"
+	// AzureCredentialsTypeServiceSAS publishes a SAS token of the container signed with the key of the storage account.
+	AzureCredentialsTypeServiceSAS AzureCredentialsType = "ServiceSAS"
+	// AzureCredentialsTypeUserDelegationSAS publishes a SAS token of the container signed with a user delegation key
+	// of the identity of the operator, which works without the key of the storage account.
+	AzureCredentialsTypeUserDelegationSAS AzureCredentialsType = "UserDelegationSAS"
+)
+
+// DefaultSASLifetime is the lifetime of the SAS tokens published for Azure buckets that do not set one.
+const DefaultSASLifetime = 24 * time.Hour
+
+// AzureBucketParameters defines the settings of Azure storage accounts.
+type AzureBucketParameters struct {
+	// SKU of the storage account. Defaults to Standard_LRS.
+	// +optional
+	SKU AzureSKU `json:"sku,omitempty"`
+
+	// AccessTier of the storage account. Defaults to Hot.
+	// +optional
+	AccessTier AzureAccessTier `json:"accessTier,omitempty"`
+
+	// Credentials published in the connection Secret. Defaults to the key of the storage account.
+	// +optional
+	Credentials *AzureBucketCredentials `json:"credentials,omitempty"`
+}
+
+// AzureBucketCredentials defines the credentials published in the connection Secret of an Azure bucket.
+type AzureBucketCredentials struct {
+	// Type of the credentials: the key of the storage account (AccountKey), or a SAS token of the container signed
+	// with that key (ServiceSAS) or with a user delegation key of the operator (UserDelegationSAS). Defaults to
+	// AccountKey.
+	// +optional
+	Type AzureCredentialsType `json:"type,omitempty"`
+
+	// AccessLevel granted by the SAS token. Defaults to ReadWrite.
+	// +optional
+	AccessLevel AccessLevel `json:"accessLevel,omitempty"`
+
+	// Lifetime of the SAS token, between 1h and 7 days. Defaults to 24h. The token is renewed when a third of its
+	// lifetime is left.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+}
+
+// BucketTag defines the type for bucket tags
+type BucketTag struct {
+	// Key is the key of the bucket tag to add to the bucket.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value is the value of the bucket tag to add to the bucket.
+	Value string `json:"value"`
+}
+
+// BucketStatus defines the observed state of Bucket
+type BucketStatus struct {
+	// BucketReady is a boolean condition to reflect the successful creation
+	// of a bucket.
+	// Deprecated: use the Ready condition instead.
+	// +optional
+	BucketReady bool `json:"bucketReady,omitempty"`
+
+	// BucketID is the unique id of the bucket.
+	// +optional
+	BucketID string `json:"bucketID,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// BucketClassName is the name of the BucketClass applied to the bucket.
+	// +optional
+	BucketClassName string `json:"bucketClassName,omitempty"`
+
+	// Adoption records the state of the bucket when it was adopted. It is only set for buckets that existed before
+	// the operator managed them.
+	// +optional
+	Adoption *BucketAdoption `json:"adoption,omitempty"`
+
+	// ConnectionSecretRef references the Secret the connection details of the bucket are published in.
+	// +optional
+	ConnectionSecretRef *SecretReference `json:"connectionSecretRef,omitempty"`
+
+	// Lifecycle is the cleanup applied to the bucket by the cloud provider.
+	// +optional
+	Lifecycle *BucketLifecycleStatus `json:"lifecycle,omitempty"`
+
+	// ObjectLock is the retention applied to the objects of the bucket. It is only set for buckets with an object lock.
+	// +optional
+	ObjectLock *BucketObjectLockStatus `json:"objectLock,omitempty"`
+
+	// Encryption is the encryption at rest applied to the bucket.
+	// +optional
+	Encryption *BucketEncryptionStatus `json:"encryption,omitempty"`
+
+	// PublicAccess is the public access protection applied to the bucket. It is only set for S3 buckets.
+	// +optional
+	PublicAccess *BucketPublicAccessStatus `json:"publicAccess,omitempty"`
+
+	// AccessRole is the access role configured for the bucket.
+	// +optional
+	AccessRole *BucketAccessRoleStatus `json:"accessRole,omitempty"`
+
+	// AccessGrants are the role assignments made on the Azure container for spec.accessGrants.
+	// +optional
+	AccessGrants []BucketAccessGrantStatus `json:"accessGrants,omitempty"`
+
+	// Credentials records the expiring credentials published in the connection Secret.
+	// +optional
+	Credentials *BucketCredentialsStatus `json:"credentials,omitempty"`
+
+	// Conditions represent the latest available observations of the bucket state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// BucketAccessRoleStatus records the access role configured for a bucket.
+type BucketAccessRoleStatus struct {
+	// ARN of the IAM role.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// Mode of the role.
+	// +optional
+	Mode AccessRoleMode `json:"mode,omitempty"`
+
+	// PodIdentityAssociations are the EKS Pod Identity associations of the service accounts with the role.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
+
+	// ManagedPolicies are the ARNs of the managed policies the operator attached to the IAM role. Policies attached
+	// outside of the Bucket are not listed and are left untouched.
+	// +optional
+	ManagedPolicies []string `json:"managedPolicies,omitempty"`
+
+	// ClientID of the Azure managed identity, which workloads use to authenticate.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// PrincipalID of the Azure managed identity, which the role assignments are granted to.
+	// +optional
+	PrincipalID string `json:"principalID,omitempty"`
+
+	// TenantID of the Azure managed identity.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// BucketAccessGrantStatus records the role assignment made for an access grant.
+type BucketAccessGrantStatus struct {
+	// PrincipalID is the object ID of the principal.
+	PrincipalID string `json:"principalID"`
+
+	// Role granted on the container.
+	Role AccessGrantRole `json:"role"`
+
+	// RoleAssignmentID is the resource ID of the role assignment made by the operator. It is empty when the principal
+	// already had the role on the container, in which case the assignment is left untouched.
+	// +optional
+	RoleAssignmentID string `json:"roleAssignmentID,omitempty"`
+}
+
+// BucketCredentialsStatus records the expiring credentials published in the connection Secret of the bucket.
+type BucketCredentialsStatus struct {
+	// Type of the credentials.
+	Type AzureCredentialsType `json:"type"`
+
+	// ExpiresAt is the time the credentials expire.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// RenewAt is the time the operator renews the credentials.
+	RenewAt metav1.Time `json:"renewAt"`
+}
+
+// PodIdentityAssociation records the EKS Pod Identity association of a service account with an access role.
+type PodIdentityAssociation struct {
+	// ID of the association.
+	ID string `json:"id"`
+
+	// ServiceAccount associated with the role.
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+}
+
+// BucketPublicAccessStatus records the S3 Block Public Access settings and the object ownership of a bucket.
+type BucketPublicAccessStatus struct {
+	// BlockPublicAcls rejects the requests setting public ACLs.
+	BlockPublicAcls bool `json:"blockPublicAcls"`
+
+	// IgnorePublicAcls ignores the public ACLs of the bucket and its objects.
+	IgnorePublicAcls bool `json:"ignorePublicAcls"`
+
+	// BlockPublicPolicy rejects the bucket policies granting public access.
+	BlockPublicPolicy bool `json:"blockPublicPolicy"`
+
+	// RestrictPublicBuckets restricts the access to a bucket with a public policy to AWS services and the bucket owner.
+	RestrictPublicBuckets bool `json:"restrictPublicBuckets"`
+
+	// ObjectOwnership of the objects uploaded to the bucket.
+	// +optional
+	ObjectOwnership ObjectOwnership `json:"objectOwnership,omitempty"`
+
+	// LastDriftCorrectionTime is the last time the operator reverted a change made to these settings outside of the
+	// Bucket.
+	// +optional
+	LastDriftCorrectionTime *metav1.Time `json:"lastDriftCorrectionTime,omitempty"`
+}
+
+// BucketAdoption records what pre-existed when the operator adopted a bucket.
+type BucketAdoption struct {
+	// AdoptedAt is the time the operator adopted the bucket.
+	AdoptedAt metav1.Time `json:"adoptedAt"`
+
+	// Tags the bucket had when it was adopted. They are kept on the bucket alongside the tags of the spec.
+	// +optional
+	Tags []BucketTag `json:"tags,omitempty"`
+}
+
+// SecretReference references a Secret.
+type SecretReference struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Namespace of the Secret.
+	Namespace string `json:"namespace"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Bucket",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Class",type=string,JSONPath=`.status.bucketClassName`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Bucket is the Schema for the buckets API
+type Bucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketSpec   `json:"spec,omitempty"`
+	Status BucketStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BucketList contains a list of Bucket
+type BucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Bucket `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Bucket{}, &BucketList{})
+}
+
+// AccessRole returns the access role of the bucket, or nil if the bucket does not have one.
+func (b *Bucket) AccessRole() *BucketAccessRole {
+	if b.Spec.Access == nil || b.Spec.Access.Role == nil || b.Spec.Access.Role.Name == "" {
+		return nil
+	}
+	return b.Spec.Access.Role
+}
+
+// AccessRoleMode returns the mode of the access role of the bucket.
+func (b *Bucket) AccessRoleMode() AccessRoleMode {
+	if accessRole := b.AccessRole(); accessRole != nil && accessRole.Mode != "" {
+		return accessRole.Mode
+	}
+	return AccessRoleModeIRSA
+}
+
+// ExpirationDays returns the number of days before objects expire, or nil if they never expire.
+func (b *Bucket) ExpirationDays() *int32 {
+	if b.Spec.Lifecycle == nil || b.Spec.Lifecycle.Expiration == nil {
+		return nil
+	}
+	return &b.Spec.Lifecycle.Expiration.Days
+}
+
+// AbortIncompleteMultipartUploadDays returns the number of days after which incomplete multipart uploads are aborted,
+// falling back to the given default. 0 means they are never aborted.
+func (b *Bucket) AbortIncompleteMultipartUploadDays(defaultDays int32) int32 {
+	if b.Spec.Lifecycle == nil || b.Spec.Lifecycle.AbortIncompleteMultipartUploadDays == nil {
+		return defaultDays
+	}
+	return *b.Spec.Lifecycle.AbortIncompleteMultipartUploadDays
+}
+
+// EncryptionMode returns the mode of the encryption at rest of the bucket.
+func (b *Bucket) EncryptionMode() EncryptionMode {
+	if b.Spec.Encryption == nil || b.Spec.Encryption.Mode == "" {
+		return EncryptionModeProviderManaged
+	}
+	return b.Spec.Encryption.Mode
+}
+
+// KMSKeyARN returns the ARN of the KMS key encrypting the bucket, or an empty string if the bucket is not encrypted with
+// a customer-managed KMS key.
+func (b *Bucket) KMSKeyARN() string {
+	if b.EncryptionMode() != EncryptionModeCustomerManaged || b.Spec.Encryption.KMS == nil {
+		return ""
+	}
+	return b.Spec.Encryption.KMS.KeyARN
+}
+
+// IsObjectLocked returns true if the objects of the bucket are, or may be, retained by an object lock.
+func (b *Bucket) IsObjectLocked() bool {
+	return b.Spec.ObjectLock != nil || b.Status.ObjectLock != nil
+}
+
+// IsAdopted returns true if the bucket existed before the operator managed it.
+func (b *Bucket) IsAdopted() bool {
+	return b.Status.Adoption != nil
+}
+
+// IsManaged returns true if the operator already manages the cloud provider bucket, either because it created it
+// or because it adopted it.
+func (b *Bucket) IsManaged() bool {
+	return b.Status.BucketID != "" || b.IsAdopted()
+}
+
+// EnforceSSLOnly returns true if the requests to the bucket that are not sent over TLS must be denied.
+func (b *Bucket) EnforceSSLOnly() bool {
+	if b.Spec.Parameters == nil || b.Spec.Parameters.AWS == nil || b.Spec.Parameters.AWS.EnforceSSLOnly == nil {
+		return true
+	}
+	return *b.Spec.Parameters.AWS.EnforceSSLOnly
+}
+
+// BlockPublicAccess returns true if the S3 Block Public Access settings of the bucket must be enabled.
+func (b *Bucket) BlockPublicAccess() bool {
+	if b.Spec.Parameters == nil || b.Spec.Parameters.AWS == nil || b.Spec.Parameters.AWS.BlockPublicAccess == nil {
+		return true
+	}
+	return *b.Spec.Parameters.AWS.BlockPublicAccess
+}
+
+// ObjectOwnership returns the object ownership of the S3 bucket.
+func (b *Bucket) ObjectOwnership() ObjectOwnership {
+	if b.Spec.Parameters == nil || b.Spec.Parameters.AWS == nil || b.Spec.Parameters.AWS.ObjectOwnership == "" {
+		return ObjectOwnershipBucketOwnerEnforced
+	}
+	return b.Spec.Parameters.AWS.ObjectOwnership
+}
+
+// AzureSKU returns the SKU of the storage account of the bucket.
+func (b *Bucket) AzureSKU() AzureSKU {
+	if b.Spec.Parameters == nil || b.Spec.Parameters.Azure == nil || b.Spec.Parameters.Azure.SKU == "" {
+		return AzureSKUStandardLRS
+	}
+	return b.Spec.Parameters.Azure.SKU
+}
+
+// AzureAccessTier returns the access tier of the storage account of the bucket.
+func (b *Bucket) AzureAccessTier() AzureAccessTier {
+	if b.Spec.Parameters == nil || b.Spec.Parameters.Azure == nil || b.Spec.Parameters.Azure.AccessTier == "" {
+		return AzureAccessTierHot
+	}
+	return b.Spec.Parameters.Azure.AccessTier
+}
+
+// AzureCredentials returns the credentials published in the connection Secret of the Azure bucket, with their
+// defaults.
+func (b *Bucket) AzureCredentials() AzureBucketCredentials {
+	credentials := AzureBucketCredentials{}
+	if b.Spec.Parameters != nil && b.Spec.Parameters.Azure != nil && b.Spec.Parameters.Azure.Credentials != nil {
+		credentials = *b.Spec.Parameters.Azure.Credentials.DeepCopy()
+	}
+	if credentials.Type == "" {
+		credentials.Type = AzureCredentialsTypeAccountKey
+	}
+	if credentials.AccessLevel == "" {
+		credentials.AccessLevel = AccessLevelReadWrite
+	}
+	if credentials.Lifetime == nil {
+		credentials.Lifetime = &metav1.Duration{Duration: DefaultSASLifetime}
+	}
+	return credentials
+}
+
+// ConnectionSecret returns the Secret the connection details of the bucket are published in.
+func (b *Bucket) ConnectionSecret() SecretReference {
+	ref := SecretReference{Name: b.Spec.Name, Namespace: b.Namespace}
+	if b.Spec.WriteConnectionSecretToRef != nil {
+		if b.Spec.WriteConnectionSecretToRef.Name != "" {
+			ref.Name = b.Spec.WriteConnectionSecretToRef.Name
+		}
+		if b.Spec.WriteConnectionSecretToRef.Namespace != "" {
+			ref.Namespace = b.Spec.WriteConnectionSecretToRef.Namespace
+		}
+	}
+	return ref
+}
+
+// IsEnabled returns true if the lifecycle rule is applied.
+func (r BucketLifecycleRule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// LifecycleRules returns the lifecycle rules of the bucket.
+func (b *Bucket) LifecycleRules() []BucketLifecycleRule {
+	if b.Spec.Lifecycle == nil {
+		return nil
+	}
+	return b.Spec.Lifecycle.Rules
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ApplyBucketClass merges the defaults of the class into the spec of the bucket, which becomes the effective spec.
+// Fields set on the bucket win: the reclaim policy, lifecycle and encryption of the class are only used when the
+// bucket does not set them, tags are merged by key and provider parameters are merged field by field.
+// The class is recorded in the status, but the effective spec is only meant to be used in memory and must never be
+// written back to the Bucket.
+func (b *Bucket) ApplyBucketClass(class *BucketClass) {
+	b.Status.BucketClassName = class.Name
+	classSpec := class.Spec.DeepCopy()
+
+	if b.Spec.ReclaimPolicy == "" {
+		b.Spec.ReclaimPolicy = classSpec.ReclaimPolicy
+	}
+	if b.Spec.Lifecycle == nil {
+		b.Spec.Lifecycle = classSpec.Lifecycle
+	}
+	if b.Spec.Encryption == nil {
+		b.Spec.Encryption = classSpec.Encryption
+	}
+
+	if len(classSpec.Tags) > 0 {
+		keys := make(map[string]bool, len(b.Spec.Tags))
+		for _, tag := range b.Spec.Tags {
+			keys[tag.Key] = true
+		}
+		tags := make([]BucketTag, 0, len(classSpec.Tags)+len(b.Spec.Tags))
+		for _, tag := range classSpec.Tags {
+			if !keys[tag.Key] {
+				tags = append(tags, tag)
+			}
+		}
+		b.Spec.Tags = append(tags, b.Spec.Tags...)
+	}
+
+	b.Spec.Parameters = mergeParameters(b.Spec.Parameters, classSpec.Parameters)
+}
+
+func mergeParameters(parameters *BucketParameters, defaults *BucketParameters) *BucketParameters {
+	if defaults == nil {
+		return parameters
+	}
+	if parameters == nil {
+		return defaults
+	}
+
+	if parameters.AWS == nil {
+		parameters.AWS = defaults.AWS
+	} else if defaults.AWS != nil {
+		if parameters.AWS.EnforceSSLOnly == nil {
+			parameters.AWS.EnforceSSLOnly = defaults.AWS.EnforceSSLOnly
+		}
+		if parameters.AWS.BlockPublicAccess == nil {
+			parameters.AWS.BlockPublicAccess = defaults.AWS.BlockPublicAccess
+		}
+		if parameters.AWS.ObjectOwnership == "" {
+			parameters.AWS.ObjectOwnership = defaults.AWS.ObjectOwnership
+		}
+	}
+
+	if parameters.Azure == nil {
+		parameters.Azure = defaults.Azure
+	} else if defaults.Azure != nil {
+		if parameters.Azure.SKU == "" {
+			parameters.Azure.SKU = defaults.Azure.SKU
+		}
+		if parameters.Azure.AccessTier == "" {
+			parameters.Azure.AccessTier = defaults.Azure.AccessTier
+		}
+		if parameters.Azure.Credentials == nil {
+			parameters.Azure.Credentials = defaults.Azure.Credentials
+		}
+	}
+	return parameters
+}
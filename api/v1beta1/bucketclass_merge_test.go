@@ -0,0 +1,154 @@
+package v1beta1
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ApplyBucketClass(t *testing.T) {
+	enforceSSLOnly := false
+	blockPublicAccess := false
+
+	testCases := []struct {
+		name         string
+		spec         BucketSpec
+		classSpec    BucketClassSpec
+		expectedSpec BucketSpec
+	}{
+		{
+			name:         "case 0: empty class",
+			spec:         BucketSpec{Name: "loki", ReclaimPolicy: ReclaimPolicyDelete},
+			classSpec:    BucketClassSpec{},
+			expectedSpec: BucketSpec{Name: "loki", ReclaimPolicy: ReclaimPolicyDelete},
+		},
+		{
+			name: "case 1: bucket without settings gets the class defaults",
+			spec: BucketSpec{Name: "loki"},
+			classSpec: BucketClassSpec{
+				ReclaimPolicy: ReclaimPolicyDelete,
+				Lifecycle:     &BucketLifecycle{Expiration: &BucketExpiration{Days: 30}},
+				Encryption:    &BucketEncryption{Mode: EncryptionModeProviderManaged},
+				Tags:          []BucketTag{{Key: "retention", Value: "short"}},
+				Parameters: &BucketParameters{
+					Azure: &AzureBucketParameters{SKU: AzureSKUStandardGRS, AccessTier: AzureAccessTierCool},
+				},
+			},
+			expectedSpec: BucketSpec{
+				Name:          "loki",
+				ReclaimPolicy: ReclaimPolicyDelete,
+				Lifecycle:     &BucketLifecycle{Expiration: &BucketExpiration{Days: 30}},
+				Encryption:    &BucketEncryption{Mode: EncryptionModeProviderManaged},
+				Tags:          []BucketTag{{Key: "retention", Value: "short"}},
+				Parameters: &BucketParameters{
+					Azure: &AzureBucketParameters{SKU: AzureSKUStandardGRS, AccessTier: AzureAccessTierCool},
+				},
+			},
+		},
+		{
+			name: "case 2: bucket settings win over the class",
+			spec: BucketSpec{
+				Name:          "loki",
+				ReclaimPolicy: ReclaimPolicyRetain,
+				Lifecycle:     &BucketLifecycle{Expiration: &BucketExpiration{Days: 100}},
+				Tags:          []BucketTag{{Key: "retention", Value: "long"}, {Key: "app", Value: "loki"}},
+				Parameters: &BucketParameters{
+					Azure: &AzureBucketParameters{AccessTier: AzureAccessTierHot},
+				},
+			},
+			classSpec: BucketClassSpec{
+				ReclaimPolicy: ReclaimPolicyDelete,
+				Lifecycle:     &BucketLifecycle{Expiration: &BucketExpiration{Days: 30}},
+				Tags:          []BucketTag{{Key: "retention", Value: "short"}, {Key: "team", Value: "atlas"}},
+				Parameters: &BucketParameters{
+					AWS:   &AWSBucketParameters{EnforceSSLOnly: &enforceSSLOnly},
+					Azure: &AzureBucketParameters{SKU: AzureSKUStandardGRS, AccessTier: AzureAccessTierCool},
+				},
+			},
+			expectedSpec: BucketSpec{
+				Name:          "loki",
+				ReclaimPolicy: ReclaimPolicyRetain,
+				Lifecycle:     &BucketLifecycle{Expiration: &BucketExpiration{Days: 100}},
+				Tags:          []BucketTag{{Key: "team", Value: "atlas"}, {Key: "retention", Value: "long"}, {Key: "app", Value: "loki"}},
+				Parameters: &BucketParameters{
+					AWS:   &AWSBucketParameters{EnforceSSLOnly: &enforceSSLOnly},
+					Azure: &AzureBucketParameters{SKU: AzureSKUStandardGRS, AccessTier: AzureAccessTierHot},
+				},
+			},
+		},
+		{
+			name: "case 3: AWS parameters merged field by field",
+			spec: BucketSpec{
+				Name: "loki",
+				Parameters: &BucketParameters{
+					AWS: &AWSBucketParameters{ObjectOwnership: ObjectOwnershipBucketOwnerPreferred},
+				},
+			},
+			classSpec: BucketClassSpec{
+				Parameters: &BucketParameters{
+					AWS: &AWSBucketParameters{
+						EnforceSSLOnly:    &enforceSSLOnly,
+						BlockPublicAccess: &blockPublicAccess,
+						ObjectOwnership:   ObjectOwnershipObjectWriter,
+					},
+				},
+			},
+			expectedSpec: BucketSpec{
+				Name: "loki",
+				Parameters: &BucketParameters{
+					AWS: &AWSBucketParameters{
+						EnforceSSLOnly:    &enforceSSLOnly,
+						BlockPublicAccess: &blockPublicAccess,
+						ObjectOwnership:   ObjectOwnershipBucketOwnerPreferred,
+					},
+				},
+			},
+		},
+		{
+			name: "case 4: Azure credentials of the class are used when the bucket does not set them",
+			spec: BucketSpec{
+				Name: "loki",
+				Parameters: &BucketParameters{
+					Azure: &AzureBucketParameters{SKU: AzureSKUStandardGRS},
+				},
+			},
+			classSpec: BucketClassSpec{
+				Parameters: &BucketParameters{
+					Azure: &AzureBucketParameters{Credentials: &AzureBucketCredentials{Type: AzureCredentialsTypeUserDelegationSAS}},
+				},
+			},
+			expectedSpec: BucketSpec{
+				Name: "loki",
+				Parameters: &BucketParameters{
+					Azure: &AzureBucketParameters{
+						SKU:         AzureSKUStandardGRS,
+						Credentials: &AzureBucketCredentials{Type: AzureCredentialsTypeUserDelegationSAS},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucketClass := &BucketClass{ObjectMeta: metav1.ObjectMeta{Name: "logs"}, Spec: tc.classSpec}
+			classSpec := bucketClass.Spec.DeepCopy()
+			bucket := &Bucket{Spec: tc.spec}
+			bucket.ApplyBucketClass(bucketClass)
+
+			if !cmp.Equal(bucket.Spec, tc.expectedSpec) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedSpec, bucket.Spec))
+			}
+			if bucket.Status.BucketClassName != "logs" {
+				t.Fatalf("expected bucket class logs, got %q", bucket.Status.BucketClassName)
+			}
+			if !cmp.Equal(&bucketClass.Spec, classSpec) {
+				t.Fatalf("bucket class was modified\n\n%s\n", cmp.Diff(classSpec, &bucketClass.Spec))
+			}
+		})
+	}
+}
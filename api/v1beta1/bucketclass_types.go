@@ -0,0 +1,80 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultBucketClassAnnotation marks the BucketClass applied to the Buckets that do not reference any class.
+const DefaultBucketClassAnnotation = "objectstorage.giantswarm.io/is-default-class"
+
+// BucketClassSpec defines the defaults applied to the Buckets of the class.
+// Every field set on a Bucket takes precedence over the one of its class.
+type BucketClassSpec struct {
+	// ReclaimPolicy defines what happens to the cloud provider bucket when the Bucket is deleted.
+	// +optional
+	ReclaimPolicy ReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// Lifecycle of the objects in the buckets.
+	// +optional
+	Lifecycle *BucketLifecycle `json:"lifecycle,omitempty"`
+
+	// Encryption at rest of the objects in the buckets.
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// Tags to add to the buckets. Tags of the Bucket with the same key win.
+	// +optional
+	Tags []BucketTag `json:"tags,omitempty"`
+
+	// Parameters specific to the cloud provider.
+	// +optional
+	Parameters *BucketParameters `json:"parameters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Default",type=string,JSONPath=`.metadata.annotations.objectstorage\.giantswarm\.io/is-default-class`
+//+kubebuilder:printcolumn:name="Reclaim Policy",type=string,JSONPath=`.spec.reclaimPolicy`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BucketClass is the Schema for the bucketclasses API. It holds platform-level defaults for Buckets.
+type BucketClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BucketClassSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BucketClassList contains a list of BucketClass
+type BucketClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BucketClass{}, &BucketClassList{})
+}
+
+// IsDefault returns true if the class applies to the Buckets that do not reference any class.
+func (c *BucketClass) IsDefault() bool {
+	return c.Annotations[DefaultBucketClassAnnotation] == "true"
+}
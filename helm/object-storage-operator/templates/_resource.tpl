@@ -19,6 +19,10 @@ room for such suffix.
 {{- include "resource.default.name" . -}}-policy-exception
 {{- end -}}
 
+{{- define "resource.webhook.name" -}}
+{{- include "resource.default.name" . -}}-webhook
+{{- end -}}
+
 {{- define "resource.default.namespace" -}}
 monitoring
 {{- end -}}
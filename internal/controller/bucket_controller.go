@@ -18,14 +18,19 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/flags"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
@@ -37,11 +42,15 @@ type BucketReconciler struct {
 	cluster.ClusterGetter
 	objectstorage.ObjectStorageServiceFactory
 	flags.ManagementCluster
+	// ConnectionSecretNamespaces are the namespaces connection Secrets may be published in besides the namespace of
+	// the Bucket.
+	ConnectionSecretNamespaces []string
 }
 
 //+kubebuilder:rbac:groups=objectstorage.giantswarm.io,resources=buckets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=objectstorage.giantswarm.io,resources=buckets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=objectstorage.giantswarm.io,resources=buckets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=objectstorage.giantswarm.io,resources=bucketclasses,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the bucket closer to the desired state.
@@ -54,7 +63,7 @@ func (r BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	logger.Info("Started reconciling Bucket")
 	defer logger.Info("Finished reconciling Bucket")
 
-	bucket := &v1alpha1.Bucket{}
+	bucket := &v1beta1.Bucket{}
 	err := r.Get(ctx, req.NamespacedName, bucket)
 	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
@@ -77,6 +86,10 @@ func (r BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	// Handle deleted clusters
 	if !bucket.DeletionTimestamp.IsZero() {
+		// Without its class, the bucket is deleted according to its own spec, which retains it by default.
+		if err := r.applyBucketClass(ctx, bucket); err != nil {
+			logger.Error(err, "failed to apply bucket class, using the bucket spec only")
+		}
 		err := r.reconcileDelete(ctx, objectStorageService, accessRoleService, bucket)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to delete bucket %s: %w", bucket.Spec.Name, err)
@@ -89,84 +102,267 @@ func (r BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 }
 
 // reconcileCreate creates the bucket.
-func (r BucketReconciler) reconcileNormal(ctx context.Context, objectStorageService objectstorage.ObjectStorageService, accessRoleService objectstorage.AccessRoleService, bucket *v1alpha1.Bucket) (ctrl.Result, error) {
+func (r BucketReconciler) reconcileNormal(ctx context.Context, objectStorageService objectstorage.ObjectStorageService, accessRoleService objectstorage.AccessRoleService, bucket *v1beta1.Bucket) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 
 	originalBucket := bucket.DeepCopy()
 	// If the Bucket doesn't have our finalizer, add it.
-	if controllerutil.AddFinalizer(bucket, v1alpha1.BucketFinalizer) {
+	if controllerutil.AddFinalizer(bucket, v1beta1.BucketFinalizer) {
 		// Register the finalizer immediately to avoid orphaning AWS resources on delete
 		if err := r.Patch(ctx, bucket, client.MergeFrom(originalBucket)); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to bucket %s: %w", bucket.Spec.Name, err)
 		}
 	}
 
+	// Every step below records its outcome as a condition, we persist them whatever happens.
+	originalBucket = bucket.DeepCopy()
+	defer func() {
+		bucket.Status.ObservedGeneration = bucket.Generation
+		bucket.SetReadyCondition()
+		if patchErr := r.Client.Status().Patch(ctx, bucket, client.MergeFrom(originalBucket)); patchErr != nil {
+			if err == nil {
+				err = fmt.Errorf("failed to update status for bucket %s: %w", bucket.Spec.Name, patchErr)
+				return
+			}
+			logger.Error(patchErr, "failed to update status", "bucket", bucket.Spec.Name)
+		}
+	}()
+
+	// From here on, the bucket holds the effective spec made of the Bucket and its class.
+	err = r.applyBucketClass(ctx, bucket)
+	if apierrors.IsNotFound(err) {
+		bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonBucketClassNotFound, err.Error())
+		return ctrl.Result{}, err
+	} else if err != nil {
+		bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonProvisioningFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	// Buckets admitted before the namespace was removed from the allowed ones, or without the webhook, are not published.
+	if !objectstorage.ConnectionSecretNamespaceAllowed(bucket, r.ConnectionSecretNamespaces) {
+		err = fmt.Errorf("connection secret of bucket %s cannot be published in namespace %s", bucket.Spec.Name, bucket.ConnectionSecret().Namespace)
+		bucket.MarkConditionFalse(v1beta1.ConditionCredentialsPublished, v1beta1.ReasonCredentialsPublishFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	logger.Info("Checking if bucket exists")
 	exists, err := objectStorageService.ExistsBucket(ctx, bucket)
-	if err != nil {
+	if errors.Is(err, objectstorage.ErrBucketUnmanaged) {
+		if bucket.Spec.AdoptionPolicy != v1beta1.AdoptionPolicyAdopt {
+			return r.reconcileConflict(ctx, bucket, v1beta1.ReasonAdoptionRequired,
+				fmt.Errorf("%w, set spec.adoptionPolicy to %s to manage it", err, v1beta1.AdoptionPolicyAdopt))
+		}
+		err = r.adoptBucket(ctx, objectStorageService, bucket, originalBucket)
+		if err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonProvisioningFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+		exists = true
+	}
+	if errors.Is(err, objectstorage.ErrBucketConflict) {
+		return r.reconcileConflict(ctx, bucket, v1beta1.ReasonConflict, err)
+	} else if err != nil {
+		bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonProvisioningFailed, err.Error())
 		return ctrl.Result{}, fmt.Errorf("failed to check if bucket %s exists: %w", bucket.Spec.Name, err)
 	} else if !exists {
 		logger.Info("Bucket is available, creating")
 		err = objectStorageService.CreateBucket(ctx, bucket)
-		if err != nil {
+		if errors.Is(err, objectstorage.ErrBucketConflict) {
+			return r.reconcileConflict(ctx, bucket, v1beta1.ReasonConflict, err)
+		} else if err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonProvisioningFailed, err.Error())
 			return ctrl.Result{}, fmt.Errorf("failed to create bucket %s: %w", bucket.Spec.Name, err)
 		}
 	} else {
 		logger.Info("Bucket exists and you already own it, let's update it")
 		err = objectStorageService.UpdateBucket(ctx, bucket)
 		if err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonProvisioningFailed, err.Error())
 			return ctrl.Result{}, fmt.Errorf("failed to update bucket %s: %w", bucket.Spec.Name, err)
 		}
 	}
+	bucket.Status.BucketID = bucket.Spec.Name
+	bucket.RemoveCondition(v1beta1.ConditionConflict)
+	if bucket.IsAdopted() {
+		bucket.MarkConditionTrue(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonAdopted, fmt.Sprintf("Bucket existed before and was adopted at %s", bucket.Status.Adoption.AdoptedAt.UTC().Format(time.RFC3339)))
+	} else {
+		bucket.MarkConditionTrue(v1beta1.ConditionBucketProvisioned, v1beta1.ReasonProvisioned, "")
+	}
 
 	logger.Info("Configuring bucket settings")
 	// If expiration is not set, we remove all lifecycle rules
 	err = objectStorageService.ConfigureBucket(ctx, bucket)
 	if err != nil {
+		bucket.MarkConditionFalse(v1beta1.ConditionConfigured, v1beta1.ReasonConfigurationFailed, err.Error())
 		return ctrl.Result{}, fmt.Errorf("failed to configure bucket %s: %w", bucket.Spec.Name, err)
 	}
+	bucket.MarkConditionTrue(v1beta1.ConditionConfigured, v1beta1.ReasonConfigured, "")
 
-	originalBucket = bucket.DeepCopy()
-	bucket.Status = v1alpha1.BucketStatus{
-		BucketID:    bucket.Spec.Name,
-		BucketReady: true,
+	if bucket.AccessRole() != nil {
+		logger.Info("Creating bucket access role")
+		err = accessRoleService.ConfigureRole(ctx, bucket)
+		if err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionAccessRoleReady, v1beta1.ReasonAccessRoleFailed, err.Error())
+			return ctrl.Result{}, fmt.Errorf("failed to configure access role for bucket %s: %w", bucket.Spec.Name, err)
+		}
+		bucket.MarkConditionTrue(v1beta1.ConditionAccessRoleReady, v1beta1.ReasonAccessRoleConfigured, "")
+		logger.Info("Bucket access role created")
+	} else {
+		bucket.RemoveCondition(v1beta1.ConditionAccessRoleReady)
+		bucket.Status.AccessRole = nil
 	}
 
-	if err = r.Client.Status().Patch(ctx, bucket, client.MergeFrom(originalBucket)); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to update status for bucket %s: %w", bucket.Spec.Name, err)
+	// Expiring credentials are renewed by reconciling the bucket again before they expire.
+	if credentials := bucket.Status.Credentials; credentials != nil {
+		logger.Info("Bucket ready, renewing its credentials later", "renewAt", credentials.RenewAt.UTC().Format(time.RFC3339))
+		return ctrl.Result{RequeueAfter: max(time.Until(credentials.RenewAt.Time), time.Second)}, nil
 	}
+
 	logger.Info("Bucket ready")
+	return ctrl.Result{}, nil
+}
 
-	if bucket.Spec.AccessRole != nil && bucket.Spec.AccessRole.RoleName != "" {
-		logger.Info("Creating bucket access role")
-		err = accessRoleService.ConfigureRole(ctx, bucket)
+// adoptBucket records what pre-existed in the status of the bucket and persists it right away, before the operator
+// stamps its ownership tags on the bucket, so that an adopted bucket is never mistaken for one the operator created.
+func (r BucketReconciler) adoptBucket(ctx context.Context, objectStorageService objectstorage.ObjectStorageService, bucket *v1beta1.Bucket, originalBucket *v1beta1.Bucket) error {
+	logger := log.FromContext(ctx)
+	logger.Info("Bucket exists but is not managed yet, adopting it")
+
+	err := objectStorageService.AdoptBucket(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to adopt bucket %s: %w", bucket.Spec.Name, err)
+	}
+	// The patch is applied to a copy as the response would overwrite the effective spec of the bucket.
+	err = r.Client.Status().Patch(ctx, bucket.DeepCopy(), client.MergeFrom(originalBucket))
+	if err != nil {
+		return fmt.Errorf("failed to record adoption of bucket %s: %w", bucket.Spec.Name, err)
+	}
+	logger.Info("Bucket adopted")
+	return nil
+}
+
+// applyBucketClass merges the class of the bucket into its spec and records it in its status.
+func (r BucketReconciler) applyBucketClass(ctx context.Context, bucket *v1beta1.Bucket) error {
+	bucketClass, err := r.getBucketClass(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if bucketClass == nil {
+		bucket.Status.BucketClassName = ""
+		return nil
+	}
+	bucket.ApplyBucketClass(bucketClass)
+	return nil
+}
+
+// getBucketClass returns the class referenced by the bucket, or the default class if the bucket does not reference any.
+// It returns nil when the bucket does not reference any class and there is no default class.
+func (r BucketReconciler) getBucketClass(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.BucketClass, error) {
+	if bucket.Spec.BucketClassName != "" {
+		bucketClass := &v1beta1.BucketClass{}
+		err := r.Get(ctx, client.ObjectKey{Name: bucket.Spec.BucketClassName}, bucketClass)
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to configure access role for bucket %s: %w", bucket.Spec.Name, err)
+			return nil, fmt.Errorf("failed to get bucket class %s for bucket %s: %w", bucket.Spec.BucketClassName, bucket.Spec.Name, err)
 		}
-		logger.Info("Bucket access role created")
+		return bucketClass, nil
+	}
+
+	bucketClasses := &v1beta1.BucketClassList{}
+	err := r.List(ctx, bucketClasses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket classes: %w", err)
+	}
+	var defaultClass *v1beta1.BucketClass
+	for i := range bucketClasses.Items {
+		bucketClass := &bucketClasses.Items[i]
+		// Like for StorageClasses, the most recent default class wins when there are several of them.
+		if bucketClass.IsDefault() && (defaultClass == nil || defaultClass.CreationTimestamp.Before(&bucketClass.CreationTimestamp)) {
+			defaultClass = bucketClass
+		}
+	}
+	return defaultClass, nil
+}
+
+// findBucketsForClass returns the buckets to reconcile when a bucket class changes: the buckets referencing it,
+// and the buckets without class as the default class may have changed.
+func (r BucketReconciler) findBucketsForClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	buckets := &v1beta1.BucketList{}
+	err := r.List(ctx, buckets)
+	if err != nil {
+		logger.Error(err, "failed to list buckets for bucket class", "bucketClass", obj.GetName())
+		return nil
 	}
+
+	var requests []reconcile.Request
+	for _, bucket := range buckets.Items {
+		if bucket.Spec.BucketClassName == "" || bucket.Spec.BucketClassName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&bucket)})
+		}
+	}
+	return requests
+}
+
+// reconcileConflict records that the bucket is owned by someone else, or that it must be adopted first. Nothing is done
+// on the cloud provider bucket and the error is not returned as retrying will not solve the conflict.
+func (r BucketReconciler) reconcileConflict(ctx context.Context, bucket *v1beta1.Bucket, reason string, err error) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Error(err, "Bucket is not ours, not managing it")
+
+	bucket.MarkConditionTrue(v1beta1.ConditionConflict, reason, err.Error())
+	bucket.MarkConditionFalse(v1beta1.ConditionBucketProvisioned, reason, err.Error())
 	return ctrl.Result{}, nil
 }
 
 // reconcileDelete deletes the bucket.
-func (r BucketReconciler) reconcileDelete(ctx context.Context, objectStorageService objectstorage.ObjectStorageService, accessRoleService objectstorage.AccessRoleService, bucket *v1alpha1.Bucket) error {
+func (r BucketReconciler) reconcileDelete(ctx context.Context, objectStorageService objectstorage.ObjectStorageService, accessRoleService objectstorage.AccessRoleService, bucket *v1beta1.Bucket) error {
 	logger := log.FromContext(ctx)
 
 	logger.Info("Checking if bucket exists")
 	exists, err := objectStorageService.ExistsBucket(ctx, bucket)
+	if errors.Is(err, objectstorage.ErrBucketConflict) || errors.Is(err, objectstorage.ErrBucketUnmanaged) {
+		// The bucket is not ours, we must not delete it nor block the deletion of the Bucket.
+		logger.Info("Bucket is not managed by the operator, not deleting it")
+		originalBucket := bucket.DeepCopy()
+		controllerutil.RemoveFinalizer(bucket, v1beta1.BucketFinalizer)
+		return r.Patch(ctx, bucket, client.MergeFrom(originalBucket))
+	}
 	if err == nil && exists {
 		switch bucket.Spec.ReclaimPolicy {
-		case v1alpha1.ReclaimPolicyDelete:
+		case v1beta1.ReclaimPolicyDelete:
 			logger.Info("Reclaim policy is set to delete, deleting bucket")
 
-			logger.Info("Bucket exists, deleting")
-			err = objectStorageService.DeleteBucket(ctx, bucket)
-			if err != nil {
-				return fmt.Errorf("failed to delete bucket %s: %w", bucket.Spec.Name, err)
+			// Access grants are revoked even when the bucket is retained below.
+			if len(bucket.Status.AccessGrants) != 0 {
+				logger.Info("Deleting bucket access grants")
+				err = objectStorageService.DeleteAccessGrants(ctx, bucket)
+				if err != nil {
+					return fmt.Errorf("failed to delete access grants for bucket %s: %w", bucket.Spec.Name, err)
+				}
+				logger.Info("Bucket access grants deleted")
 			}
-			logger.Info("Bucket deleted")
 
-			if bucket.Spec.AccessRole != nil && bucket.Spec.AccessRole.RoleName != "" {
+			if bucket.IsAdopted() && !bucket.Spec.AllowAdoptedBucketDeletion {
+				// Adopted buckets hold data the operator did not create, they are only deleted when explicitly allowed.
+				logger.Info("Bucket was adopted and its deletion is not allowed, not deleting it")
+			} else if bucket.IsObjectLocked() {
+				// Retained objects must outlive the bucket, the cloud provider would refuse to delete them anyway.
+				// We keep the finalizer, along with the access role, so that the bucket is not orphaned.
+				message := objectLockMessage(bucket)
+				logger.Info("Bucket has an object lock, not deleting it", "retention", message)
+				originalBucket := bucket.DeepCopy()
+				bucket.MarkConditionTrue(v1beta1.ConditionDeletionBlocked, v1beta1.ReasonObjectLocked, message)
+				return r.Client.Status().Patch(ctx, bucket, client.MergeFrom(originalBucket))
+			} else {
+				logger.Info("Bucket exists, deleting")
+				err = objectStorageService.DeleteBucket(ctx, bucket)
+				if err != nil {
+					return fmt.Errorf("failed to delete bucket %s: %w", bucket.Spec.Name, err)
+				}
+				logger.Info("Bucket deleted")
+			}
+
+			if bucket.AccessRole() != nil {
 				logger.Info("Deleting bucket access role")
 				err = accessRoleService.DeleteRole(ctx, bucket)
 				if err != nil {
@@ -179,9 +375,9 @@ func (r BucketReconciler) reconcileDelete(ctx context.Context, objectStorageServ
 
 			// Remove the finalizer.
 			originalBucket := bucket.DeepCopy()
-			controllerutil.RemoveFinalizer(bucket, v1alpha1.BucketFinalizer)
+			controllerutil.RemoveFinalizer(bucket, v1beta1.BucketFinalizer)
 			return r.Patch(ctx, bucket, client.MergeFrom(originalBucket))
-		case v1alpha1.ReclaimPolicyRetain:
+		case v1beta1.ReclaimPolicyRetain:
 			logger.Info("Reclaim policy is set to retain, not deleting bucket")
 		default:
 			logger.Info("Reclaim policy is the default one (retain), not deleting bucket")
@@ -190,9 +386,25 @@ func (r BucketReconciler) reconcileDelete(ctx context.Context, objectStorageServ
 	return nil
 }
 
+// objectLockMessage describes the retention that prevents the deletion of a locked bucket.
+func objectLockMessage(bucket *v1beta1.Bucket) string {
+	var mode v1beta1.ObjectLockMode
+	var retentionDays int32
+	if bucket.Status.ObjectLock != nil {
+		mode, retentionDays = bucket.Status.ObjectLock.Mode, bucket.Status.ObjectLock.RetentionDays
+	} else if bucket.Spec.ObjectLock != nil {
+		mode, retentionDays = bucket.Spec.ObjectLock.Mode, bucket.Spec.ObjectLock.RetentionDays
+	}
+	if mode == "" || retentionDays == 0 {
+		return "Bucket has an object lock and is not deleted, objects are retained on demand"
+	}
+	return fmt.Sprintf("Bucket has a %s object lock and is not deleted, objects are retained for %d days after their creation", mode, retentionDays)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r BucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.Bucket{}).
+		For(&v1beta1.Bucket{}).
+		Watches(&v1beta1.BucketClass{}, handler.EnqueueRequestsFromMapFunc(r.findBucketsForClass)).
 		Complete(r)
 }
@@ -3,6 +3,7 @@ package controller_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -14,10 +15,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 	"github.com/giantswarm/object-storage-operator/internal/controller"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster/clusterfakes"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/flags"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/aws"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/azure"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/objectstoragefakes"
@@ -32,8 +34,9 @@ var _ = Describe("Bucket Reconciler", func() {
 	var (
 		ctx context.Context
 
-		reconciler   controller.BucketReconciler
-		reconcileErr error
+		reconciler      controller.BucketReconciler
+		reconcileResult ctrl.Result
+		reconcileErr    error
 
 		fakeClient           client.Client
 		serviceFactory       objectstoragefakes.FakeObjectStorageServiceFactory
@@ -50,7 +53,7 @@ var _ = Describe("Bucket Reconciler", func() {
 
 		ctx = context.Background()
 
-		fakeClient = fake.NewClientBuilder().WithStatusSubresource(&v1alpha1.Bucket{}).Build()
+		fakeClient = fake.NewClientBuilder().WithStatusSubresource(&v1beta1.Bucket{}).Build()
 		serviceFactory = objectstoragefakes.FakeObjectStorageServiceFactory{}
 		fakeClusterGetter = clusterfakes.FakeClusterGetter{}
 		objectStorageService = objectstoragefakes.FakeObjectStorageService{}
@@ -78,13 +81,13 @@ var _ = Describe("Bucket Reconciler", func() {
 		JustBeforeEach(func() {
 			// starts the reconciler
 			request := ctrl.Request{NamespacedName: bucketKey}
-			_, reconcileErr = reconciler.Reconcile(ctx, request)
+			reconcileResult, reconcileErr = reconciler.Reconcile(ctx, request)
 		})
 
 		When("reconciling a missing bucket", func() {
 			It("does nothing", func() {
 				Expect(reconcileErr).ToNot(HaveOccurred())
-				var existingBucket v1alpha1.Bucket
+				var existingBucket v1beta1.Bucket
 				_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 				Expect(existingBucket.Finalizers).To(BeEmpty())
 			})
@@ -93,15 +96,15 @@ var _ = Describe("Bucket Reconciler", func() {
 		When("the management cluster is in error", func() {
 			BeforeEach(func() {
 				// creates dummy bucket
-				bucket := v1alpha1.Bucket{
+				bucket := v1beta1.Bucket{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      BucketName,
 						Namespace: BucketNamespace,
 					},
-					Spec: v1alpha1.BucketSpec{
+					Spec: v1beta1.BucketSpec{
 						Name: BucketName,
 					},
-					Status: v1alpha1.BucketStatus{},
+					Status: v1beta1.BucketStatus{},
 				}
 				_ = fakeClient.Create(ctx, &bucket)
 			})
@@ -114,7 +117,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError("failed to get cluster for bucket my-bucket-name: Missing management cluster AWSCluster CR"))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -142,7 +145,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -172,7 +175,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -216,7 +219,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).To(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -274,15 +277,15 @@ var _ = Describe("Bucket Reconciler", func() {
 			When("the bucket is being created/updated", func() {
 				BeforeEach(func() {
 					// creates dummy bucket
-					bucket := v1alpha1.Bucket{
+					bucket := v1beta1.Bucket{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      BucketName,
 							Namespace: BucketNamespace,
 						},
-						Spec: v1alpha1.BucketSpec{
+						Spec: v1beta1.BucketSpec{
 							Name: BucketName,
 						},
-						Status: v1alpha1.BucketStatus{},
+						Status: v1beta1.BucketStatus{},
 					}
 					_ = fakeClient.Create(ctx, &bucket)
 				})
@@ -296,9 +299,9 @@ var _ = Describe("Bucket Reconciler", func() {
 					It("failed", func() {
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 
@@ -312,11 +315,16 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.CreateBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(existingBucket.Status.BucketID).To(Equal(BucketName))
 						Expect(existingBucket.Status.BucketReady).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionBucketProvisioned)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConfigured)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).ObservedGeneration).To(Equal(existingBucket.Generation))
+						Expect(existingBucket.Status.ObservedGeneration).To(Equal(existingBucket.Generation))
 					})
 				})
 
@@ -328,11 +336,16 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(existingBucket.Status.BucketID).To(Equal(BucketName))
 						Expect(existingBucket.Status.BucketReady).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionBucketProvisioned)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConfigured)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).ObservedGeneration).To(Equal(existingBucket.Generation))
+						Expect(existingBucket.Status.ObservedGeneration).To(Equal(existingBucket.Generation))
 					})
 				})
 
@@ -344,11 +357,14 @@ var _ = Describe("Bucket Reconciler", func() {
 					})
 
 					It("returns the error", func() {
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(reconcileErr).Should(MatchError(expectedError))
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeFalse())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Reason).To(Equal(v1beta1.ReasonProvisioningFailed))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Message).To(Equal(expectedError.Error()))
 					})
 				})
 
@@ -360,11 +376,363 @@ var _ = Describe("Bucket Reconciler", func() {
 					})
 
 					It("returns the error", func() {
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(reconcileErr).Should(MatchError(expectedError))
+						Expect(existingBucket.Status.BucketReady).To(BeFalse())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionBucketProvisioned)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionConfigured).Reason).To(Equal(v1beta1.ReasonConfigurationFailed))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).Reason).To(Equal(v1beta1.ReasonConfigurationFailed))
+					})
+				})
+			})
+
+			When("the bucket has an access role", func() {
+				BeforeEach(func() {
+					// creates dummy bucket with an access role
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+						},
+						Spec: v1beta1.BucketSpec{
+							Name: BucketName,
+							Access: &v1beta1.BucketAccess{
+								Role: &v1beta1.BucketAccessRole{
+									Name: "my-role",
+									ServiceAccount: v1beta1.ServiceAccountReference{
+										Name:      "my-service-account",
+										Namespace: "my-namespace",
+									},
+								},
+							},
+						},
+						Status: v1beta1.BucketStatus{},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+					objectStorageService.ExistsBucketReturns(true, nil)
+				})
+
+				When("the access role is configured", func() {
+					It("is ready", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(accessRoleService.ConfigureRoleCallCount()).To(Equal(1))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionAccessRoleReady)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+					})
+				})
+
+				When("there is an error trying to configure the access role", func() {
+					expectedError := errors.New("failed configuring the role")
+
+					BeforeEach(func() {
+						accessRoleService.ConfigureRoleReturns(expectedError)
+					})
+
+					It("is not ready", func() {
+						Expect(reconcileErr).To(HaveOccurred())
+						Expect(reconcileErr).Should(MatchError(expectedError))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Status.BucketReady).To(BeFalse())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConfigured)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionAccessRoleReady).Reason).To(Equal(v1beta1.ReasonAccessRoleFailed))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionAccessRoleReady).Message).To(Equal(expectedError.Error()))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).Reason).To(Equal(v1beta1.ReasonAccessRoleFailed))
+					})
+				})
+			})
+
+			When("the bucket is owned by someone else", func() {
+				conflictError := fmt.Errorf("%w: S3 bucket %s is not owned by account 123456789012", objectstorage.ErrBucketConflict, BucketName)
+
+				BeforeEach(func() {
+					// creates dummy bucket
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+						},
+						Spec: v1beta1.BucketSpec{
+							Name: BucketName,
+						},
+						Status: v1beta1.BucketStatus{},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+				})
+
+				When("the bucket exists in another account", func() {
+					BeforeEach(func() {
+						objectStorageService.ExistsBucketReturns(false, conflictError)
+					})
+
+					It("does not touch the bucket and reports a conflict", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.CreateBucketCallCount()).To(Equal(0))
+						Expect(objectStorageService.UpdateBucketCallCount()).To(Equal(0))
+						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Status.BucketReady).To(BeFalse())
+						Expect(existingBucket.Status.BucketID).To(BeEmpty())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConflict)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionConflict).Message).To(Equal(conflictError.Error()))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).Reason).To(Equal(v1beta1.ReasonConflict))
+					})
+				})
+
+				When("the bucket is created in another account in the meantime", func() {
+					BeforeEach(func() {
+						objectStorageService.ExistsBucketReturns(false, nil)
+						objectStorageService.CreateBucketReturns(conflictError)
+					})
+
+					It("does not touch the bucket and reports a conflict", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConflict)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Reason).To(Equal(v1beta1.ReasonConflict))
+					})
+				})
+			})
+
+			When("the bucket has a class", func() {
+				// The bucket given to the services is updated by the status patch at the end of the reconciliation.
+				var configuredBucket *v1beta1.Bucket
+
+				createBucket := func(bucketClassName string) {
+					// creates dummy bucket
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+						},
+						Spec: v1beta1.BucketSpec{
+							Name:            BucketName,
+							BucketClassName: bucketClassName,
+							Tags:            []v1beta1.BucketTag{{Key: "app", Value: "loki"}},
+						},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+				}
+
+				BeforeEach(func() {
+					// creates dummy bucket classes
+					logs := v1beta1.BucketClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "logs",
+							Annotations: map[string]string{v1beta1.DefaultBucketClassAnnotation: "true"},
+						},
+						Spec: v1beta1.BucketClassSpec{
+							ReclaimPolicy: v1beta1.ReclaimPolicyDelete,
+							Lifecycle:     &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}},
+							Tags:          []v1beta1.BucketTag{{Key: "retention", Value: "short"}},
+						},
+					}
+					_ = fakeClient.Create(ctx, &logs)
+					archive := v1beta1.BucketClass{
+						ObjectMeta: metav1.ObjectMeta{Name: "archive"},
+						Spec: v1beta1.BucketClassSpec{
+							Lifecycle: &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 3650}},
+						},
+					}
+					_ = fakeClient.Create(ctx, &archive)
+					objectStorageService.ExistsBucketReturns(true, nil)
+					objectStorageService.ConfigureBucketCalls(func(ctx context.Context, bucket *v1beta1.Bucket) error {
+						configuredBucket = bucket.DeepCopy()
+						return nil
+					})
+				})
+
+				When("the bucket references a class", func() {
+					BeforeEach(func() {
+						createBucket("archive")
+					})
+
+					It("is configured with the effective spec", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(1))
+						Expect(*configuredBucket.ExpirationDays()).To(Equal(int32(3650)))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Spec.Lifecycle).To(BeNil())
+						Expect(existingBucket.Status.BucketClassName).To(Equal("archive"))
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+					})
+				})
+
+				When("the bucket does not reference any class", func() {
+					BeforeEach(func() {
+						createBucket("")
+					})
+
+					It("is configured with the default class", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(configuredBucket.Spec.ReclaimPolicy).To(Equal(v1beta1.ReclaimPolicyDelete))
+						Expect(*configuredBucket.ExpirationDays()).To(Equal(int32(30)))
+						Expect(configuredBucket.Spec.Tags).To(Equal([]v1beta1.BucketTag{{Key: "retention", Value: "short"}, {Key: "app", Value: "loki"}}))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Spec.ReclaimPolicy).To(BeEmpty())
+						Expect(existingBucket.Spec.Tags).To(Equal([]v1beta1.BucketTag{{Key: "app", Value: "loki"}}))
+						Expect(existingBucket.Status.BucketClassName).To(Equal("logs"))
+					})
+				})
+
+				When("the class of the bucket does not exist", func() {
+					BeforeEach(func() {
+						createBucket("backups")
+					})
+
+					It("is not provisioned", func() {
+						Expect(reconcileErr).To(HaveOccurred())
+						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Reason).To(Equal(v1beta1.ReasonBucketClassNotFound))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).Reason).To(Equal(v1beta1.ReasonBucketClassNotFound))
+					})
+				})
+			})
+
+			When("the connection secret of the bucket is published in another namespace", func() {
+				BeforeEach(func() {
+					reconciler.ConnectionSecretNamespaces = []string{"monitoring"}
+				})
+
+				createBucket := func(namespace string) {
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+						},
+						Spec: v1beta1.BucketSpec{
+							Name:                       BucketName,
+							WriteConnectionSecretToRef: &v1beta1.ConnectionSecretReference{Namespace: namespace},
+						},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+				}
+
+				When("the namespace is allowed", func() {
+					BeforeEach(func() {
+						createBucket("monitoring")
+						objectStorageService.ExistsBucketReturns(false, nil)
+					})
+
+					It("was created", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.CreateBucketCallCount()).To(Equal(1))
+					})
+				})
+
+				When("the namespace is not allowed", func() {
+					BeforeEach(func() {
+						createBucket("kube-system")
+					})
+
+					It("is not provisioned", func() {
+						Expect(reconcileErr).To(HaveOccurred())
+						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.GetCondition(v1beta1.ConditionCredentialsPublished).Reason).To(Equal(v1beta1.ReasonCredentialsPublishFailed))
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeFalse())
+					})
+				})
+			})
+
+			When("the bucket exists but is not managed by the operator", func() {
+				unmanagedError := fmt.Errorf("%w: S3 bucket %s already exists", objectstorage.ErrBucketUnmanaged, BucketName)
+
+				createBucket := func(adoptionPolicy v1beta1.AdoptionPolicy) {
+					// creates dummy bucket
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+						},
+						Spec: v1beta1.BucketSpec{
+							Name:           BucketName,
+							AdoptionPolicy: adoptionPolicy,
+						},
+						Status: v1beta1.BucketStatus{},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+				}
+
+				BeforeEach(func() {
+					objectStorageService.ExistsBucketReturns(true, unmanagedError)
+					objectStorageService.AdoptBucketCalls(func(ctx context.Context, bucket *v1beta1.Bucket) error {
+						bucket.Status.Adoption = &v1beta1.BucketAdoption{
+							AdoptedAt: metav1.Now(),
+							Tags:      []v1beta1.BucketTag{{Key: "team", Value: "atlas"}},
+						}
+						return nil
+					})
+				})
+
+				When("the adoption policy is not set", func() {
+					BeforeEach(func() {
+						createBucket("")
+					})
+
+					It("does not touch the bucket and asks for adoption", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.AdoptBucketCallCount()).To(Equal(0))
+						Expect(objectStorageService.UpdateBucketCallCount()).To(Equal(0))
+						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Status.BucketID).To(BeEmpty())
+						Expect(existingBucket.IsAdopted()).To(BeFalse())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConflict)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).Reason).To(Equal(v1beta1.ReasonAdoptionRequired))
+					})
+				})
+
+				When("the adoption policy is Adopt", func() {
+					BeforeEach(func() {
+						createBucket(v1beta1.AdoptionPolicyAdopt)
+					})
+
+					It("adopts the bucket", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.AdoptBucketCallCount()).To(Equal(1))
+						Expect(objectStorageService.CreateBucketCallCount()).To(Equal(0))
+						Expect(objectStorageService.UpdateBucketCallCount()).To(Equal(1))
+						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(1))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Status.BucketID).To(Equal(BucketName))
+						Expect(existingBucket.IsAdopted()).To(BeTrue())
+						Expect(existingBucket.Status.Adoption.Tags).To(Equal([]v1beta1.BucketTag{{Key: "team", Value: "atlas"}}))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Reason).To(Equal(v1beta1.ReasonAdopted))
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+					})
+				})
+
+				When("there is an error trying to adopt the bucket", func() {
+					expectedError := errors.New("failed getting the tags")
+
+					BeforeEach(func() {
+						createBucket(v1beta1.AdoptionPolicyAdopt)
+						objectStorageService.AdoptBucketReturns(expectedError)
+					})
+
+					It("returns the error", func() {
+						Expect(reconcileErr).Should(MatchError(expectedError))
+						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.IsAdopted()).To(BeFalse())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Reason).To(Equal(v1beta1.ReasonProvisioningFailed))
 					})
 				})
 			})
@@ -373,19 +741,19 @@ var _ = Describe("Bucket Reconciler", func() {
 				BeforeEach(func() {
 					// creates dummy bucket in deleting state
 					var gracePeriod int64 = 120
-					bucket := v1alpha1.Bucket{
+					bucket := v1beta1.Bucket{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      BucketName,
 							Namespace: BucketNamespace,
 							Finalizers: []string{
-								v1alpha1.BucketFinalizer,
+								v1beta1.BucketFinalizer,
 							},
 						},
-						Spec: v1alpha1.BucketSpec{
+						Spec: v1beta1.BucketSpec{
 							Name:          BucketName,
-							ReclaimPolicy: v1alpha1.ReclaimPolicyDelete,
+							ReclaimPolicy: v1beta1.ReclaimPolicyDelete,
 						},
-						Status: v1alpha1.BucketStatus{},
+						Status: v1beta1.BucketStatus{},
 					}
 					_ = fakeClient.Create(ctx, &bucket)
 					_ = fakeClient.Delete(ctx, &bucket, &client.DeleteOptions{GracePeriodSeconds: &gracePeriod})
@@ -402,9 +770,9 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 
@@ -417,9 +785,24 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
+					})
+				})
+
+				When("deleting a bucket owned by someone else", func() {
+					BeforeEach(func() {
+						objectStorageService.ExistsBucketReturns(false, fmt.Errorf("%w: S3 bucket %s is not owned by account 123456789012", objectstorage.ErrBucketConflict, BucketName))
+					})
+
+					It("was free of its finalizer without deleting the bucket", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
+						Expect(accessRoleService.DeleteRoleCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						err := fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(err).To(HaveOccurred())
 					})
 				})
 
@@ -431,29 +814,135 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).ToNot(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).ToNot(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 			})
+			When("an adopted bucket is being deleted (ReclaimPolicy = Delete)", func() {
+				createBucket := func(allowAdoptedBucketDeletion bool) {
+					// creates dummy adopted bucket in deleting state
+					var gracePeriod int64 = 120
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+							Finalizers: []string{
+								v1beta1.BucketFinalizer,
+							},
+						},
+						Spec: v1beta1.BucketSpec{
+							Name:                       BucketName,
+							ReclaimPolicy:              v1beta1.ReclaimPolicyDelete,
+							AdoptionPolicy:             v1beta1.AdoptionPolicyAdopt,
+							AllowAdoptedBucketDeletion: allowAdoptedBucketDeletion,
+						},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+					bucket.Status.BucketID = BucketName
+					bucket.Status.Adoption = &v1beta1.BucketAdoption{AdoptedAt: metav1.Now()}
+					_ = fakeClient.Status().Update(ctx, &bucket)
+					_ = fakeClient.Delete(ctx, &bucket, &client.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+				}
+
+				BeforeEach(func() {
+					objectStorageService.ExistsBucketReturns(true, nil)
+				})
+
+				When("the deletion of adopted buckets is not allowed", func() {
+					BeforeEach(func() {
+						createBucket(false)
+					})
+
+					It("was free of its finalizer without deleting the bucket", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						err := fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(err).To(HaveOccurred())
+					})
+				})
+
+				When("the deletion of adopted buckets is allowed", func() {
+					BeforeEach(func() {
+						createBucket(true)
+					})
+
+					It("was deleted", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(1))
+						var existingBucket v1beta1.Bucket
+						err := fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(err).To(HaveOccurred())
+					})
+				})
+			})
+
+			When("a bucket with an object lock is being deleted (ReclaimPolicy = Delete)", func() {
+				BeforeEach(func() {
+					// creates dummy locked bucket in deleting state
+					var gracePeriod int64 = 120
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+							Finalizers: []string{
+								v1beta1.BucketFinalizer,
+							},
+						},
+						Spec: v1beta1.BucketSpec{
+							Name:          BucketName,
+							ReclaimPolicy: v1beta1.ReclaimPolicyDelete,
+						},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+					bucket.Status.BucketID = BucketName
+					bucket.Status.ObjectLock = &v1beta1.BucketObjectLockStatus{Mode: v1beta1.ObjectLockModeCompliance, RetentionDays: 365}
+					_ = fakeClient.Status().Update(ctx, &bucket)
+					_ = fakeClient.Delete(ctx, &bucket, &client.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+					objectStorageService.ExistsBucketReturns(true, nil)
+				})
+
+				It("kept its finalizer without deleting the bucket", func() {
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
+					Expect(accessRoleService.DeleteRoleCallCount()).To(Equal(0))
+					var existingBucket v1beta1.Bucket
+					err := fakeClient.Get(ctx, bucketKey, &existingBucket)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
+				})
+
+				It("reported the retention", func() {
+					var existingBucket v1beta1.Bucket
+					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+					condition := existingBucket.GetCondition(v1beta1.ConditionDeletionBlocked)
+					Expect(condition).NotTo(BeNil())
+					Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+					Expect(condition.Reason).To(Equal(v1beta1.ReasonObjectLocked))
+					Expect(condition.Message).To(ContainSubstring("COMPLIANCE object lock"))
+					Expect(condition.Message).To(ContainSubstring("365 days"))
+				})
+			})
+
 			When("the bucket is being deleted (ReclaimPolicy = Retain)", func() {
 				BeforeEach(func() {
 					// creates dummy bucket in deleting state
 					var gracePeriod int64 = 120
-					bucket := v1alpha1.Bucket{
+					bucket := v1beta1.Bucket{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      BucketName,
 							Namespace: BucketNamespace,
 							Finalizers: []string{
-								v1alpha1.BucketFinalizer,
+								v1beta1.BucketFinalizer,
 							},
 						},
-						Spec: v1alpha1.BucketSpec{
+						Spec: v1beta1.BucketSpec{
 							Name:          BucketName,
-							ReclaimPolicy: v1alpha1.ReclaimPolicyRetain,
+							ReclaimPolicy: v1beta1.ReclaimPolicyRetain,
 						},
-						Status: v1alpha1.BucketStatus{},
+						Status: v1beta1.BucketStatus{},
 					}
 					_ = fakeClient.Create(ctx, &bucket)
 					_ = fakeClient.Delete(ctx, &bucket, &client.DeleteOptions{GracePeriodSeconds: &gracePeriod})
@@ -468,9 +957,9 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 
@@ -482,9 +971,9 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 			})
@@ -509,13 +998,13 @@ var _ = Describe("Bucket Reconciler", func() {
 		JustBeforeEach(func() {
 			// starts the reconciler
 			request := ctrl.Request{NamespacedName: bucketKey}
-			_, reconcileErr = reconciler.Reconcile(ctx, request)
+			reconcileResult, reconcileErr = reconciler.Reconcile(ctx, request)
 		})
 
 		When("reconciling a missing bucket", func() {
 			It("does nothing", func() {
 				Expect(reconcileErr).ToNot(HaveOccurred())
-				var existingBucket v1alpha1.Bucket
+				var existingBucket v1beta1.Bucket
 				_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 				Expect(existingBucket.Finalizers).To(BeEmpty())
 			})
@@ -524,15 +1013,15 @@ var _ = Describe("Bucket Reconciler", func() {
 		When("the management cluster is in error", func() {
 			BeforeEach(func() {
 				// creates dummy bucket
-				bucket := v1alpha1.Bucket{
+				bucket := v1beta1.Bucket{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      BucketName,
 						Namespace: BucketNamespace,
 					},
-					Spec: v1alpha1.BucketSpec{
+					Spec: v1beta1.BucketSpec{
 						Name: BucketName,
 					},
-					Status: v1alpha1.BucketStatus{},
+					Status: v1beta1.BucketStatus{},
 				}
 				_ = fakeClient.Create(ctx, &bucket)
 			})
@@ -545,7 +1034,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -573,7 +1062,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -603,7 +1092,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -651,7 +1140,7 @@ var _ = Describe("Bucket Reconciler", func() {
 				It("fails", func() {
 					Expect(reconcileErr).To(HaveOccurred())
 					Expect(reconcileErr).Should(MatchError(expectedError))
-					var existingBucket v1alpha1.Bucket
+					var existingBucket v1beta1.Bucket
 					_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
 					Expect(existingBucket.Finalizers).To(BeEmpty())
 				})
@@ -717,15 +1206,15 @@ var _ = Describe("Bucket Reconciler", func() {
 			When("the bucket is being created/updated", func() {
 				BeforeEach(func() {
 					// creates dummy bucket
-					bucket := v1alpha1.Bucket{
+					bucket := v1beta1.Bucket{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      BucketName,
 							Namespace: BucketNamespace,
 						},
-						Spec: v1alpha1.BucketSpec{
+						Spec: v1beta1.BucketSpec{
 							Name: BucketName,
 						},
-						Status: v1alpha1.BucketStatus{},
+						Status: v1beta1.BucketStatus{},
 					}
 					_ = fakeClient.Create(ctx, &bucket)
 				})
@@ -740,9 +1229,9 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(reconcileErr).Should(MatchError(expectedError))
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 
@@ -756,11 +1245,41 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.CreateBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(existingBucket.Status.BucketID).To(Equal(BucketName))
 						Expect(existingBucket.Status.BucketReady).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionBucketProvisioned)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConfigured)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).ObservedGeneration).To(Equal(existingBucket.Generation))
+						Expect(existingBucket.Status.ObservedGeneration).To(Equal(existingBucket.Generation))
+					})
+				})
+
+				When("reconciling a bucket publishing a SAS token", func() {
+					renewAt := time.Now().Add(16 * time.Hour).Truncate(time.Second)
+					BeforeEach(func() {
+						objectStorageService.ExistsBucketReturns(true, nil)
+						objectStorageService.UpdateBucketCalls(func(ctx context.Context, bucket *v1beta1.Bucket) error {
+							bucket.Status.Credentials = &v1beta1.BucketCredentialsStatus{
+								Type:      v1beta1.AzureCredentialsTypeServiceSAS,
+								ExpiresAt: metav1.NewTime(renewAt.Add(8 * time.Hour)),
+								RenewAt:   metav1.NewTime(renewAt),
+							}
+							return nil
+						})
+					})
+
+					It("is reconciled again to renew the token", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(reconcileResult.RequeueAfter).To(BeNumerically("~", time.Until(renewAt), time.Minute))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Status.Credentials).ToNot(BeNil())
+						Expect(existingBucket.Status.Credentials.RenewAt.Time).To(BeTemporally("==", renewAt))
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
 					})
 				})
 
@@ -772,11 +1291,16 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.ConfigureBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(existingBucket.Status.BucketID).To(Equal(BucketName))
 						Expect(existingBucket.Status.BucketReady).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionBucketProvisioned)).To(BeTrue())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionConfigured)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).ObservedGeneration).To(Equal(existingBucket.Generation))
+						Expect(existingBucket.Status.ObservedGeneration).To(Equal(existingBucket.Generation))
 					})
 				})
 
@@ -788,11 +1312,14 @@ var _ = Describe("Bucket Reconciler", func() {
 					})
 
 					It("returns the error", func() {
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(reconcileErr).Should(MatchError(expectedError))
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionReady)).To(BeFalse())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Reason).To(Equal(v1beta1.ReasonProvisioningFailed))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionBucketProvisioned).Message).To(Equal(expectedError.Error()))
 					})
 				})
 
@@ -804,11 +1331,15 @@ var _ = Describe("Bucket Reconciler", func() {
 					})
 
 					It("returns the error", func() {
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 						Expect(reconcileErr).To(HaveOccurred())
 						Expect(reconcileErr).Should(MatchError(expectedError))
+						Expect(existingBucket.Status.BucketReady).To(BeFalse())
+						Expect(existingBucket.IsConditionTrue(v1beta1.ConditionBucketProvisioned)).To(BeTrue())
+						Expect(existingBucket.GetCondition(v1beta1.ConditionConfigured).Reason).To(Equal(v1beta1.ReasonConfigurationFailed))
+						Expect(existingBucket.GetCondition(v1beta1.ConditionReady).Reason).To(Equal(v1beta1.ReasonConfigurationFailed))
 					})
 				})
 			})
@@ -817,19 +1348,19 @@ var _ = Describe("Bucket Reconciler", func() {
 				BeforeEach(func() {
 					// creates dummy bucket in deleting state
 					var gracePeriod int64 = 120
-					bucket := v1alpha1.Bucket{
+					bucket := v1beta1.Bucket{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      BucketName,
 							Namespace: BucketNamespace,
 							Finalizers: []string{
-								v1alpha1.BucketFinalizer,
+								v1beta1.BucketFinalizer,
 							},
 						},
-						Spec: v1alpha1.BucketSpec{
+						Spec: v1beta1.BucketSpec{
 							Name:          BucketName,
-							ReclaimPolicy: v1alpha1.ReclaimPolicyDelete,
+							ReclaimPolicy: v1beta1.ReclaimPolicyDelete,
 						},
-						Status: v1alpha1.BucketStatus{},
+						Status: v1beta1.BucketStatus{},
 					}
 					_ = fakeClient.Create(ctx, &bucket)
 					_ = fakeClient.Delete(ctx, &bucket, &client.DeleteOptions{GracePeriodSeconds: &gracePeriod})
@@ -847,9 +1378,9 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).Should(MatchError(expectedError))
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 
@@ -862,9 +1393,9 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).To(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
 					})
 				})
 
@@ -876,9 +1407,66 @@ var _ = Describe("Bucket Reconciler", func() {
 						Expect(reconcileErr).ToNot(HaveOccurred())
 						Expect(objectStorageService.ExistsBucketCallCount()).To(Equal(1))
 						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(1))
-						var existingBucket v1alpha1.Bucket
+						var existingBucket v1beta1.Bucket
 						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
-						Expect(existingBucket.Finalizers).ToNot(ContainElement(v1alpha1.BucketFinalizer))
+						Expect(existingBucket.Finalizers).ToNot(ContainElement(v1beta1.BucketFinalizer))
+					})
+				})
+			})
+
+			When("an adopted bucket with access grants is being deleted (ReclaimPolicy = Delete)", func() {
+				BeforeEach(func() {
+					// creates dummy adopted bucket with access grants in deleting state
+					var gracePeriod int64 = 120
+					bucket := v1beta1.Bucket{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      BucketName,
+							Namespace: BucketNamespace,
+							Finalizers: []string{
+								v1beta1.BucketFinalizer,
+							},
+						},
+						Spec: v1beta1.BucketSpec{
+							Name:           BucketName,
+							ReclaimPolicy:  v1beta1.ReclaimPolicyDelete,
+							AdoptionPolicy: v1beta1.AdoptionPolicyAdopt,
+						},
+					}
+					_ = fakeClient.Create(ctx, &bucket)
+					bucket.Status.BucketID = BucketName
+					bucket.Status.Adoption = &v1beta1.BucketAdoption{AdoptedAt: metav1.Now()}
+					bucket.Status.AccessGrants = []v1beta1.BucketAccessGrantStatus{{
+						PrincipalID:      "00000000-0000-0000-0000-000000000001",
+						Role:             v1beta1.AccessGrantRoleReader,
+						RoleAssignmentID: "/assignments/reader",
+					}}
+					_ = fakeClient.Status().Update(ctx, &bucket)
+					_ = fakeClient.Delete(ctx, &bucket, &client.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+					objectStorageService.ExistsBucketReturns(true, nil)
+				})
+
+				When("revoking the access grants is failing", func() {
+					expectedError := errors.New("role assignment could not be deleted")
+					BeforeEach(func() {
+						objectStorageService.DeleteAccessGrantsReturns(expectedError)
+					})
+
+					It("was not deleted", func() {
+						Expect(reconcileErr).Should(MatchError(expectedError))
+						var existingBucket v1beta1.Bucket
+						_ = fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(existingBucket.Finalizers).To(ContainElement(v1beta1.BucketFinalizer))
+					})
+				})
+
+				When("revoking the access grants succeeds", func() {
+					It("revoked the access grants without deleting the bucket", func() {
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						Expect(objectStorageService.DeleteAccessGrantsCallCount()).To(Equal(1))
+						Expect(objectStorageService.DeleteBucketCallCount()).To(Equal(0))
+						var existingBucket v1beta1.Bucket
+						err := fakeClient.Get(ctx, bucketKey, &existingBucket)
+						Expect(err).To(HaveOccurred())
 					})
 				})
 			})
@@ -0,0 +1,385 @@
+// Package cosi maps the Container Object Storage Interface (COSI) driver calls onto the object storage services of
+// the operator, so that COSI BucketClaims and BucketAccesses are served by the same AWS and Azure adapters as Buckets.
+//
+// The requests and responses of the Driver mirror the messages of the COSI specification, and the Server translates
+// the gRPC calls of the COSI sidecar into them.
+package cosi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/aws"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/azure"
+)
+
+// Parameters of COSI BucketClasses and BucketAccessClasses understood by the driver.
+const (
+	ParameterExpirationDays          = "expirationDays"
+	ParameterEnforceSSLOnly          = "enforceSSLOnly"
+	ParameterAzureSKU                = "azureSKU"
+	ParameterAzureAccessTier         = "azureAccessTier"
+	ParameterServiceAccountName      = "serviceAccountName"
+	ParameterServiceAccountNamespace = "serviceAccountNamespace"
+	ParameterAccessLevel             = "accessLevel"
+)
+
+// AuthenticationTypeIAM is the only COSI authentication type supported by the driver: workloads assume the access
+// role of the bucket through their service account.
+const AuthenticationTypeIAM = "IAM"
+
+// Keys of the credentials returned by DriverGrantBucketAccess, one per protocol as in the COSI specification. With IAM
+// authentication, they identify the role the workloads assume instead of carrying keys.
+const (
+	CredentialsS3Key    = "s3"
+	CredentialsAzureKey = "azure"
+
+	CredentialRoleARN  = "roleArn"
+	CredentialClientID = "clientId"
+	CredentialTenantID = "tenantId"
+)
+
+// Errors returned by the driver. The gRPC server maps them to the matching status codes.
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrUnimplemented   = errors.New("unimplemented")
+)
+
+type DriverCreateBucketRequest struct {
+	Name       string
+	Parameters map[string]string
+}
+
+type DriverCreateBucketResponse struct {
+	BucketID   string
+	BucketInfo BucketInfo
+}
+
+// BucketInfo tells how to reach the bucket with the protocol of the provider.
+type BucketInfo struct {
+	// S3Region is the region of S3 buckets.
+	S3Region string
+	// AzureStorageAccount is the storage account of Azure containers.
+	AzureStorageAccount string
+}
+
+type DriverDeleteBucketRequest struct {
+	BucketID string
+}
+
+type DriverGrantBucketAccessRequest struct {
+	BucketID           string
+	Name               string
+	AuthenticationType string
+	Parameters         map[string]string
+}
+
+type DriverGrantBucketAccessResponse struct {
+	AccountID string
+	// Credentials are the secrets of the access per protocol.
+	Credentials map[string]map[string]string
+}
+
+type DriverRevokeBucketAccessRequest struct {
+	BucketID  string
+	AccountID string
+}
+
+// Driver implements the COSI identity and provisioner services.
+type Driver struct {
+	cluster.ClusterGetter
+	objectstorage.ObjectStorageServiceFactory
+	Client client.Client
+	Logger logr.Logger
+
+	// Name of the driver, referenced by the driverName of COSI BucketClasses.
+	Name string
+	// Namespace recorded as the owner of the buckets created through COSI, which are not namespaced.
+	Namespace string
+}
+
+// DriverGetInfo returns the name of the driver.
+func (d Driver) DriverGetInfo(ctx context.Context) string {
+	return d.Name
+}
+
+// DriverCreateBucket creates the bucket, or configures it again if it already exists and was created by the driver.
+func (d Driver) DriverCreateBucket(ctx context.Context, req *DriverCreateBucketRequest) (*DriverCreateBucketResponse, error) {
+	bucket, err := d.newBucket(req.Name, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := d.getCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objectStorageService, err := d.newObjectStorageService(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := objectStorageService.ExistsBucket(ctx, bucket)
+	if errors.Is(err, objectstorage.ErrBucketConflict) || errors.Is(err, objectstorage.ErrBucketUnmanaged) {
+		return nil, fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check if bucket %s exists: %w", bucket.Spec.Name, err)
+	}
+	if !exists {
+		err = objectStorageService.CreateBucket(ctx, bucket)
+		if errors.Is(err, objectstorage.ErrBucketConflict) {
+			return nil, fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to create bucket %s: %w", bucket.Spec.Name, err)
+		}
+	}
+	bucket.Status.BucketID = bucket.Spec.Name
+
+	err = objectStorageService.ConfigureBucket(ctx, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure bucket %s: %w", bucket.Spec.Name, err)
+	}
+	return &DriverCreateBucketResponse{BucketID: bucket.Status.BucketID, BucketInfo: bucketInfo(cluster, bucket)}, nil
+}
+
+// DriverDeleteBucket deletes the bucket. Buckets that do not exist anymore, or that were not created by the driver,
+// are left untouched.
+func (d Driver) DriverDeleteBucket(ctx context.Context, req *DriverDeleteBucketRequest) error {
+	bucket, err := d.newBucket(req.BucketID, nil)
+	if err != nil {
+		return err
+	}
+	cluster, err := d.getCluster(ctx)
+	if err != nil {
+		return err
+	}
+	objectStorageService, err := d.newObjectStorageService(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	exists, err := objectStorageService.ExistsBucket(ctx, bucket)
+	if errors.Is(err, objectstorage.ErrBucketConflict) || errors.Is(err, objectstorage.ErrBucketUnmanaged) {
+		d.Logger.Info("Bucket is not managed by the driver, not deleting it", "bucket", bucket.Spec.Name)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check if bucket %s exists: %w", bucket.Spec.Name, err)
+	}
+	if !exists {
+		return nil
+	}
+
+	err = objectStorageService.DeleteBucket(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to delete bucket %s: %w", bucket.Spec.Name, err)
+	}
+	return nil
+}
+
+// DriverGrantBucketAccess configures an access role named after the COSI BucketAccess, that the service account given
+// in the parameters can assume with the access level of the parameters. The role name is returned as the account ID,
+// and the role ARN, or the client ID of the Azure managed identity, as the credentials.
+func (d Driver) DriverGrantBucketAccess(ctx context.Context, req *DriverGrantBucketAccessRequest) (*DriverGrantBucketAccessResponse, error) {
+	if req.AuthenticationType != AuthenticationTypeIAM {
+		return nil, fmt.Errorf("%w: authentication type %q, only %s is supported", ErrUnimplemented, req.AuthenticationType, AuthenticationTypeIAM)
+	}
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: missing access name", ErrInvalidArgument)
+	}
+	serviceAccount := v1beta1.ServiceAccountReference{
+		Name:      req.Parameters[ParameterServiceAccountName],
+		Namespace: req.Parameters[ParameterServiceAccountNamespace],
+	}
+	if serviceAccount.Name == "" || serviceAccount.Namespace == "" {
+		return nil, fmt.Errorf("%w: parameters %s and %s are required", ErrInvalidArgument, ParameterServiceAccountName, ParameterServiceAccountNamespace)
+	}
+	accessLevel := v1beta1.AccessLevel(req.Parameters[ParameterAccessLevel])
+	switch accessLevel {
+	case "", v1beta1.AccessLevelReadOnly, v1beta1.AccessLevelReadWriteNoDelete, v1beta1.AccessLevelReadWrite, v1beta1.AccessLevelAdmin:
+	default:
+		return nil, fmt.Errorf("%w: parameter %s must be ReadOnly, ReadWriteNoDelete, ReadWrite or Admin, got %q", ErrInvalidArgument, ParameterAccessLevel, accessLevel)
+	}
+
+	bucket, err := d.newBucket(req.BucketID, nil)
+	if err != nil {
+		return nil, err
+	}
+	bucket.Spec.Access = &v1beta1.BucketAccess{
+		Role: &v1beta1.BucketAccessRole{
+			Name:              req.Name,
+			ServiceAccount:    serviceAccount,
+			BucketPermissions: v1beta1.BucketPermissions{AccessLevel: accessLevel},
+		},
+	}
+	cluster, err := d.getCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accessRoleService, err := d.newAccessRoleService(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	err = accessRoleService.ConfigureRole(ctx, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure access role %s for bucket %s: %w", req.Name, bucket.Spec.Name, err)
+	}
+	return &DriverGrantBucketAccessResponse{AccountID: req.Name, Credentials: credentials(bucket)}, nil
+}
+
+// bucketInfo returns how to reach the bucket on the cluster of the driver.
+func bucketInfo(cluster cluster.Cluster, bucket *v1beta1.Bucket) BucketInfo {
+	switch cluster.(type) {
+	case aws.AWSCluster:
+		return BucketInfo{S3Region: cluster.GetRegion()}
+	case azure.AzureCluster:
+		return BucketInfo{AzureStorageAccount: azure.SanitizeStorageAccountName(bucket.Spec.Name)}
+	}
+	return BucketInfo{}
+}
+
+// credentials returns the identity of the access role configured for the bucket, as recorded in its status.
+func credentials(bucket *v1beta1.Bucket) map[string]map[string]string {
+	status := bucket.Status.AccessRole
+	switch {
+	case status == nil:
+		return nil
+	case status.ARN != "":
+		return map[string]map[string]string{
+			CredentialsS3Key: {CredentialRoleARN: status.ARN},
+		}
+	case status.ClientID != "":
+		return map[string]map[string]string{
+			CredentialsAzureKey: {CredentialClientID: status.ClientID, CredentialTenantID: status.TenantID},
+		}
+	}
+	return nil
+}
+
+// DriverRevokeBucketAccess deletes the access role created by DriverGrantBucketAccess.
+func (d Driver) DriverRevokeBucketAccess(ctx context.Context, req *DriverRevokeBucketAccessRequest) error {
+	if req.AccountID == "" {
+		return fmt.Errorf("%w: missing account ID", ErrInvalidArgument)
+	}
+
+	bucket, err := d.newBucket(req.BucketID, nil)
+	if err != nil {
+		return err
+	}
+	bucket.Spec.Access = &v1beta1.BucketAccess{
+		Role: &v1beta1.BucketAccessRole{Name: req.AccountID},
+	}
+	cluster, err := d.getCluster(ctx)
+	if err != nil {
+		return err
+	}
+	accessRoleService, err := d.newAccessRoleService(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = accessRoleService.DeleteRole(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to delete access role %s for bucket %s: %w", req.AccountID, bucket.Spec.Name, err)
+	}
+	return nil
+}
+
+// newBucket builds the Bucket the services work on out of the name of the COSI bucket and the parameters of its class.
+func (d Driver) newBucket(name string, parameters map[string]string) (*v1beta1.Bucket, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: missing bucket name", ErrInvalidArgument)
+	}
+
+	bucket := &v1beta1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: d.Namespace,
+		},
+		Spec: v1beta1.BucketSpec{
+			Name: name,
+			// The COSI controller only deletes buckets whose deletion policy is Delete.
+			ReclaimPolicy: v1beta1.ReclaimPolicyDelete,
+		},
+	}
+
+	for key, value := range parameters {
+		switch key {
+		case ParameterExpirationDays:
+			days, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || days <= 0 {
+				return nil, fmt.Errorf("%w: parameter %s must be a positive number of days, got %q", ErrInvalidArgument, key, value)
+			}
+			bucket.Spec.Lifecycle = &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: int32(days)}}
+		case ParameterEnforceSSLOnly:
+			enforceSSLOnly, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: parameter %s must be a boolean, got %q", ErrInvalidArgument, key, value)
+			}
+			bucket.Spec.Parameters = withAWSParameters(bucket.Spec.Parameters)
+			bucket.Spec.Parameters.AWS.EnforceSSLOnly = &enforceSSLOnly
+		case ParameterAzureSKU:
+			bucket.Spec.Parameters = withAzureParameters(bucket.Spec.Parameters)
+			bucket.Spec.Parameters.Azure.SKU = v1beta1.AzureSKU(value)
+		case ParameterAzureAccessTier:
+			bucket.Spec.Parameters = withAzureParameters(bucket.Spec.Parameters)
+			bucket.Spec.Parameters.Azure.AccessTier = v1beta1.AzureAccessTier(value)
+		default:
+			return nil, fmt.Errorf("%w: unknown parameter %s", ErrInvalidArgument, key)
+		}
+	}
+	return bucket, nil
+}
+
+func withAWSParameters(parameters *v1beta1.BucketParameters) *v1beta1.BucketParameters {
+	if parameters == nil {
+		parameters = &v1beta1.BucketParameters{}
+	}
+	if parameters.AWS == nil {
+		parameters.AWS = &v1beta1.AWSBucketParameters{}
+	}
+	return parameters
+}
+
+func withAzureParameters(parameters *v1beta1.BucketParameters) *v1beta1.BucketParameters {
+	if parameters == nil {
+		parameters = &v1beta1.BucketParameters{}
+	}
+	if parameters.Azure == nil {
+		parameters.Azure = &v1beta1.AzureBucketParameters{}
+	}
+	return parameters
+}
+
+func (d Driver) getCluster(ctx context.Context) (cluster.Cluster, error) {
+	cluster, err := d.GetCluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	return cluster, nil
+}
+
+func (d Driver) newObjectStorageService(ctx context.Context, cluster cluster.Cluster) (objectstorage.ObjectStorageService, error) {
+	objectStorageService, err := d.NewObjectStorageService(ctx, d.Logger, cluster, d.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object storage service: %w", err)
+	}
+	return objectStorageService, nil
+}
+
+func (d Driver) newAccessRoleService(ctx context.Context, cluster cluster.Cluster) (objectstorage.AccessRoleService, error) {
+	accessRoleService, err := d.NewAccessRoleService(ctx, d.Logger, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access role service: %w", err)
+	}
+	return accessRoleService, nil
+}
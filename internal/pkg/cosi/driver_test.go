@@ -0,0 +1,201 @@
+package cosi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster/clusterfakes"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/objectstoragefakes"
+)
+
+func newDriver(objectStorageService *objectstoragefakes.FakeObjectStorageService, accessRoleService *objectstoragefakes.FakeAccessRoleService) Driver {
+	serviceFactory := &objectstoragefakes.FakeObjectStorageServiceFactory{}
+	serviceFactory.NewObjectStorageServiceReturns(objectStorageService, nil)
+	serviceFactory.NewAccessRoleServiceReturns(accessRoleService, nil)
+	return Driver{
+		ClusterGetter:               &clusterfakes.FakeClusterGetter{},
+		ObjectStorageServiceFactory: serviceFactory,
+		Logger:                      logr.Discard(),
+		Name:                        "objectstorage.giantswarm.io",
+		Namespace:                   "giantswarm",
+	}
+}
+
+func Test_DriverCreateBucket(t *testing.T) {
+	testCases := []struct {
+		name         string
+		parameters   map[string]string
+		exists       bool
+		existsErr    error
+		expectedErr  error
+		expectCreate bool
+		expectedSpec func(*v1beta1.Bucket) bool
+	}{
+		{
+			name:         "case 0: new bucket",
+			expectCreate: true,
+		},
+		{
+			name:   "case 1: bucket created by the driver",
+			exists: true,
+		},
+		{
+			name:        "case 2: bucket owned by someone else",
+			existsErr:   fmt.Errorf("%w: S3 bucket is not owned by account 123456789012", objectstorage.ErrBucketConflict),
+			expectedErr: ErrAlreadyExists,
+		},
+		{
+			name:        "case 3: bucket not created by the driver",
+			exists:      true,
+			existsErr:   fmt.Errorf("%w: S3 bucket already exists", objectstorage.ErrBucketUnmanaged),
+			expectedErr: ErrAlreadyExists,
+		},
+		{
+			name:         "case 4: class parameters",
+			parameters:   map[string]string{ParameterExpirationDays: "30", ParameterEnforceSSLOnly: "false", ParameterAzureSKU: "Standard_GRS"},
+			expectCreate: true,
+			expectedSpec: func(bucket *v1beta1.Bucket) bool {
+				return *bucket.ExpirationDays() == 30 && !bucket.EnforceSSLOnly() && bucket.AzureSKU() == v1beta1.AzureSKUStandardGRS
+			},
+		},
+		{
+			name:        "case 5: invalid expiration days",
+			parameters:  map[string]string{ParameterExpirationDays: "0"},
+			expectedErr: ErrInvalidArgument,
+		},
+		{
+			name:        "case 6: unknown parameter",
+			parameters:  map[string]string{"storageClass": "GLACIER"},
+			expectedErr: ErrInvalidArgument,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			objectStorageService := &objectstoragefakes.FakeObjectStorageService{}
+			objectStorageService.ExistsBucketReturns(tc.exists, tc.existsErr)
+			driver := newDriver(objectStorageService, &objectstoragefakes.FakeAccessRoleService{})
+
+			response, err := driver.DriverCreateBucket(context.Background(), &DriverCreateBucketRequest{
+				Name:       "bucket-0a1b2c",
+				Parameters: tc.parameters,
+			})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr != nil {
+				if objectStorageService.ConfigureBucketCallCount() != 0 {
+					t.Fatal("expected bucket not to be configured")
+				}
+				return
+			}
+
+			if response.BucketID != "bucket-0a1b2c" {
+				t.Fatalf("expected bucket ID bucket-0a1b2c, got %s", response.BucketID)
+			}
+			if created := objectStorageService.CreateBucketCallCount() == 1; created != tc.expectCreate {
+				t.Fatalf("bucket created: %t, expected %t", created, tc.expectCreate)
+			}
+			_, bucket := objectStorageService.ConfigureBucketArgsForCall(0)
+			if bucket.Namespace != "giantswarm" {
+				t.Fatalf("expected bucket in namespace giantswarm, got %s", bucket.Namespace)
+			}
+			if tc.expectedSpec != nil && !tc.expectedSpec(bucket) {
+				t.Fatalf("unexpected bucket spec %+v", bucket.Spec)
+			}
+		})
+	}
+}
+
+func Test_DriverGrantBucketAccess(t *testing.T) {
+	testCases := []struct {
+		name                string
+		authenticationType  string
+		parameters          map[string]string
+		expectedAccessLevel v1beta1.AccessLevel
+		expectedErr         error
+	}{
+		{
+			name:               "case 0: IAM access",
+			authenticationType: AuthenticationTypeIAM,
+			parameters:         map[string]string{ParameterServiceAccountName: "loki", ParameterServiceAccountNamespace: "loki"},
+		},
+		{
+			name:               "case 1: key access",
+			authenticationType: "Key",
+			parameters:         map[string]string{ParameterServiceAccountName: "loki", ParameterServiceAccountNamespace: "loki"},
+			expectedErr:        ErrUnimplemented,
+		},
+		{
+			name:               "case 2: missing service account",
+			authenticationType: AuthenticationTypeIAM,
+			expectedErr:        ErrInvalidArgument,
+		},
+		{
+			name:                "case 3: read-only IAM access",
+			authenticationType:  AuthenticationTypeIAM,
+			parameters:          map[string]string{ParameterServiceAccountName: "loki", ParameterServiceAccountNamespace: "loki", ParameterAccessLevel: "ReadOnly"},
+			expectedAccessLevel: v1beta1.AccessLevelReadOnly,
+		},
+		{
+			name:               "case 4: unknown access level",
+			authenticationType: AuthenticationTypeIAM,
+			parameters:         map[string]string{ParameterServiceAccountName: "loki", ParameterServiceAccountNamespace: "loki", ParameterAccessLevel: "WriteOnly"},
+			expectedErr:        ErrInvalidArgument,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			accessRoleService := &objectstoragefakes.FakeAccessRoleService{}
+			accessRoleService.ConfigureRoleCalls(func(ctx context.Context, bucket *v1beta1.Bucket) error {
+				bucket.Status.AccessRole = &v1beta1.BucketAccessRoleStatus{ARN: "arn:aws:iam::123456789012:role/" + bucket.AccessRole().Name}
+				return nil
+			})
+			driver := newDriver(&objectstoragefakes.FakeObjectStorageService{}, accessRoleService)
+
+			response, err := driver.DriverGrantBucketAccess(context.Background(), &DriverGrantBucketAccessRequest{
+				BucketID:           "bucket-0a1b2c",
+				Name:               "access-3d4e5f",
+				AuthenticationType: tc.authenticationType,
+				Parameters:         tc.parameters,
+			})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr != nil {
+				return
+			}
+
+			if response.AccountID != "access-3d4e5f" {
+				t.Fatalf("expected account ID access-3d4e5f, got %s", response.AccountID)
+			}
+			_, bucket := accessRoleService.ConfigureRoleArgsForCall(0)
+			role := bucket.AccessRole()
+			if role == nil || role.Name != "access-3d4e5f" || role.ServiceAccount.Name != "loki" || role.ServiceAccount.Namespace != "loki" {
+				t.Fatalf("unexpected access role %+v", role)
+			}
+			if role.AccessLevel != tc.expectedAccessLevel {
+				t.Fatalf("expected access level %q, got %q", tc.expectedAccessLevel, role.AccessLevel)
+			}
+			expectedCredentials := map[string]map[string]string{
+				CredentialsS3Key: {CredentialRoleARN: "arn:aws:iam::123456789012:role/access-3d4e5f"},
+			}
+			if !cmp.Equal(response.Credentials, expectedCredentials) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(expectedCredentials, response.Credentials))
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package cosi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cosispec "sigs.k8s.io/container-object-storage-interface-spec"
+)
+
+// Server serves the identity and provisioner services of the Driver to the COSI sidecar over gRPC.
+type Server struct {
+	Driver Driver
+	// Endpoint the server listens on, e.g. unix:///var/lib/cosi/cosi.sock or tcp://127.0.0.1:9000.
+	Endpoint string
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every replica serves the sidecar running next to it,
+// which elects its own leader.
+func (s Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable. It serves the driver until the context is cancelled.
+func (s Server) Start(ctx context.Context) error {
+	listener, err := listen(s.Endpoint)
+	if err != nil {
+		return err
+	}
+	return s.serve(ctx, listener)
+}
+
+func (s Server) serve(ctx context.Context, listener net.Listener) error {
+	server := grpc.NewServer()
+	cosispec.RegisterIdentityServer(server, driverServer{s.Driver})
+	cosispec.RegisterProvisionerServer(server, driverServer{s.Driver})
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	s.Driver.Logger.Info("Serving COSI driver", "name", s.Driver.Name, "endpoint", listener.Addr().String())
+	if err := server.Serve(listener); err != nil {
+		return fmt.Errorf("failed to serve COSI driver on %s: %w", s.Endpoint, err)
+	}
+	return nil
+}
+
+// listen listens on the unix socket or TCP address of the endpoint. A socket left over by a previous run is removed.
+func listen(endpoint string) (net.Listener, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse COSI endpoint %s: %w", endpoint, err)
+	}
+
+	var address string
+	switch u.Scheme {
+	case "unix":
+		address = u.Path
+		if err := os.Remove(address); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove COSI socket %s: %w", address, err)
+		}
+	case "tcp":
+		address = u.Host
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q of COSI endpoint %s, expected unix or tcp", u.Scheme, endpoint)
+	}
+
+	listener, err := net.Listen(u.Scheme, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on COSI endpoint %s: %w", endpoint, err)
+	}
+	return listener, nil
+}
+
+// driverServer translates the gRPC messages of the COSI specification from and to the ones of the Driver.
+type driverServer struct {
+	driver Driver
+}
+
+func (s driverServer) DriverGetInfo(ctx context.Context, req *cosispec.DriverGetInfoRequest) (*cosispec.DriverGetInfoResponse, error) {
+	return &cosispec.DriverGetInfoResponse{Name: s.driver.DriverGetInfo(ctx)}, nil
+}
+
+func (s driverServer) DriverCreateBucket(ctx context.Context, req *cosispec.DriverCreateBucketRequest) (*cosispec.DriverCreateBucketResponse, error) {
+	response, err := s.driver.DriverCreateBucket(ctx, &DriverCreateBucketRequest{
+		Name:       req.GetName(),
+		Parameters: req.GetParameters(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	bucketInfo := &cosispec.Protocol{}
+	switch {
+	case response.BucketInfo.S3Region != "":
+		bucketInfo.Type = &cosispec.Protocol_S3{S3: &cosispec.S3{
+			Region:           response.BucketInfo.S3Region,
+			SignatureVersion: cosispec.S3SignatureVersion_S3V4,
+		}}
+	case response.BucketInfo.AzureStorageAccount != "":
+		bucketInfo.Type = &cosispec.Protocol_AzureBlob{AzureBlob: &cosispec.AzureBlob{
+			StorageAccount: response.BucketInfo.AzureStorageAccount,
+		}}
+	}
+	return &cosispec.DriverCreateBucketResponse{BucketId: response.BucketID, BucketInfo: bucketInfo}, nil
+}
+
+func (s driverServer) DriverDeleteBucket(ctx context.Context, req *cosispec.DriverDeleteBucketRequest) (*cosispec.DriverDeleteBucketResponse, error) {
+	err := s.driver.DriverDeleteBucket(ctx, &DriverDeleteBucketRequest{BucketID: req.GetBucketId()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &cosispec.DriverDeleteBucketResponse{}, nil
+}
+
+func (s driverServer) DriverGrantBucketAccess(ctx context.Context, req *cosispec.DriverGrantBucketAccessRequest) (*cosispec.DriverGrantBucketAccessResponse, error) {
+	response, err := s.driver.DriverGrantBucketAccess(ctx, &DriverGrantBucketAccessRequest{
+		BucketID:           req.GetBucketId(),
+		Name:               req.GetName(),
+		AuthenticationType: req.GetAuthenticationType().String(),
+		Parameters:         req.GetParameters(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	credentials := map[string]*cosispec.CredentialDetails{}
+	for protocol, secrets := range response.Credentials {
+		credentials[protocol] = &cosispec.CredentialDetails{Secrets: secrets}
+	}
+	return &cosispec.DriverGrantBucketAccessResponse{AccountId: response.AccountID, Credentials: credentials}, nil
+}
+
+func (s driverServer) DriverRevokeBucketAccess(ctx context.Context, req *cosispec.DriverRevokeBucketAccessRequest) (*cosispec.DriverRevokeBucketAccessResponse, error) {
+	err := s.driver.DriverRevokeBucketAccess(ctx, &DriverRevokeBucketAccessRequest{
+		BucketID:  req.GetBucketId(),
+		AccountID: req.GetAccountId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &cosispec.DriverRevokeBucketAccessResponse{}, nil
+}
+
+// toStatus maps the errors of the driver to the gRPC status codes expected by the COSI sidecar.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrUnimplemented):
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
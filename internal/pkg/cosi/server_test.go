@@ -0,0 +1,109 @@
+package cosi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	cosispec "sigs.k8s.io/container-object-storage-interface-spec"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster/clusterfakes"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/aws"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/objectstoragefakes"
+)
+
+func Test_Server(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	accessRoleService := &objectstoragefakes.FakeAccessRoleService{}
+	accessRoleService.ConfigureRoleCalls(func(ctx context.Context, bucket *v1beta1.Bucket) error {
+		bucket.Status.AccessRole = &v1beta1.BucketAccessRoleStatus{ARN: "arn:aws:iam::123456789012:role/" + bucket.AccessRole().Name}
+		return nil
+	})
+	driver := newDriver(&objectstoragefakes.FakeObjectStorageService{}, accessRoleService)
+	clusterGetter := &clusterfakes.FakeClusterGetter{}
+	clusterGetter.GetClusterReturns(aws.AWSCluster{Region: "eu-west-1"}, nil)
+	driver.ClusterGetter = clusterGetter
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		if err := (Server{Driver: driver}).serve(ctx, listener); err != nil {
+			t.Error(err)
+		}
+	}()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close() //nolint:errcheck
+	identity := cosispec.NewIdentityClient(conn)
+	provisioner := cosispec.NewProvisionerClient(conn)
+
+	info, err := identity.DriverGetInfo(ctx, &cosispec.DriverGetInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.GetName() != "objectstorage.giantswarm.io" {
+		t.Fatalf("expected driver name objectstorage.giantswarm.io, got %s", info.GetName())
+	}
+
+	bucket, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{Name: "bucket-0a1b2c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBucket := &cosispec.DriverCreateBucketResponse{
+		BucketId: "bucket-0a1b2c",
+		BucketInfo: &cosispec.Protocol{Type: &cosispec.Protocol_S3{S3: &cosispec.S3{
+			Region:           "eu-west-1",
+			SignatureVersion: cosispec.S3SignatureVersion_S3V4,
+		}}},
+	}
+	if !cmp.Equal(bucket, expectedBucket, protocmp.Transform()) {
+		t.Fatalf("\n\n%s\n", cmp.Diff(expectedBucket, bucket, protocmp.Transform()))
+	}
+
+	access, err := provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+		BucketId:           "bucket-0a1b2c",
+		Name:               "access-3d4e5f",
+		AuthenticationType: cosispec.AuthenticationType_IAM,
+		Parameters:         map[string]string{ParameterServiceAccountName: "loki", ParameterServiceAccountNamespace: "loki"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAccess := &cosispec.DriverGrantBucketAccessResponse{
+		AccountId: "access-3d4e5f",
+		Credentials: map[string]*cosispec.CredentialDetails{
+			CredentialsS3Key: {Secrets: map[string]string{CredentialRoleARN: "arn:aws:iam::123456789012:role/access-3d4e5f"}},
+		},
+	}
+	if !cmp.Equal(access, expectedAccess, protocmp.Transform()) {
+		t.Fatalf("\n\n%s\n", cmp.Diff(expectedAccess, access, protocmp.Transform()))
+	}
+
+	_, err = provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+		BucketId:           "bucket-0a1b2c",
+		Name:               "access-3d4e5f",
+		AuthenticationType: cosispec.AuthenticationType_Key,
+	})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected code %s, got %v", codes.Unimplemented, err)
+	}
+
+	_, err = provisioner.DriverRevokeBucketAccess(ctx, &cosispec.DriverRevokeBucketAccessRequest{BucketId: "bucket-0a1b2c"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected code %s, got %v", codes.InvalidArgument, err)
+	}
+}
@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// EnforceSSLOnlyStatementID is the ID of the bucket policy statement of the operator denying the requests that are
+// not sent over TLS.
+const EnforceSSLOnlyStatementID = "EnforceSSLOnly"
+
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of an IAM policy.
+type PolicyStatement struct {
+	Sid          string                    `json:"Sid,omitempty"`
+	Effect       string                    `json:"Effect"`
+	Principal    any                       `json:"Principal,omitempty"`
+	NotPrincipal any                       `json:"NotPrincipal,omitempty"`
+	Action       PolicyStrings             `json:"Action,omitempty"`
+	NotAction    PolicyStrings             `json:"NotAction,omitempty"`
+	Resource     PolicyStrings             `json:"Resource,omitempty"`
+	NotResource  PolicyStrings             `json:"NotResource,omitempty"`
+	Condition    map[string]map[string]any `json:"Condition,omitempty"`
+}
+
+// PolicyStrings is a list of strings that IAM policies may write as a single string.
+type PolicyStrings []string
+
+func (p *PolicyStrings) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*p = PolicyStrings{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("expected a string or a list of strings: %w", err)
+	}
+	*p = values
+	return nil
+}
+
+// ParseRawBucketPolicyStatements parses a JSON array of bucket policy statements. Unknown fields are rejected, and
+// every statement needs an effect, a principal and an action.
+func ParseRawBucketPolicyStatements(raw string) ([]PolicyStatement, error) {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var statements []PolicyStatement
+	if err := decoder.Decode(&statements); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of policy statements: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("expected a single JSON array of policy statements")
+	}
+
+	for i, statement := range statements {
+		switch {
+		case statement.Effect != string(v1beta1.PolicyEffectAllow) && statement.Effect != string(v1beta1.PolicyEffectDeny):
+			return nil, fmt.Errorf("statement %d: Effect must be Allow or Deny", i)
+		case statement.Principal == nil && statement.NotPrincipal == nil:
+			return nil, fmt.Errorf("statement %d: Principal or NotPrincipal is required", i)
+		case len(statement.Action) == 0 && len(statement.NotAction) == 0:
+			return nil, fmt.Errorf("statement %d: Action or NotAction is required", i)
+		}
+	}
+	return statements, nil
+}
+
+// bucketPolicyDocument returns the policy of the bucket: the statements of the operator followed by the statements of
+// the bucket. It is empty when the bucket has no statement at all.
+func bucketPolicyDocument(bucketPolicyTemplate *template.Template, awsDomain string, bucket *v1beta1.Bucket) (string, error) {
+	policy := policyDocument{Version: "2012-10-17"}
+	if bucket.EnforceSSLOnly() {
+		var rendered bytes.Buffer
+		err := bucketPolicyTemplate.Execute(&rendered, BucketPolicyData{
+			AWSDomain:  awsDomain,
+			BucketName: bucket.Spec.Name,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to execute bucket policy template: %w", err)
+		}
+		err = json.Unmarshal(rendered.Bytes(), &policy)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse bucket policy template: %w", err)
+		}
+	}
+
+	if bucket.Spec.BucketPolicy != nil {
+		bucketARN := fmt.Sprintf("arn:%s:s3:::%s", awsDomain, bucket.Spec.Name)
+		for _, statement := range bucket.Spec.BucketPolicy.Statements {
+			policy.Statement = append(policy.Statement, bucketPolicyStatement(bucketARN, statement))
+		}
+
+		if bucket.Spec.BucketPolicy.RawStatements != "" {
+			statements, err := ParseRawBucketPolicyStatements(bucket.Spec.BucketPolicy.RawStatements)
+			if err != nil {
+				return "", fmt.Errorf("invalid raw bucket policy statements: %w", err)
+			}
+			policy.Statement = append(policy.Statement, statements...)
+		}
+	}
+
+	if len(policy.Statement) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bucket policy: %w", err)
+	}
+	return string(data), nil
+}
+
+// bucketPolicyStatement converts a statement of the bucket. Statements without resources apply to the bucket and
+// all its objects.
+func bucketPolicyStatement(bucketARN string, statement v1beta1.BucketPolicyStatement) PolicyStatement {
+	principal := map[string]PolicyStrings{}
+	if len(statement.Principal.AWS) != 0 {
+		principal["AWS"] = statement.Principal.AWS
+	}
+	if len(statement.Principal.Service) != 0 {
+		principal["Service"] = statement.Principal.Service
+	}
+
+	resources := statement.Resources
+	if len(resources) == 0 {
+		resources = []string{bucketARN, bucketARN + "/*"}
+	}
+
+	var condition map[string]map[string]any
+	for _, c := range statement.Conditions {
+		if condition == nil {
+			condition = map[string]map[string]any{}
+		}
+		if condition[c.Operator] == nil {
+			condition[c.Operator] = map[string]any{}
+		}
+		condition[c.Operator][c.Key] = c.Values
+	}
+
+	return PolicyStatement{
+		Sid:       statement.Sid,
+		Effect:    string(statement.Effect),
+		Principal: principal,
+		Action:    statement.Actions,
+		Resource:  resources,
+		Condition: condition,
+	}
+}
@@ -0,0 +1,147 @@
+package aws
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"text/template"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_BucketPolicyDocument(t *testing.T) {
+	enforceSSLOnly := false
+
+	testCases := []struct {
+		name               string
+		parameters         *v1beta1.BucketParameters
+		bucketPolicy       *v1beta1.BucketPolicy
+		expectedStatements []map[string]any
+		expectError        bool
+	}{
+		{
+			name: "case 0: EnforceSSLOnly only",
+			expectedStatements: []map[string]any{{
+				"Sid":       EnforceSSLOnlyStatementID,
+				"Effect":    "Deny",
+				"Principal": "*",
+				"Action":    []any{"s3:*"},
+				"Resource":  []any{"arn:aws:s3:::giantswarm-glippy-loki", "arn:aws:s3:::giantswarm-glippy-loki/*"},
+				"Condition": map[string]any{"Bool": map[string]any{"aws:SecureTransport": "false"}},
+			}},
+		},
+		{
+			name:       "case 1: no statement at all",
+			parameters: &v1beta1.BucketParameters{AWS: &v1beta1.AWSBucketParameters{EnforceSSLOnly: &enforceSSLOnly}},
+		},
+		{
+			name:       "case 2: structured and raw statements",
+			parameters: &v1beta1.BucketParameters{AWS: &v1beta1.AWSBucketParameters{EnforceSSLOnly: &enforceSSLOnly}},
+			bucketPolicy: &v1beta1.BucketPolicy{
+				Statements: []v1beta1.BucketPolicyStatement{
+					{
+						Sid:       "CrossAccountRead",
+						Effect:    v1beta1.PolicyEffectAllow,
+						Principal: v1beta1.BucketPolicyPrincipal{AWS: []string{"arn:aws:iam::210987654321:root"}},
+						Actions:   []string{"s3:GetObject", "s3:ListBucket"},
+					},
+					{
+						Sid:       "DenyOutsideVpce",
+						Effect:    v1beta1.PolicyEffectDeny,
+						Principal: v1beta1.BucketPolicyPrincipal{AWS: []string{"*"}},
+						Actions:   []string{"s3:GetObject"},
+						Resources: []string{"arn:aws:s3:::giantswarm-glippy-loki/private/*"},
+						Conditions: []v1beta1.BucketPolicyCondition{
+							{Operator: "StringNotEquals", Key: "aws:SourceVpce", Values: []string{"vpce-1a2b3c4d"}},
+						},
+					},
+				},
+				RawStatements: `[{"Sid": "DenyUnencryptedUploads", "Effect": "Deny", "Principal": "*", "Action": "s3:PutObject",
+					"Resource": "arn:aws:s3:::giantswarm-glippy-loki/*", "Condition": {"Null": {"s3:x-amz-server-side-encryption": "true"}}}]`,
+			},
+			expectedStatements: []map[string]any{
+				{
+					"Sid":       "CrossAccountRead",
+					"Effect":    "Allow",
+					"Principal": map[string]any{"AWS": []any{"arn:aws:iam::210987654321:root"}},
+					"Action":    []any{"s3:GetObject", "s3:ListBucket"},
+					"Resource":  []any{"arn:aws:s3:::giantswarm-glippy-loki", "arn:aws:s3:::giantswarm-glippy-loki/*"},
+				},
+				{
+					"Sid":       "DenyOutsideVpce",
+					"Effect":    "Deny",
+					"Principal": map[string]any{"AWS": []any{"*"}},
+					"Action":    []any{"s3:GetObject"},
+					"Resource":  []any{"arn:aws:s3:::giantswarm-glippy-loki/private/*"},
+					"Condition": map[string]any{"StringNotEquals": map[string]any{"aws:SourceVpce": []any{"vpce-1a2b3c4d"}}},
+				},
+				{
+					"Sid":       "DenyUnencryptedUploads",
+					"Effect":    "Deny",
+					"Principal": "*",
+					"Action":    []any{"s3:PutObject"},
+					"Resource":  []any{"arn:aws:s3:::giantswarm-glippy-loki/*"},
+					"Condition": map[string]any{"Null": map[string]any{"s3:x-amz-server-side-encryption": "true"}},
+				},
+			},
+		},
+		{
+			name: "case 3: raw statement without principal",
+			bucketPolicy: &v1beta1.BucketPolicy{
+				RawStatements: `[{"Effect": "Allow", "Action": "s3:GetObject"}]`,
+			},
+			expectError: true,
+		},
+		{
+			name: "case 4: raw statement with an unknown field",
+			bucketPolicy: &v1beta1.BucketPolicy{
+				RawStatements: `[{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Actions": "s3:PutObject"}]`,
+			},
+			expectError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{
+				Name:         "giantswarm-glippy-loki",
+				Parameters:   tc.parameters,
+				BucketPolicy: tc.bucketPolicy,
+			}}
+			policy, err := bucketPolicyDocument(template.Must(template.New("bucketPolicy").Parse(bucketPolicy)), "aws", bucket)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.expectedStatements == nil {
+				if policy != "" {
+					t.Fatalf("expected no policy, got %s", policy)
+				}
+				return
+			}
+			var document struct {
+				Version   string
+				Statement []map[string]any
+			}
+			if err := json.Unmarshal([]byte(policy), &document); err != nil {
+				t.Fatalf("bucket policy is not valid JSON: %v\n%s", err, policy)
+			}
+			if document.Version != "2012-10-17" {
+				t.Fatalf("unexpected version %s", document.Version)
+			}
+			if !cmp.Equal(document.Statement, tc.expectedStatements) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatements, document.Statement))
+			}
+		})
+	}
+}
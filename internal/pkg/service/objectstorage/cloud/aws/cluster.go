@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -26,6 +27,17 @@ const (
 	VersionCluster         = "v1beta2"
 	KindClusterIdentity    = "AWSClusterRoleIdentity"
 	VersionClusterIdentity = "v1beta2"
+
+	// OIDCIssuersAnnotation lists the comma-separated issuer URLs of the OIDC providers access roles trust on the
+	// AWSCluster of the management cluster. It replaces the default IRSA domain, e.g. for clusters using a custom
+	// issuer or migrating between issuers.
+	OIDCIssuersAnnotation = "objectstorage.giantswarm.io/oidc-issuers"
+	// OIDCAudiencesAnnotation lists the comma-separated audiences the tokens of the OIDC providers of the
+	// OIDCIssuersAnnotation must be issued for.
+	OIDCAudiencesAnnotation = "objectstorage.giantswarm.io/oidc-audiences"
+	// EKSClusterNameAnnotation sets the name of the EKS cluster of the management cluster on its AWSCluster, where
+	// access roles in the PodIdentity mode associate their service accounts. Defaults to the management cluster name.
+	EKSClusterNameAnnotation = "objectstorage.giantswarm.io/eks-cluster-name"
 )
 
 func (c AWSClusterGetter) GetCluster(ctx context.Context) (cluster.Cluster, error) {
@@ -65,19 +77,47 @@ func (c AWSClusterGetter) GetCluster(ctx context.Context) (cluster.Cluster, erro
 		logger.Info("No cluster tags found")
 	}
 
+	eksClusterName := cluster.GetAnnotations()[EKSClusterNameAnnotation]
+	if eksClusterName == "" {
+		eksClusterName = c.ManagementCluster.Name
+	}
+
 	return AWSCluster{
-		Client:     c.Client,
-		Name:       c.ManagementCluster.Name,
-		Namespace:  c.ManagementCluster.Namespace,
-		BaseDomain: c.ManagementCluster.BaseDomain,
-		Region:     c.ManagementCluster.Region,
-		Tags:       clusterTags,
+		Client:         c.Client,
+		Name:           c.ManagementCluster.Name,
+		Namespace:      c.ManagementCluster.Namespace,
+		BaseDomain:     c.ManagementCluster.BaseDomain,
+		Region:         c.ManagementCluster.Region,
+		Tags:           clusterTags,
+		OIDCProviders:  oidcProvidersFromAnnotations(cluster.GetAnnotations()),
+		EKSClusterName: eksClusterName,
 		Credentials: AWSCredentials{
 			Role: roleArn,
 		},
 	}, nil
 }
 
+// oidcProvidersFromAnnotations returns the OIDC providers set by the annotations of the AWSCluster, if any.
+func oidcProvidersFromAnnotations(annotations map[string]string) []TrustedOIDCProvider {
+	audiences := splitAnnotation(annotations[OIDCAudiencesAnnotation])
+
+	var providers []TrustedOIDCProvider
+	for _, issuer := range splitAnnotation(annotations[OIDCIssuersAnnotation]) {
+		providers = append(providers, trustedOIDCProvider(issuer, audiences))
+	}
+	return providers
+}
+
+func splitAnnotation(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 func (c AWSClusterGetter) getClusterCR(ctx context.Context) (*unstructured.Unstructured, error) {
 	cluster := &unstructured.Unstructured{}
 	cluster.SetGroupVersionKind(schema.GroupVersionKind{
@@ -116,6 +156,10 @@ type AWSCluster struct {
 	Region      string
 	Tags        map[string]string
 	Credentials AWSCredentials
+	// OIDCProviders trusted by the access roles instead of the default IRSA domain of the cluster.
+	OIDCProviders []TrustedOIDCProvider
+	// EKSClusterName is the name of the EKS cluster holding the Pod Identity associations of the access roles.
+	EKSClusterName string
 }
 
 type AWSCredentials struct {
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
+)
+
+// Keys of the connection Secret published for every S3 bucket.
+const (
+	ConnectionBucketNameKey = "bucketName"
+	ConnectionRegionKey     = "region"
+	ConnectionEndpointKey   = "endpoint"
+	ConnectionBucketARNKey  = "bucketArn"
+	ConnectionRoleARNKey    = "roleArn"
+)
+
+// s3Endpoint returns the regional S3 endpoint, which lives under a different domain in the China partition.
+func s3Endpoint(region string) string {
+	if isChinaRegion(region) {
+		return fmt.Sprintf("https://s3.%s.amazonaws.com.cn", region)
+	}
+	return fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+}
+
+// connectionDetails returns what applications need to reach the bucket. The role ARN is only set when the bucket has
+// an access role, which lives in the same account as the bucket. It is the ARN of the existing role, as its path cannot
+// change, or else the ARN the role is created with.
+func (s S3ObjectStorageAdapter) connectionDetails(bucket *v1beta1.Bucket) map[string]string {
+	domain := awsDomain(s.cluster.Region)
+	details := map[string]string{
+		ConnectionBucketNameKey: bucket.Spec.Name,
+		ConnectionRegionKey:     s.cluster.Region,
+		ConnectionEndpointKey:   s3Endpoint(s.cluster.Region),
+		ConnectionBucketARNKey:  fmt.Sprintf("arn:%s:s3:::%s", domain, bucket.Spec.Name),
+	}
+	if bucket.AccessRole() != nil {
+		details[ConnectionRoleARNKey] = fmt.Sprintf("arn:%s:iam::%s:role%s%s", domain, s.accountId, s.accessRoleDefaults.path(bucket), bucket.AccessRole().Name)
+		if bucket.Status.AccessRole != nil && bucket.Status.AccessRole.ARN != "" {
+			details[ConnectionRoleARNKey] = bucket.Status.AccessRole.ARN
+		}
+	}
+	return details
+}
+
+// publishConnectionSecret creates or updates the connection Secret of the bucket. The Secret is owned by the Bucket
+// when it lives in its namespace, so it goes away with it.
+func (s S3ObjectStorageAdapter) publishConnectionSecret(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return objectstorage.PublishConnectionSecret(ctx, s.client, bucket, s.connectionDetails(bucket), "")
+}
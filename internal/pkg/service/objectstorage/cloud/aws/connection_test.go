@@ -0,0 +1,164 @@
+package aws
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_PublishConnectionSecret(t *testing.T) {
+	testCases := []struct {
+		name         string
+		region       string
+		defaults     AccessRoleDefaults
+		access       *v1beta1.BucketAccess
+		status       v1beta1.BucketStatus
+		expectedData map[string]string
+	}{
+		{
+			name:   "case 0: bucket without access role",
+			region: "eu-west-1",
+			expectedData: map[string]string{
+				ConnectionBucketNameKey: "giantswarm-glippy-loki",
+				ConnectionRegionKey:     "eu-west-1",
+				ConnectionEndpointKey:   "https://s3.eu-west-1.amazonaws.com",
+				ConnectionBucketARNKey:  "arn:aws:s3:::giantswarm-glippy-loki",
+			},
+		},
+		{
+			name:   "case 1: bucket with access role",
+			region: "eu-west-1",
+			access: &v1beta1.BucketAccess{
+				Role: &v1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				},
+			},
+			expectedData: map[string]string{
+				ConnectionBucketNameKey: "giantswarm-glippy-loki",
+				ConnectionRegionKey:     "eu-west-1",
+				ConnectionEndpointKey:   "https://s3.eu-west-1.amazonaws.com",
+				ConnectionBucketARNKey:  "arn:aws:s3:::giantswarm-glippy-loki",
+				ConnectionRoleARNKey:    "arn:aws:iam::123456789012:role/giantswarm-glippy-loki",
+			},
+		},
+		{
+			name:   "case 2: bucket in the China partition",
+			region: "cn-north-1",
+			access: &v1beta1.BucketAccess{
+				Role: &v1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				},
+			},
+			expectedData: map[string]string{
+				ConnectionBucketNameKey: "giantswarm-glippy-loki",
+				ConnectionRegionKey:     "cn-north-1",
+				ConnectionEndpointKey:   "https://s3.cn-north-1.amazonaws.com.cn",
+				ConnectionBucketARNKey:  "arn:aws-cn:s3:::giantswarm-glippy-loki",
+				ConnectionRoleARNKey:    "arn:aws-cn:iam::123456789012:role/giantswarm-glippy-loki",
+			},
+		},
+		{
+			name:     "case 3: access role with a path, before it exists",
+			region:   "eu-west-1",
+			defaults: AccessRoleDefaults{Path: "/giantswarm/"},
+			access: &v1beta1.BucketAccess{
+				Role: &v1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					Path:           "/observability/loki/",
+				},
+			},
+			expectedData: map[string]string{
+				ConnectionBucketNameKey: "giantswarm-glippy-loki",
+				ConnectionRegionKey:     "eu-west-1",
+				ConnectionEndpointKey:   "https://s3.eu-west-1.amazonaws.com",
+				ConnectionBucketARNKey:  "arn:aws:s3:::giantswarm-glippy-loki",
+				ConnectionRoleARNKey:    "arn:aws:iam::123456789012:role/observability/loki/giantswarm-glippy-loki",
+			},
+		},
+		{
+			name:     "case 4: existing access role with a path",
+			region:   "eu-west-1",
+			defaults: AccessRoleDefaults{Path: "/giantswarm/"},
+			access: &v1beta1.BucketAccess{
+				Role: &v1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				},
+			},
+			status: v1beta1.BucketStatus{
+				AccessRole: &v1beta1.BucketAccessRoleStatus{ARN: "arn:aws:iam::123456789012:role/legacy/giantswarm-glippy-loki"},
+			},
+			expectedData: map[string]string{
+				ConnectionBucketNameKey: "giantswarm-glippy-loki",
+				ConnectionRegionKey:     "eu-west-1",
+				ConnectionEndpointKey:   "https://s3.eu-west-1.amazonaws.com",
+				ConnectionBucketARNKey:  "arn:aws:s3:::giantswarm-glippy-loki",
+				ConnectionRoleARNKey:    "arn:aws:iam::123456789012:role/legacy/giantswarm-glippy-loki",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := v1beta1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			bucket := &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki", UID: "3f1c2a4e-6a1b-4f5e-9c1d-2b7e8f9a0c1d"},
+				Spec:       v1beta1.BucketSpec{Name: "giantswarm-glippy-loki", Access: tc.access},
+				Status:     tc.status,
+			}
+			adapter := S3ObjectStorageAdapter{
+				accountId: "123456789012",
+				cluster:   AWSCluster{Region: tc.region},
+				client:    fakeClient,
+
+				accessRoleDefaults: tc.defaults,
+			}
+
+			if err := adapter.publishConnectionSecret(ctx, bucket); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bucket.IsConditionTrue(v1beta1.ConditionCredentialsPublished) {
+				t.Fatalf("expected condition %s to be true", v1beta1.ConditionCredentialsPublished)
+			}
+
+			secret := &v1.Secret{}
+			if err := fakeClient.Get(ctx, client.ObjectKey{Name: "giantswarm-glippy-loki", Namespace: "loki"}, secret); err != nil {
+				t.Fatal(err)
+			}
+			data := map[string]string{}
+			for key, value := range secret.Data {
+				data[key] = string(value)
+			}
+			if !cmp.Equal(data, tc.expectedData) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedData, data))
+			}
+			if !metav1.IsControlledBy(secret, bucket) {
+				t.Fatalf("expected secret to be controlled by bucket %s", bucket.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// PodIdentityAssociation is an EKS Pod Identity association of a service account with a role.
+type PodIdentityAssociation struct {
+	AssociationID  string            `json:"associationId,omitempty"`
+	ClusterName    string            `json:"clusterName,omitempty"`
+	Namespace      string            `json:"namespace,omitempty"`
+	ServiceAccount string            `json:"serviceAccount,omitempty"`
+	RoleARN        string            `json:"roleArn,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// PodIdentityAPIError is an error returned by the EKS Pod Identity API.
+type PodIdentityAPIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *PodIdentityAPIError) Error() string {
+	return fmt.Sprintf("EKS API error %s (%d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// isPodIdentityAssociationNotFound returns whether the error is returned for an association that does not exist.
+func isPodIdentityAssociationNotFound(err error) bool {
+	var apiError *PodIdentityAPIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
+
+// PodIdentityClient manages the EKS Pod Identity associations of a cluster through the EKS REST API, signing the
+// requests with the credentials of the AWS config.
+type PodIdentityClient struct {
+	httpClient  *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	endpoint    string
+}
+
+func NewPodIdentityClient(cfg aws.Config) *PodIdentityClient {
+	return &PodIdentityClient{
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		region:      cfg.Region,
+		endpoint:    eksEndpoint(cfg.Region),
+	}
+}
+
+func eksEndpoint(region string) string {
+	if isChinaRegion(region) {
+		return fmt.Sprintf("https://eks.%s.amazonaws.com.cn", region)
+	}
+	return fmt.Sprintf("https://eks.%s.amazonaws.com", region)
+}
+
+// ListAssociations returns the associations of a service account of the cluster.
+func (c *PodIdentityClient) ListAssociations(ctx context.Context, clusterName string, namespace string, serviceAccount string) ([]PodIdentityAssociation, error) {
+	var associations []PodIdentityAssociation
+	query := url.Values{"namespace": {namespace}, "serviceAccount": {serviceAccount}}
+	for {
+		var output struct {
+			Associations []PodIdentityAssociation `json:"associations"`
+			NextToken    string                   `json:"nextToken"`
+		}
+		err := c.do(ctx, http.MethodGet, associationsPath(clusterName)+"?"+query.Encode(), nil, &output)
+		if err != nil {
+			return nil, err
+		}
+		associations = append(associations, output.Associations...)
+		if output.NextToken == "" {
+			return associations, nil
+		}
+		query.Set("nextToken", output.NextToken)
+	}
+}
+
+// DescribeAssociation returns an association of the cluster, including its role.
+func (c *PodIdentityClient) DescribeAssociation(ctx context.Context, clusterName string, associationID string) (PodIdentityAssociation, error) {
+	var output struct {
+		Association PodIdentityAssociation `json:"association"`
+	}
+	err := c.do(ctx, http.MethodGet, associationsPath(clusterName)+"/"+url.PathEscape(associationID), nil, &output)
+	return output.Association, err
+}
+
+// CreateAssociation associates a service account of the cluster with a role.
+func (c *PodIdentityClient) CreateAssociation(ctx context.Context, clusterName string, association PodIdentityAssociation) (PodIdentityAssociation, error) {
+	var output struct {
+		Association PodIdentityAssociation `json:"association"`
+	}
+	err := c.do(ctx, http.MethodPost, associationsPath(clusterName), association, &output)
+	return output.Association, err
+}
+
+// UpdateAssociation changes the role of an association of the cluster.
+func (c *PodIdentityClient) UpdateAssociation(ctx context.Context, clusterName string, associationID string, roleARN string) error {
+	return c.do(ctx, http.MethodPost, associationsPath(clusterName)+"/"+url.PathEscape(associationID), PodIdentityAssociation{RoleARN: roleARN}, nil)
+}
+
+// DeleteAssociation deletes an association of the cluster.
+func (c *PodIdentityClient) DeleteAssociation(ctx context.Context, clusterName string, associationID string) error {
+	return c.do(ctx, http.MethodDelete, associationsPath(clusterName)+"/"+url.PathEscape(associationID), nil, nil)
+}
+
+func associationsPath(clusterName string) string {
+	return "/clusters/" + url.PathEscape(clusterName) + "/pod-identity-associations"
+}
+
+func (c *PodIdentityClient) do(ctx context.Context, method string, path string, input any, output any) error {
+	var body []byte
+	if input != nil {
+		var err error
+		body, err = json.Marshal(input)
+		if err != nil {
+			return fmt.Errorf("failed to marshal EKS request: %w", err)
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build EKS request: %w", err)
+	}
+	if input != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	credentials, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	err = c.signer.SignHTTP(ctx, credentials, request, hex.EncodeToString(payloadHash[:]), "eks", c.region, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to sign EKS request: %w", err)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send EKS request: %w", err)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read EKS response: %w", err)
+	}
+	if response.StatusCode >= http.StatusMultipleChoices {
+		apiError := &PodIdentityAPIError{StatusCode: response.StatusCode}
+		// The error type header looks like ResourceNotFoundException:http://internal.amazon.com/coral/...
+		apiError.Code, _, _ = strings.Cut(response.Header.Get("X-Amzn-Errortype"), ":")
+		var errorBody struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &errorBody) == nil {
+			apiError.Message = errorBody.Message
+		}
+		return apiError
+	}
+
+	if output != nil && len(data) != 0 {
+		if err := json.Unmarshal(data, output); err != nil {
+			return fmt.Errorf("failed to parse EKS response: %w", err)
+		}
+	}
+	return nil
+}
@@ -1,13 +1,12 @@
 package aws
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"reflect"
-	"strings"
-	"text/template"
+	"slices"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
@@ -15,34 +14,120 @@ import (
 	"github.com/aws/smithy-go"
 	"github.com/go-logr/logr"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 )
 
-type IAMAccessRoleServiceAdapter struct {
-	iamClient           *iam.Client
-	logger              logr.Logger
-	accountId           string
-	cluster             AWSCluster
-	trustIdentityPolicy *template.Template
-	rolePolicy          *template.Template
+// AccessRoleDefaults are the settings of the access roles whose Bucket does not set them.
+type AccessRoleDefaults struct {
+	// Description of the roles. Defaults to a description naming the bucket.
+	Description string
+	// Path of the new roles. Defaults to /.
+	Path string
+	// PermissionsBoundary is the ARN of the permissions boundary of the roles. It is required.
+	PermissionsBoundary string
+	// MaxSessionDuration of the roles. Defaults to 1h.
+	MaxSessionDuration time.Duration
+	// ManagedPolicies are the ARNs of the managed policies attached to the roles.
+	ManagedPolicies []string
+	// AllowedPermissionsBoundaries are the ARNs of the permissions boundaries Buckets may set instead of
+	// PermissionsBoundary.
+	AllowedPermissionsBoundaries []string
+	// AllowedManagedPolicies are the ARNs of the managed policies Buckets may attach besides ManagedPolicies.
+	AllowedManagedPolicies []string
 }
 
-func NewIamService(iamClient *iam.Client, logger logr.Logger, accountId string, cluster AWSCluster) IAMAccessRoleServiceAdapter {
-	trustIdentityPolicy, err := template.New("trustIdentityPolicy").Parse(trustIdentityPolicy)
-	if err != nil {
-		panic(err)
+// PermissionsBoundaryAllowed returns true if Buckets may set the permissions boundary on their access role.
+func (d AccessRoleDefaults) PermissionsBoundaryAllowed(policyARN string) bool {
+	return policyARN == d.PermissionsBoundary || slices.Contains(d.AllowedPermissionsBoundaries, policyARN)
+}
+
+// ManagedPolicyAllowed returns true if Buckets may attach the managed policy to their access role.
+func (d AccessRoleDefaults) ManagedPolicyAllowed(policyARN string) bool {
+	return slices.Contains(d.ManagedPolicies, policyARN) || slices.Contains(d.AllowedManagedPolicies, policyARN)
+}
+
+// accessRoleSettings are the settings of an access role, from its Bucket or else from the defaults.
+type accessRoleSettings struct {
+	Description         string
+	Path                string
+	PermissionsBoundary string
+	MaxSessionDuration  int32
+	ManagedPolicies     []string
+}
+
+// settings returns the settings of the access role of the bucket. The permissions boundary and the managed policies of
+// the bucket must be allowed by the defaults, as they would otherwise let the bucket grant any permission to the role.
+func (d AccessRoleDefaults) settings(bucket *v1beta1.Bucket) (accessRoleSettings, error) {
+	settings := accessRoleSettings{
+		Description:         d.Description,
+		Path:                d.path(bucket),
+		PermissionsBoundary: d.PermissionsBoundary,
+		MaxSessionDuration:  int32(d.MaxSessionDuration.Seconds()),
+		ManagedPolicies:     d.ManagedPolicies,
 	}
-	rolePolicy, err := template.New("rolePolicy").Parse(rolePolicy)
-	if err != nil {
-		panic(err)
+
+	accessRole := bucket.AccessRole()
+	if accessRole.Description != "" {
+		settings.Description = accessRole.Description
+	}
+	if accessRole.PermissionsBoundary != "" {
+		if !d.PermissionsBoundaryAllowed(accessRole.PermissionsBoundary) {
+			return accessRoleSettings{}, fmt.Errorf("permissions boundary %s of access role %s is not allowed", accessRole.PermissionsBoundary, accessRole.Name)
+		}
+		settings.PermissionsBoundary = accessRole.PermissionsBoundary
+	}
+	if accessRole.MaxSessionDuration != nil {
+		settings.MaxSessionDuration = int32(accessRole.MaxSessionDuration.Seconds())
+	}
+	if accessRole.ManagedPolicies != nil {
+		for _, policyARN := range accessRole.ManagedPolicies {
+			if !d.ManagedPolicyAllowed(policyARN) {
+				return accessRoleSettings{}, fmt.Errorf("managed policy %s of access role %s is not allowed", policyARN, accessRole.Name)
+			}
+		}
+		settings.ManagedPolicies = accessRole.ManagedPolicies
+	}
+
+	if settings.PermissionsBoundary == "" {
+		return accessRoleSettings{}, fmt.Errorf("access role %s requires a permissions boundary", accessRole.Name)
 	}
+	if settings.Description == "" {
+		settings.Description = fmt.Sprintf("Access role of the bucket %s managed by object-storage-operator", bucket.Spec.Name)
+	}
+	if settings.MaxSessionDuration == 0 {
+		settings.MaxSessionDuration = int32(time.Hour.Seconds())
+	}
+	return settings, nil
+}
+
+// path returns the path of the access role of the bucket.
+func (d AccessRoleDefaults) path(bucket *v1beta1.Bucket) string {
+	if path := bucket.AccessRole().Path; path != "" {
+		return path
+	}
+	if d.Path != "" {
+		return d.Path
+	}
+	return "/"
+}
+
+type IAMAccessRoleServiceAdapter struct {
+	iamClient         *iam.Client
+	podIdentityClient *PodIdentityClient
+	logger            logr.Logger
+	accountId         string
+	cluster           AWSCluster
+	defaults          AccessRoleDefaults
+}
+
+func NewIamService(iamClient *iam.Client, podIdentityClient *PodIdentityClient, logger logr.Logger, accountId string, cluster AWSCluster, defaults AccessRoleDefaults) IAMAccessRoleServiceAdapter {
 	return IAMAccessRoleServiceAdapter{
-		iamClient:           iamClient,
-		logger:              logger,
-		accountId:           accountId,
-		cluster:             cluster,
-		trustIdentityPolicy: trustIdentityPolicy,
-		rolePolicy:          rolePolicy,
+		iamClient:         iamClient,
+		podIdentityClient: podIdentityClient,
+		logger:            logger,
+		accountId:         accountId,
+		cluster:           cluster,
+		defaults:          defaults,
 	}
 }
 
@@ -75,8 +160,24 @@ func (s IAMAccessRoleServiceAdapter) irsaDomain() string {
 	}
 }
 
-func (s IAMAccessRoleServiceAdapter) ConfigureRole(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	roleName := bucket.Spec.AccessRole.RoleName
+// oidcProviders returns the OIDC providers trusted by the access role: its own providers, or else the providers of the
+// management cluster, or else the default IRSA domain of the management cluster.
+func (s IAMAccessRoleServiceAdapter) oidcProviders(accessRole *v1beta1.BucketAccessRole) []TrustedOIDCProvider {
+	if len(accessRole.OIDCProviders) != 0 {
+		providers := make([]TrustedOIDCProvider, 0, len(accessRole.OIDCProviders))
+		for _, provider := range accessRole.OIDCProviders {
+			providers = append(providers, trustedOIDCProvider(provider.Issuer, provider.Audiences))
+		}
+		return providers
+	}
+	if len(s.cluster.OIDCProviders) != 0 {
+		return s.cluster.OIDCProviders
+	}
+	return []TrustedOIDCProvider{{Domain: s.irsaDomain()}}
+}
+
+func (s IAMAccessRoleServiceAdapter) ConfigureRole(ctx context.Context, bucket *v1beta1.Bucket) error {
+	roleName := bucket.AccessRole().Name
 	role, err := s.getRole(ctx, roleName)
 	if err != nil {
 		return err
@@ -99,41 +200,49 @@ func (s IAMAccessRoleServiceAdapter) ConfigureRole(ctx context.Context, bucket *
 		}
 	}
 
-	var trustPolicy bytes.Buffer
-	isGrafanaPostgresql := strings.Contains(bucket.Spec.AccessRole.ServiceAccountName, "grafana-postgresql")
-
-	err = s.trustIdentityPolicy.Execute(&trustPolicy, TrustIdentityPolicyData{
-		AccountId:               s.accountId,
-		AWSDomain:               awsDomain(s.cluster.Region),
-		CloudFrontDomain:        s.irsaDomain(),
-		ServiceAccountName:      bucket.Spec.AccessRole.ServiceAccountName,
-		ServiceAccountNamespace: bucket.Spec.AccessRole.ServiceAccountNamespace,
-		IsGrafanaPostgresql:     isGrafanaPostgresql,
-	})
+	var trustPolicy string
+	if bucket.AccessRoleMode() == v1beta1.AccessRoleModePodIdentity {
+		trustPolicy, err = podIdentityTrustPolicyDocument(s.cluster.EKSClusterName, trustedServiceAccounts(bucket.AccessRole()))
+	} else {
+		trustPolicy, err = trustPolicyDocument(s.accountId, awsDomain(s.cluster.Region), s.oidcProviders(bucket.AccessRole()), trustedServiceAccounts(bucket.AccessRole()))
+	}
 	if err != nil {
-		return fmt.Errorf("failed to execute trust identity policy template for role %s: %w", roleName, err)
+		return fmt.Errorf("failed to build trust policy for role %s: %w", roleName, err)
 	}
 
+	settings, err := s.defaults.settings(bucket)
+	if err != nil {
+		return err
+	}
 	if role == nil {
-		_, err := s.iamClient.CreateRole(ctx, &iam.CreateRoleInput{
+		output, err := s.iamClient.CreateRole(ctx, &iam.CreateRoleInput{
 			RoleName:                 aws.String(roleName),
-			AssumeRolePolicyDocument: aws.String(trustPolicy.String()),
-			Description:              aws.String("Role for Giant Swarm managed Loki"),
+			AssumeRolePolicyDocument: aws.String(trustPolicy),
+			Description:              aws.String(settings.Description),
+			Path:                     aws.String(settings.Path),
+			PermissionsBoundary:      aws.String(settings.PermissionsBoundary),
+			MaxSessionDuration:       aws.Int32(settings.MaxSessionDuration),
 			Tags:                     tags,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create IAM role %s: %w", roleName, err)
 		}
+		role = output.Role
 		s.logger.Info("IAM Role created")
 	} else {
 		_, err = s.iamClient.UpdateAssumeRolePolicy(ctx, &iam.UpdateAssumeRolePolicyInput{
 			RoleName:       aws.String(roleName),
-			PolicyDocument: aws.String(trustPolicy.String()),
+			PolicyDocument: aws.String(trustPolicy),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to update assume role policy for IAM role %s: %w", roleName, err)
 		}
 
+		err = s.correctRoleDrift(ctx, role, settings)
+		if err != nil {
+			return err
+		}
+
 		// Update tags (need to untag with existing keys then retag)
 		if !reflect.DeepEqual(role.Tags, tags) {
 			tagKeys := []string{}
@@ -157,36 +266,282 @@ func (s IAMAccessRoleServiceAdapter) ConfigureRole(ctx context.Context, bucket *
 		}
 	}
 
-	var rolePolicy bytes.Buffer
-	var data = RolePolicyData{
-		AWSDomain:        awsDomain(s.cluster.Region),
-		BucketName:       bucket.Spec.Name,
-		ExtraBucketNames: bucket.Spec.AccessRole.ExtraBucketNames,
-	}
-
-	err = s.rolePolicy.Execute(&rolePolicy, data)
+	rolePolicy, err := rolePolicyDocument(awsDomain(s.cluster.Region), bucket)
 	if err != nil {
-		return fmt.Errorf("failed to execute role policy template for role %s: %w", roleName, err)
+		return fmt.Errorf("failed to build role policy for role %s: %w", roleName, err)
 	}
 
 	_, err = s.iamClient.PutRolePolicy(ctx, &iam.PutRolePolicyInput{
 		RoleName:       aws.String(roleName),
 		PolicyName:     aws.String(roleName),
-		PolicyDocument: aws.String(rolePolicy.String()),
+		PolicyDocument: aws.String(rolePolicy),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to put IAM role policy for role %s: %w", roleName, err)
 	}
+
+	var previousManagedPolicies []string
+	if bucket.Status.AccessRole != nil {
+		previousManagedPolicies = bucket.Status.AccessRole.ManagedPolicies
+	}
+	managedPolicies, err := s.configureManagedPolicies(ctx, roleName, settings.ManagedPolicies, previousManagedPolicies)
+	// The managed policies attached so far must be recorded so that they get detached if they are removed meanwhile,
+	// whatever fails next.
+	if bucket.Status.AccessRole == nil {
+		bucket.Status.AccessRole = &v1beta1.BucketAccessRoleStatus{}
+	}
+	bucket.Status.AccessRole.ARN = aws.ToString(role.Arn)
+	bucket.Status.AccessRole.ManagedPolicies = managedPolicies
+	if err != nil {
+		return err
+	}
+
+	associations, err := s.configurePodIdentityAssociations(ctx, bucket, aws.ToString(role.Arn))
+	if err != nil {
+		return err
+	}
+	bucket.Status.AccessRole = &v1beta1.BucketAccessRoleStatus{
+		ARN:                     aws.ToString(role.Arn),
+		Mode:                    bucket.AccessRoleMode(),
+		PodIdentityAssociations: associations,
+		ManagedPolicies:         managedPolicies,
+	}
+	return nil
+}
+
+// correctRoleDrift reverts the changes made to the settings of an existing role outside of its Bucket, and applies the
+// changes of the settings. The path of a role cannot change, so a different path is only logged.
+func (s IAMAccessRoleServiceAdapter) correctRoleDrift(ctx context.Context, role *types.Role, settings accessRoleSettings) error {
+	roleName := aws.ToString(role.RoleName)
+	if aws.ToString(role.Path) != settings.Path {
+		s.logger.Info("IAM role path differs and cannot be changed, recreate the role to move it", "path", aws.ToString(role.Path), "expectedPath", settings.Path)
+	}
+
+	if aws.ToString(role.Description) != settings.Description || aws.ToInt32(role.MaxSessionDuration) != settings.MaxSessionDuration {
+		s.logger.Info("Correcting IAM role description and maximum session duration")
+		_, err := s.iamClient.UpdateRole(ctx, &iam.UpdateRoleInput{
+			RoleName:           aws.String(roleName),
+			Description:        aws.String(settings.Description),
+			MaxSessionDuration: aws.Int32(settings.MaxSessionDuration),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update IAM role %s: %w", roleName, err)
+		}
+	}
+
+	var permissionsBoundary string
+	if role.PermissionsBoundary != nil {
+		permissionsBoundary = aws.ToString(role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+	if permissionsBoundary != settings.PermissionsBoundary {
+		s.logger.Info("Setting IAM role permissions boundary", "permissionsBoundary", settings.PermissionsBoundary)
+		_, err := s.iamClient.PutRolePermissionsBoundary(ctx, &iam.PutRolePermissionsBoundaryInput{
+			RoleName:            aws.String(roleName),
+			PermissionsBoundary: aws.String(settings.PermissionsBoundary),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put permissions boundary of IAM role %s: %w", roleName, err)
+		}
+	}
 	return nil
 }
 
-func (s IAMAccessRoleServiceAdapter) DeleteRole(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	roleName := bucket.Spec.AccessRole.RoleName
+// configureManagedPolicies attaches the managed policies to the role and detaches the ones the operator attached before
+// that are not listed anymore. Policies attached outside of the Bucket are left untouched. It returns the managed
+// policies attached by the operator, including the ones that may still be attached when it fails.
+func (s IAMAccessRoleServiceAdapter) configureManagedPolicies(ctx context.Context, roleName string, managedPolicies []string, previous []string) ([]string, error) {
+	var attached []string
+	paginator := iam.NewListAttachedRolePoliciesPaginator(s.iamClient, &iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return previous, fmt.Errorf("failed to list attached policies for IAM role %s: %w", roleName, err)
+		}
+		for _, policy := range page.AttachedPolicies {
+			attached = append(attached, aws.ToString(policy.PolicyArn))
+		}
+	}
+
+	toAttach, toDetach, owned := managedPolicyChanges(attached, managedPolicies, previous)
+	for i, policyARN := range toAttach {
+		_, err := s.iamClient.AttachRolePolicy(ctx, &iam.AttachRolePolicyInput{
+			RoleName:  aws.String(roleName),
+			PolicyArn: aws.String(policyARN),
+		})
+		if err != nil {
+			return mergeManagedPolicies(toAttach[:i], previous), fmt.Errorf("failed to attach policy %s to IAM role %s: %w", policyARN, roleName, err)
+		}
+		s.logger.Info("attached managed policy", "policy", policyARN)
+	}
+	for i, policyARN := range toDetach {
+		_, err := s.iamClient.DetachRolePolicy(ctx, &iam.DetachRolePolicyInput{
+			RoleName:  aws.String(roleName),
+			PolicyArn: aws.String(policyARN),
+		})
+		if err != nil {
+			return mergeManagedPolicies(owned, toDetach[i:]), fmt.Errorf("failed to detach policy %s from IAM role %s: %w", policyARN, roleName, err)
+		}
+		s.logger.Info("detached managed policy", "policy", policyARN)
+	}
+	return owned, nil
+}
+
+// managedPolicyChanges returns the managed policies to attach to a role, the ones to detach from it and the ones
+// attached by the operator once applied. Only the policies the operator attached before are detached, and a listed
+// policy that was already attached outside of the Bucket is not considered attached by the operator.
+func managedPolicyChanges(attached []string, managedPolicies []string, previous []string) (toAttach []string, toDetach []string, owned []string) {
+	for _, policyARN := range managedPolicies {
+		switch {
+		case !slices.Contains(attached, policyARN):
+			toAttach = append(toAttach, policyARN)
+			owned = append(owned, policyARN)
+		case slices.Contains(previous, policyARN):
+			owned = append(owned, policyARN)
+		}
+	}
+	for _, policyARN := range previous {
+		if slices.Contains(attached, policyARN) && !slices.Contains(managedPolicies, policyARN) {
+			toDetach = append(toDetach, policyARN)
+		}
+	}
+	return toAttach, toDetach, owned
+}
+
+// mergeManagedPolicies returns the managed policies attached by the operator, followed by the other ones that may
+// still be attached.
+func mergeManagedPolicies(owned []string, others []string) []string {
+	merged := append([]string(nil), owned...)
+	for _, policyARN := range others {
+		if !slices.Contains(merged, policyARN) {
+			merged = append(merged, policyARN)
+		}
+	}
+	return merged
+}
+
+// configurePodIdentityAssociations associates the service accounts of an access role in the PodIdentity mode with the
+// role, and deletes the associations of the service accounts that are not trusted by the role anymore, e.g. once the
+// role is back to the IRSA mode. It returns the associations of the role.
+func (s IAMAccessRoleServiceAdapter) configurePodIdentityAssociations(ctx context.Context, bucket *v1beta1.Bucket, roleARN string) ([]v1beta1.PodIdentityAssociation, error) {
+	var previous []v1beta1.PodIdentityAssociation
+	if bucket.Status.AccessRole != nil {
+		previous = bucket.Status.AccessRole.PodIdentityAssociations
+	}
+
+	var serviceAccounts []v1beta1.ServiceAccountReference
+	if bucket.AccessRoleMode() == v1beta1.AccessRoleModePodIdentity {
+		accessRole := bucket.AccessRole()
+		serviceAccounts = append([]v1beta1.ServiceAccountReference{accessRole.ServiceAccount}, accessRole.ServiceAccounts...)
+	}
+
+	var associations []v1beta1.PodIdentityAssociation
+	for _, serviceAccount := range serviceAccounts {
+		associationID, err := s.podIdentityAssociation(ctx, bucket, serviceAccount, roleARN, previous)
+		if err != nil {
+			return nil, err
+		}
+		associations = append(associations, v1beta1.PodIdentityAssociation{ID: associationID, ServiceAccount: serviceAccount})
+	}
+
+	for _, association := range previous {
+		if slices.Contains(serviceAccounts, association.ServiceAccount) {
+			continue
+		}
+		err := s.deletePodIdentityAssociation(ctx, association)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return associations, nil
+}
+
+// podIdentityAssociation returns the ID of the association of a service account with the role, creating it when the
+// service account has none. Associations with another role are only moved to the role when the bucket created them.
+func (s IAMAccessRoleServiceAdapter) podIdentityAssociation(ctx context.Context, bucket *v1beta1.Bucket, serviceAccount v1beta1.ServiceAccountReference, roleARN string, previous []v1beta1.PodIdentityAssociation) (string, error) {
+	clusterName := s.cluster.EKSClusterName
+	existing, err := s.podIdentityClient.ListAssociations(ctx, clusterName, serviceAccount.Namespace, serviceAccount.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to list Pod Identity associations of service account %s/%s: %w", serviceAccount.Namespace, serviceAccount.Name, err)
+	}
+
+	if len(existing) == 0 {
+		association, err := s.podIdentityClient.CreateAssociation(ctx, clusterName, PodIdentityAssociation{
+			Namespace:      serviceAccount.Namespace,
+			ServiceAccount: serviceAccount.Name,
+			RoleARN:        roleARN,
+			Tags:           map[string]string{OwnerTagKey: getBucketOwner(bucket)},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create Pod Identity association of service account %s/%s: %w", serviceAccount.Namespace, serviceAccount.Name, err)
+		}
+		s.logger.Info("Pod Identity association created", "namespace", serviceAccount.Namespace, "serviceAccount", serviceAccount.Name)
+		return association.AssociationID, nil
+	}
+
+	associationID := existing[0].AssociationID
+	association, err := s.podIdentityClient.DescribeAssociation(ctx, clusterName, associationID)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe Pod Identity association %s: %w", associationID, err)
+	}
+	if association.RoleARN == roleARN {
+		return associationID, nil
+	}
+
+	owned := slices.ContainsFunc(previous, func(a v1beta1.PodIdentityAssociation) bool { return a.ID == associationID })
+	if !owned {
+		return "", fmt.Errorf("service account %s/%s is already associated with IAM role %s", serviceAccount.Namespace, serviceAccount.Name, association.RoleARN)
+	}
+	err = s.podIdentityClient.UpdateAssociation(ctx, clusterName, associationID, roleARN)
+	if err != nil {
+		return "", fmt.Errorf("failed to update Pod Identity association %s: %w", associationID, err)
+	}
+	s.logger.Info("Pod Identity association updated", "namespace", serviceAccount.Namespace, "serviceAccount", serviceAccount.Name)
+	return associationID, nil
+}
+
+func (s IAMAccessRoleServiceAdapter) deletePodIdentityAssociation(ctx context.Context, association v1beta1.PodIdentityAssociation) error {
+	err := s.podIdentityClient.DeleteAssociation(ctx, s.cluster.EKSClusterName, association.ID)
+	if err != nil && !isPodIdentityAssociationNotFound(err) {
+		return fmt.Errorf("failed to delete Pod Identity association %s: %w", association.ID, err)
+	}
+	s.logger.Info("Pod Identity association deleted", "namespace", association.ServiceAccount.Namespace, "serviceAccount", association.ServiceAccount.Name)
+	return nil
+}
+
+// trustedServiceAccounts returns the service accounts allowed to assume the access role: its service account, then the
+// additional ones.
+func trustedServiceAccounts(accessRole *v1beta1.BucketAccessRole) []TrustedServiceAccount {
+	serviceAccounts := []TrustedServiceAccount{{
+		Namespace: accessRole.ServiceAccount.Namespace,
+		Name:      accessRole.ServiceAccount.Name,
+	}}
+	for _, serviceAccount := range accessRole.ServiceAccounts {
+		serviceAccounts = append(serviceAccounts, TrustedServiceAccount{
+			Namespace: serviceAccount.Namespace,
+			Name:      serviceAccount.Name,
+		})
+	}
+	return serviceAccounts
+}
+
+func (s IAMAccessRoleServiceAdapter) DeleteRole(ctx context.Context, bucket *v1beta1.Bucket) error {
+	roleName := bucket.AccessRole().Name
 	role, err := s.getRole(ctx, roleName)
 	if err != nil {
 		return fmt.Errorf("failed to get IAM role %s for deletion: %w", roleName, err)
 	}
 
+	if bucket.Status.AccessRole != nil {
+		for _, association := range bucket.Status.AccessRole.PodIdentityAssociations {
+			err = s.deletePodIdentityAssociation(ctx, association)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	if role == nil {
 		s.logger.Info("IAM role does not exist, skipping deletion")
 		return nil
@@ -0,0 +1,449 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// fakeEKS serves the Pod Identity associations of the glippy cluster.
+type fakeEKS struct {
+	associations map[string]PodIdentityAssociation
+}
+
+func (f *fakeEKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	id, found := strings.CutPrefix(r.URL.Path, "/clusters/glippy/pod-identity-associations/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/clusters/glippy/pod-identity-associations":
+		var associations []PodIdentityAssociation
+		for _, association := range f.associations {
+			if association.Namespace == r.URL.Query().Get("namespace") && association.ServiceAccount == r.URL.Query().Get("serviceAccount") {
+				associations = append(associations, PodIdentityAssociation{AssociationID: association.AssociationID})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"associations": associations})
+	case r.Method == http.MethodPost && r.URL.Path == "/clusters/glippy/pod-identity-associations":
+		var association PodIdentityAssociation
+		_ = json.NewDecoder(r.Body).Decode(&association)
+		association.AssociationID = fmt.Sprintf("a-%d", len(f.associations))
+		f.associations[association.AssociationID] = association
+		_ = json.NewEncoder(w).Encode(map[string]any{"association": association})
+	case found && f.associations[id].AssociationID == "":
+		w.Header().Set("X-Amzn-Errortype", "ResourceNotFoundException:http://internal.amazon.com/coral/com.amazonaws.eks/")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "association not found"}`))
+	case found && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]any{"association": f.associations[id]})
+	case found && r.Method == http.MethodPost:
+		var update PodIdentityAssociation
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		association := f.associations[id]
+		association.RoleARN = update.RoleARN
+		f.associations[id] = association
+		_ = json.NewEncoder(w).Encode(map[string]any{"association": association})
+	case found && r.Method == http.MethodDelete:
+		delete(f.associations, id)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func Test_ConfigurePodIdentityAssociations(t *testing.T) {
+	const roleARN = "arn:aws:iam::123456789012:role/giantswarm-glippy-loki"
+	loki := v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"}
+	canary := v1beta1.ServiceAccountReference{Name: "loki-canary", Namespace: "loki"}
+
+	testCases := []struct {
+		name                 string
+		mode                 v1beta1.AccessRoleMode
+		existing             []PodIdentityAssociation
+		previous             []v1beta1.PodIdentityAssociation
+		expectedAssociations []v1beta1.PodIdentityAssociation
+		expectedRoles        map[string]string
+		expectError          bool
+	}{
+		{
+			name: "case 0: create the associations of the service accounts",
+			mode: v1beta1.AccessRoleModePodIdentity,
+			expectedAssociations: []v1beta1.PodIdentityAssociation{
+				{ID: "a-0", ServiceAccount: loki},
+				{ID: "a-1", ServiceAccount: canary},
+			},
+			expectedRoles: map[string]string{"a-0": roleARN, "a-1": roleARN},
+		},
+		{
+			name: "case 1: keep an association with the role and update an association created by the bucket",
+			mode: v1beta1.AccessRoleModePodIdentity,
+			existing: []PodIdentityAssociation{
+				{AssociationID: "a-loki", Namespace: "loki", ServiceAccount: "loki", RoleARN: roleARN},
+				{AssociationID: "a-canary", Namespace: "loki", ServiceAccount: "loki-canary", RoleARN: "arn:aws:iam::123456789012:role/previous"},
+			},
+			previous: []v1beta1.PodIdentityAssociation{{ID: "a-canary", ServiceAccount: canary}},
+			expectedAssociations: []v1beta1.PodIdentityAssociation{
+				{ID: "a-loki", ServiceAccount: loki},
+				{ID: "a-canary", ServiceAccount: canary},
+			},
+			expectedRoles: map[string]string{"a-loki": roleARN, "a-canary": roleARN},
+		},
+		{
+			name: "case 2: service account associated with another role",
+			mode: v1beta1.AccessRoleModePodIdentity,
+			existing: []PodIdentityAssociation{
+				{AssociationID: "a-loki", Namespace: "loki", ServiceAccount: "loki", RoleARN: "arn:aws:iam::123456789012:role/mimir"},
+			},
+			expectError: true,
+		},
+		{
+			name: "case 3: delete the associations once back to IRSA",
+			mode: v1beta1.AccessRoleModeIRSA,
+			existing: []PodIdentityAssociation{
+				{AssociationID: "a-loki", Namespace: "loki", ServiceAccount: "loki", RoleARN: roleARN},
+			},
+			previous: []v1beta1.PodIdentityAssociation{
+				{ID: "a-loki", ServiceAccount: loki},
+				{ID: "a-deleted", ServiceAccount: canary},
+			},
+			expectedRoles: map[string]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			eks := &fakeEKS{associations: map[string]PodIdentityAssociation{}}
+			for _, association := range tc.existing {
+				eks.associations[association.AssociationID] = association
+			}
+			server := httptest.NewServer(eks)
+			defer server.Close()
+
+			s := IAMAccessRoleServiceAdapter{
+				podIdentityClient: &PodIdentityClient{
+					httpClient:  server.Client(),
+					credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+					signer:      v4.NewSigner(),
+					region:      "eu-west-1",
+					endpoint:    server.URL,
+				},
+				logger:  logr.Discard(),
+				cluster: AWSCluster{EKSClusterName: "glippy"},
+			}
+			bucket := &v1beta1.Bucket{
+				Spec: v1beta1.BucketSpec{
+					Name: "giantswarm-glippy-loki",
+					Access: &v1beta1.BucketAccess{Role: &v1beta1.BucketAccessRole{
+						Name:            "giantswarm-glippy-loki",
+						Mode:            tc.mode,
+						ServiceAccount:  loki,
+						ServiceAccounts: []v1beta1.ServiceAccountReference{canary},
+					}},
+				},
+				Status: v1beta1.BucketStatus{
+					AccessRole: &v1beta1.BucketAccessRoleStatus{ARN: roleARN, PodIdentityAssociations: tc.previous},
+				},
+			}
+
+			associations, err := s.configurePodIdentityAssociations(context.Background(), bucket, roleARN)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(associations, tc.expectedAssociations) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedAssociations, associations))
+			}
+
+			roles := map[string]string{}
+			for id, association := range eks.associations {
+				roles[id] = association.RoleARN
+			}
+			if !cmp.Equal(roles, tc.expectedRoles) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedRoles, roles))
+			}
+		})
+	}
+}
+
+func Test_PodIdentityAPIError(t *testing.T) {
+	server := httptest.NewServer(&fakeEKS{associations: map[string]PodIdentityAssociation{}})
+	defer server.Close()
+
+	client := &PodIdentityClient{
+		httpClient:  server.Client(),
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		signer:      v4.NewSigner(),
+		region:      "eu-west-1",
+		endpoint:    server.URL,
+	}
+	_, err := client.DescribeAssociation(context.Background(), "glippy", "a-missing")
+	if !isPodIdentityAssociationNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	expected := &PodIdentityAPIError{StatusCode: http.StatusNotFound, Code: "ResourceNotFoundException", Message: "association not found"}
+	if !cmp.Equal(err, error(expected)) {
+		t.Fatalf("\n\n%s\n", cmp.Diff(expected, err))
+	}
+}
+
+func Test_AccessRoleSettings(t *testing.T) {
+	testCases := []struct {
+		name             string
+		defaults         AccessRoleDefaults
+		accessRole       v1beta1.BucketAccessRole
+		expectedSettings accessRoleSettings
+		expectedError    string
+	}{
+		{
+			name:          "case 0: no permissions boundary",
+			accessRole:    v1beta1.BucketAccessRole{Name: "loki"},
+			expectedError: "access role loki requires a permissions boundary",
+		},
+		{
+			name: "case 1: defaults of the operator",
+			defaults: AccessRoleDefaults{
+				Description:         "Giant Swarm managed bucket access",
+				Path:                "/giantswarm/",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				MaxSessionDuration:  2 * time.Hour,
+				ManagedPolicies:     []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+			},
+			accessRole: v1beta1.BucketAccessRole{Name: "loki"},
+			expectedSettings: accessRoleSettings{
+				Description:         "Giant Swarm managed bucket access",
+				Path:                "/giantswarm/",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				MaxSessionDuration:  7200,
+				ManagedPolicies:     []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+			},
+		},
+		{
+			name: "case 2: overrides of the bucket",
+			defaults: AccessRoleDefaults{
+				Path:                         "/giantswarm/",
+				PermissionsBoundary:          "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				MaxSessionDuration:           2 * time.Hour,
+				ManagedPolicies:              []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+				AllowedPermissionsBoundaries: []string{"arn:aws:iam::123456789012:policy/observability-boundary"},
+				AllowedManagedPolicies:       []string{"arn:aws:iam::123456789012:policy/loki"},
+			},
+			accessRole: v1beta1.BucketAccessRole{
+				Name:                "loki",
+				Description:         "Loki chunks and index",
+				Path:                "/giantswarm/observability/",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/observability-boundary",
+				MaxSessionDuration:  &metav1.Duration{Duration: 12 * time.Hour},
+				ManagedPolicies:     []string{"arn:aws:iam::123456789012:policy/loki"},
+			},
+			expectedSettings: accessRoleSettings{
+				Description:         "Loki chunks and index",
+				Path:                "/giantswarm/observability/",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/observability-boundary",
+				MaxSessionDuration:  43200,
+				ManagedPolicies:     []string{"arn:aws:iam::123456789012:policy/loki"},
+			},
+		},
+		{
+			name: "case 3: default managed policies removed by the bucket",
+			defaults: AccessRoleDefaults{
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				ManagedPolicies:     []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+			},
+			accessRole: v1beta1.BucketAccessRole{
+				Name:                "loki",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				ManagedPolicies:     []string{},
+			},
+			expectedSettings: accessRoleSettings{
+				Description:         "Access role of the bucket giantswarm-glippy-loki managed by object-storage-operator",
+				Path:                "/",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				MaxSessionDuration:  3600,
+				ManagedPolicies:     []string{},
+			},
+		},
+		{
+			name: "case 4: permissions boundary of the bucket that is not allowed",
+			defaults: AccessRoleDefaults{
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+			},
+			accessRole: v1beta1.BucketAccessRole{
+				Name:                "loki",
+				PermissionsBoundary: "arn:aws:iam::aws:policy/AdministratorAccess",
+			},
+			expectedError: "permissions boundary arn:aws:iam::aws:policy/AdministratorAccess of access role loki is not allowed",
+		},
+		{
+			name: "case 5: managed policy of the bucket that is not allowed",
+			defaults: AccessRoleDefaults{
+				PermissionsBoundary:    "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+				AllowedManagedPolicies: []string{"arn:aws:iam::123456789012:policy/loki"},
+			},
+			accessRole: v1beta1.BucketAccessRole{
+				Name:            "loki",
+				ManagedPolicies: []string{"arn:aws:iam::123456789012:policy/loki", "arn:aws:iam::aws:policy/AdministratorAccess"},
+			},
+			expectedError: "managed policy arn:aws:iam::aws:policy/AdministratorAccess of access role loki is not allowed",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{
+				Name:   "giantswarm-glippy-loki",
+				Access: &v1beta1.BucketAccess{Role: &tc.accessRole},
+			}}
+			settings, err := tc.defaults.settings(bucket)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(settings, tc.expectedSettings) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedSettings, settings))
+			}
+		})
+	}
+}
+
+func Test_ManagedPolicyChanges(t *testing.T) {
+	const (
+		readOnly = "arn:aws:iam::aws:policy/ReadOnlyAccess"
+		ssm      = "arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"
+		loki     = "arn:aws:iam::123456789012:policy/loki"
+	)
+
+	testCases := []struct {
+		name             string
+		attached         []string
+		managedPolicies  []string
+		previous         []string
+		expectedToAttach []string
+		expectedToDetach []string
+		expectedOwned    []string
+	}{
+		{
+			name:             "case 0: new policies are attached and policies attached outside of the bucket are kept",
+			attached:         []string{readOnly},
+			managedPolicies:  []string{ssm, loki},
+			expectedToAttach: []string{ssm, loki},
+			expectedOwned:    []string{ssm, loki},
+		},
+		{
+			name:             "case 1: policies attached by the operator that are not listed anymore are detached",
+			attached:         []string{readOnly, ssm, loki},
+			managedPolicies:  []string{loki},
+			previous:         []string{ssm, loki},
+			expectedToDetach: []string{ssm},
+			expectedOwned:    []string{loki},
+		},
+		{
+			name:            "case 2: listed policy attached outside of the bucket is not owned",
+			attached:        []string{readOnly},
+			managedPolicies: []string{readOnly},
+			expectedOwned:   nil,
+		},
+		{
+			name:             "case 3: policy detached outside of the bucket is attached again",
+			attached:         nil,
+			managedPolicies:  []string{loki},
+			previous:         []string{loki},
+			expectedToAttach: []string{loki},
+			expectedOwned:    []string{loki},
+		},
+		{
+			name:            "case 4: policy detached outside of the bucket and not listed anymore",
+			attached:        []string{readOnly},
+			managedPolicies: nil,
+			previous:        []string{loki},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			toAttach, toDetach, owned := managedPolicyChanges(tc.attached, tc.managedPolicies, tc.previous)
+			if !cmp.Equal(toAttach, tc.expectedToAttach) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedToAttach, toAttach))
+			}
+			if !cmp.Equal(toDetach, tc.expectedToDetach) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedToDetach, toDetach))
+			}
+			if !cmp.Equal(owned, tc.expectedOwned) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedOwned, owned))
+			}
+		})
+	}
+}
+
+func Test_MergeManagedPolicies(t *testing.T) {
+	const (
+		readOnly = "arn:aws:iam::aws:policy/ReadOnlyAccess"
+		loki     = "arn:aws:iam::123456789012:policy/loki"
+	)
+
+	testCases := []struct {
+		name                    string
+		owned                   []string
+		others                  []string
+		expectedManagedPolicies []string
+	}{
+		{
+			name:                    "case 0: nothing attached yet",
+			others:                  []string{loki},
+			expectedManagedPolicies: []string{loki},
+		},
+		{
+			name:                    "case 1: policies attached again are recorded once",
+			owned:                   []string{readOnly, loki},
+			others:                  []string{loki},
+			expectedManagedPolicies: []string{readOnly, loki},
+		},
+		{
+			name:                    "case 2: nothing left to detach",
+			owned:                   []string{readOnly},
+			expectedManagedPolicies: []string{readOnly},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			managedPolicies := mergeManagedPolicies(tc.owned, tc.others)
+
+			if !cmp.Equal(managedPolicies, tc.expectedManagedPolicies) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedManagedPolicies, managedPolicies))
+			}
+		})
+	}
+}
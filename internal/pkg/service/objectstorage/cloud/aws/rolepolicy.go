@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// Actions the access levels allow on buckets, restricted to the prefixes of the role through the s3:prefix condition
+// key, and on objects.
+var (
+	accessLevelBucketActions = map[v1beta1.AccessLevel][]string{
+		v1beta1.AccessLevelReadOnly:          {"s3:ListBucket"},
+		v1beta1.AccessLevelReadWriteNoDelete: {"s3:ListBucket"},
+		v1beta1.AccessLevelReadWrite:         {"s3:ListBucket"},
+		v1beta1.AccessLevelAdmin:             {"s3:ListBucket", "s3:ListBucketVersions", "s3:ListBucketMultipartUploads"},
+	}
+	accessLevelObjectActions = map[v1beta1.AccessLevel][]string{
+		v1beta1.AccessLevelReadOnly:          {"s3:GetObject"},
+		v1beta1.AccessLevelReadWriteNoDelete: {"s3:GetObject", "s3:PutObject"},
+		v1beta1.AccessLevelReadWrite:         {"s3:GetObject", "s3:PutObject", "s3:DeleteObject"},
+		v1beta1.AccessLevelAdmin: {
+			"s3:*Object",
+			"s3:*ObjectVersion",
+			"s3:*ObjectTagging",
+			"s3:*ObjectVersionTagging",
+			"s3:*ObjectRetention",
+			"s3:*ObjectLegalHold",
+			"s3:AbortMultipartUpload",
+			"s3:ListMultipartUploadParts",
+		},
+	}
+)
+
+// rolePolicyDocument returns the policy of the access role of the bucket: the actions of its access level on the
+// bucket and its extra buckets, and the use of the KMS key of the bucket.
+func rolePolicyDocument(awsDomain string, bucket *v1beta1.Bucket) (string, error) {
+	accessRole := bucket.AccessRole()
+	policy := policyDocument{Version: "2012-10-17"}
+
+	policy.Statement = append(policy.Statement, bucketPermissionsStatements(awsDomain, bucket.Spec.Name, accessRole.BucketPermissions)...)
+	for _, name := range accessRole.ExtraBucketNames {
+		policy.Statement = append(policy.Statement, bucketPermissionsStatements(awsDomain, name, v1beta1.BucketPermissions{})...)
+	}
+	for _, extraBucket := range accessRole.ExtraBuckets {
+		policy.Statement = append(policy.Statement, bucketPermissionsStatements(awsDomain, extraBucket.Name, extraBucket.BucketPermissions)...)
+	}
+
+	policy.Statement = append(policy.Statement, PolicyStatement{
+		Effect:   string(v1beta1.PolicyEffectAllow),
+		Action:   PolicyStrings{"s3:GetAccessPoint", "s3:GetAccountPublicAccessBlock", "s3:ListAccessPoints"},
+		Resource: PolicyStrings{"*"},
+	})
+	if keyARN := bucket.KMSKeyARN(); keyARN != "" {
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Effect:   string(v1beta1.PolicyEffectAllow),
+			Action:   PolicyStrings{"kms:Decrypt", "kms:GenerateDataKey"},
+			Resource: PolicyStrings{keyARN},
+		})
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal role policy: %w", err)
+	}
+	return string(data), nil
+}
+
+// bucketPermissionsStatements returns the statements allowing the permissions on a bucket. Permissions restricted to
+// prefixes only allow listing these prefixes and accessing the objects within them.
+func bucketPermissionsStatements(awsDomain string, bucketName string, permissions v1beta1.BucketPermissions) []PolicyStatement {
+	accessLevel := permissions.AccessLevel
+	if accessLevel == "" {
+		accessLevel = v1beta1.AccessLevelReadWrite
+	}
+	bucketARN := fmt.Sprintf("arn:%s:s3:::%s", awsDomain, bucketName)
+
+	bucketStatement := PolicyStatement{
+		Effect:   string(v1beta1.PolicyEffectAllow),
+		Action:   accessLevelBucketActions[accessLevel],
+		Resource: PolicyStrings{bucketARN},
+	}
+	objectResources := PolicyStrings{bucketARN + "/*"}
+	if len(permissions.Prefixes) != 0 {
+		var prefixes []string
+		objectResources = nil
+		for _, prefix := range permissions.Prefixes {
+			prefixes = append(prefixes, prefix+"*")
+			objectResources = append(objectResources, fmt.Sprintf("%s/%s*", bucketARN, prefix))
+		}
+		bucketStatement.Condition = map[string]map[string]any{
+			"StringLike": {"s3:prefix": prefixes},
+		}
+	}
+
+	statements := []PolicyStatement{
+		bucketStatement,
+		{
+			Effect:   string(v1beta1.PolicyEffectAllow),
+			Action:   accessLevelObjectActions[accessLevel],
+			Resource: objectResources,
+		},
+	}
+	if len(permissions.ExtraActions) != 0 {
+		statements = append(statements, PolicyStatement{
+			Effect:   string(v1beta1.PolicyEffectAllow),
+			Action:   permissions.ExtraActions,
+			Resource: append(PolicyStrings{bucketARN}, objectResources...),
+		})
+	}
+	return statements
+}
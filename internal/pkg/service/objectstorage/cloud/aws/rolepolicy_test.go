@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_RolePolicyDocument(t *testing.T) {
+	accessPointsStatement := map[string]any{
+		"Effect":   "Allow",
+		"Action":   []any{"s3:GetAccessPoint", "s3:GetAccountPublicAccessBlock", "s3:ListAccessPoints"},
+		"Resource": []any{"*"},
+	}
+
+	testCases := []struct {
+		name               string
+		accessRole         v1beta1.BucketAccessRole
+		encryption         *v1beta1.BucketEncryption
+		expectedStatements []map[string]any
+	}{
+		{
+			name:       "case 0: default access level on the bucket and extra bucket names",
+			accessRole: v1beta1.BucketAccessRole{Name: "loki", ExtraBucketNames: []string{"giantswarm-glippy-mimir"}},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:ListBucket"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-loki"},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:GetObject", "s3:PutObject", "s3:DeleteObject"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-loki/*"},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:ListBucket"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-mimir"},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:GetObject", "s3:PutObject", "s3:DeleteObject"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-mimir/*"},
+				},
+				accessPointsStatement,
+			},
+		},
+		{
+			name: "case 1: read-only access to prefixes with extra actions",
+			accessRole: v1beta1.BucketAccessRole{
+				Name: "loki",
+				BucketPermissions: v1beta1.BucketPermissions{
+					AccessLevel:  v1beta1.AccessLevelReadOnly,
+					Prefixes:     []string{"logs/", "traces/"},
+					ExtraActions: []string{"s3:ListBucketMultipartUploads"},
+				},
+			},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":    "Allow",
+					"Action":    []any{"s3:ListBucket"},
+					"Resource":  []any{"arn:aws:s3:::giantswarm-glippy-loki"},
+					"Condition": map[string]any{"StringLike": map[string]any{"s3:prefix": []any{"logs/*", "traces/*"}}},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:GetObject"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-loki/logs/*", "arn:aws:s3:::giantswarm-glippy-loki/traces/*"},
+				},
+				{
+					"Effect": "Allow",
+					"Action": []any{"s3:ListBucketMultipartUploads"},
+					"Resource": []any{
+						"arn:aws:s3:::giantswarm-glippy-loki",
+						"arn:aws:s3:::giantswarm-glippy-loki/logs/*",
+						"arn:aws:s3:::giantswarm-glippy-loki/traces/*",
+					},
+				},
+				accessPointsStatement,
+			},
+		},
+		{
+			name: "case 2: write without delete on an extra bucket, with a KMS key",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:              "loki",
+				BucketPermissions: v1beta1.BucketPermissions{AccessLevel: v1beta1.AccessLevelAdmin},
+				ExtraBuckets: []v1beta1.ExtraBucketPermissions{{
+					Name:              "giantswarm-glippy-archive",
+					BucketPermissions: v1beta1.BucketPermissions{AccessLevel: v1beta1.AccessLevelReadWriteNoDelete},
+				}},
+			},
+			encryption: &v1beta1.BucketEncryption{
+				Mode: v1beta1.EncryptionModeCustomerManaged,
+				KMS:  &v1beta1.KMSEncryption{KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/loki"},
+			},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:ListBucket", "s3:ListBucketVersions", "s3:ListBucketMultipartUploads"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-loki"},
+				},
+				{
+					"Effect": "Allow",
+					"Action": []any{
+						"s3:*Object",
+						"s3:*ObjectVersion",
+						"s3:*ObjectTagging",
+						"s3:*ObjectVersionTagging",
+						"s3:*ObjectRetention",
+						"s3:*ObjectLegalHold",
+						"s3:AbortMultipartUpload",
+						"s3:ListMultipartUploadParts",
+					},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-loki/*"},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:ListBucket"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-archive"},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"s3:GetObject", "s3:PutObject"},
+					"Resource": []any{"arn:aws:s3:::giantswarm-glippy-archive/*"},
+				},
+				accessPointsStatement,
+				{
+					"Effect":   "Allow",
+					"Action":   []any{"kms:Decrypt", "kms:GenerateDataKey"},
+					"Resource": []any{"arn:aws:kms:eu-west-1:123456789012:key/loki"},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-loki",
+				Access:     &v1beta1.BucketAccess{Role: &tc.accessRole},
+				Encryption: tc.encryption,
+			}}
+			policy, err := rolePolicyDocument("aws", bucket)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var document struct {
+				Version   string
+				Statement []map[string]any
+			}
+			if err := json.Unmarshal([]byte(policy), &document); err != nil {
+				t.Fatalf("role policy is not valid JSON: %v\n%s", err, policy)
+			}
+			if !cmp.Equal(document.Statement, tc.expectedStatements) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatements, document.Statement))
+			}
+		})
+	}
+}
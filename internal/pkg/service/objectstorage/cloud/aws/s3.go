@@ -1,29 +1,42 @@
 package aws
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"text/template"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 )
 
 type S3ObjectStorageAdapter struct {
 	s3Client             *s3.Client
 	logger               logr.Logger
+	accountId            string
 	cluster              AWSCluster
 	bucketPolicyTemplate *template.Template
+	client               client.Client
+	// abortIncompleteMultipartUploadDays applies to the buckets that do not set it.
+	abortIncompleteMultipartUploadDays int32
+	// accessRoleDefaults give the path of the access roles whose ARN is published before they exist.
+	accessRoleDefaults AccessRoleDefaults
 }
 
-func NewS3Service(s3Client *s3.Client, logger logr.Logger, cluster AWSCluster) S3ObjectStorageAdapter {
+func NewS3Service(s3Client *s3.Client, logger logr.Logger, accountId string, cluster AWSCluster, client client.Client, abortIncompleteMultipartUploadDays int32, accessRoleDefaults AccessRoleDefaults) S3ObjectStorageAdapter {
 	bucketPolicyTemplate, err := template.New("bucketPolicy").Parse(bucketPolicy)
 	if err != nil {
 		panic(err)
@@ -32,32 +45,82 @@ func NewS3Service(s3Client *s3.Client, logger logr.Logger, cluster AWSCluster) S
 	return S3ObjectStorageAdapter{
 		s3Client:             s3Client,
 		logger:               logger,
+		accountId:            accountId,
 		cluster:              cluster,
 		bucketPolicyTemplate: bucketPolicyTemplate,
+		client:               client,
+
+		abortIncompleteMultipartUploadDays: abortIncompleteMultipartUploadDays,
+		accessRoleDefaults:                 accessRoleDefaults,
 	}
 }
-func (s S3ObjectStorageAdapter) ExistsBucket(ctx context.Context, bucket *v1alpha1.Bucket) (bool, error) {
+
+// OwnerTagKey is the tag set on S3 buckets to record the Bucket that owns them.
+const OwnerTagKey = "giantswarm.io/bucket"
+
+// LifecycleRuleID is the ID of the lifecycle rule expiring all objects of the bucket.
+const LifecycleRuleID = "Expiration"
+
+// AbortIncompleteMultipartUploadRuleID is the ID of the lifecycle rule aborting the incomplete multipart uploads.
+const AbortIncompleteMultipartUploadRuleID = "AbortIncompleteMultipartUpload"
+
+// ExistsBucket checks if the bucket exists in the account of the management cluster.
+// S3 answers with a 403 when the bucket exists but belongs to another account, which is reported as a conflict.
+// A bucket of the account that is tagged as owned by another Bucket is a conflict too, and a bucket without owner tag
+// that the operator neither created nor adopted is reported as unmanaged.
+func (s S3ObjectStorageAdapter) ExistsBucket(ctx context.Context, bucket *v1beta1.Bucket) (bool, error) {
 	_, err := s.s3Client.HeadBucket(ctx, &s3.HeadBucketInput{
-		Bucket: aws.String(bucket.Spec.Name),
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
 	})
-	exists := true
 	if err != nil {
-		var apiError smithy.APIError
-		if errors.As(err, &apiError) {
-			switch apiError.(type) {
-			case *types.NotFound:
-				exists = false
-				err = nil
-			}
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+
+		var responseError *awshttp.ResponseError
+		if errors.As(err, &responseError) && responseError.HTTPStatusCode() == http.StatusForbidden {
+			return false, fmt.Errorf("%w: S3 bucket %s is not owned by account %s", objectstorage.ErrBucketConflict, bucket.Spec.Name, s.accountId)
 		}
+		return false, err
 	}
 
-	return exists, err
+	tags, err := s.getTags(ctx, bucket)
+	if err != nil {
+		return false, err
+	}
+	owner, ok := tags[OwnerTagKey]
+	switch {
+	case ok && owner != getBucketOwner(bucket):
+		return false, fmt.Errorf("%w: S3 bucket %s is owned by bucket %s", objectstorage.ErrBucketConflict, bucket.Spec.Name, owner)
+	case !ok && !bucket.IsManaged():
+		return true, fmt.Errorf("%w: S3 bucket %s already exists", objectstorage.ErrBucketUnmanaged, bucket.Spec.Name)
+	}
+	return true, nil
 }
 
-func (s S3ObjectStorageAdapter) CreateBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
+// AdoptBucket records the tags of the existing S3 bucket so that they are kept once the operator manages its tags.
+func (s S3ObjectStorageAdapter) AdoptBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
+	tags, err := s.getTags(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	adoption := &v1beta1.BucketAdoption{AdoptedAt: metav1.Now()}
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		adoption.Tags = append(adoption.Tags, v1beta1.BucketTag{Key: key, Value: tags[key]})
+	}
+	bucket.Status.Adoption = adoption
+	return nil
+}
+
+func (s S3ObjectStorageAdapter) CreateBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
 	createBucketInput := s3.CreateBucketInput{
 		Bucket: aws.String(bucket.Spec.Name),
+		// Object lock also enables versioning on the bucket
+		ObjectLockEnabledForBucket: aws.Bool(bucket.Spec.ObjectLock != nil),
+		ObjectOwnership:            types.ObjectOwnership(bucket.ObjectOwnership()),
 	}
 	// If the region is us-east-1, then location needs to be null, FFS
 	// https://github.com/aws/aws-sdk-go-v2/issues/1894
@@ -69,67 +132,117 @@ func (s S3ObjectStorageAdapter) CreateBucket(ctx context.Context, bucket *v1alph
 
 	_, err := s.s3Client.CreateBucket(ctx, &createBucketInput)
 	if err != nil {
+		var alreadyExists *types.BucketAlreadyExists
+		if errors.As(err, &alreadyExists) {
+			return fmt.Errorf("%w: S3 bucket %s already exists in another account", objectstorage.ErrBucketConflict, bucket.Spec.Name)
+		}
 		return fmt.Errorf("failed to create S3 bucket %s in region %s: %w", bucket.Spec.Name, s.cluster.GetRegion(), err)
 	}
 	return nil
 }
 
 // UpdateBucket does nothing as we cannot update an s3 bucket
-func (s S3ObjectStorageAdapter) UpdateBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
+func (s S3ObjectStorageAdapter) UpdateBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
 	return nil
 }
 
-func (s S3ObjectStorageAdapter) DeleteBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	// First we need to empty the bucket
-	paginator := s3.NewListObjectsV2Paginator(s.s3Client, &s3.ListObjectsV2Input{
-		Bucket: aws.String(bucket.Spec.Name),
+// DeleteAccessGrants does nothing, access grants are only supported on Azure.
+func (s S3ObjectStorageAdapter) DeleteAccessGrants(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return nil
+}
+
+func (s S3ObjectStorageAdapter) DeleteBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
+	// First we need to empty the bucket, including the previous versions and the delete markers of versioned buckets
+	paginator := s3.NewListObjectVersionsPaginator(s.s3Client, &s3.ListObjectVersionsInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
 	})
 
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to list objects in S3 bucket %s for deletion: %w", bucket.Spec.Name, err)
+			return fmt.Errorf("failed to list object versions in S3 bucket %s for deletion: %w", bucket.Spec.Name, err)
 		}
 
 		var objects []types.ObjectIdentifier
-		for _, object := range page.Contents {
+		for _, version := range page.Versions {
 			objects = append(objects, types.ObjectIdentifier{
-				Key: object.Key,
+				Key:       version.Key,
+				VersionId: version.VersionId,
+			})
+		}
+		for _, marker := range page.DeleteMarkers {
+			objects = append(objects, types.ObjectIdentifier{
+				Key:       marker.Key,
+				VersionId: marker.VersionId,
 			})
 		}
 
 		if len(objects) != 0 {
-			_, err = s.s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
-				Bucket: aws.String(bucket.Spec.Name),
+			output, err := s.s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket:              aws.String(bucket.Spec.Name),
+				ExpectedBucketOwner: aws.String(s.accountId),
 				Delete: &types.Delete{
 					Objects: objects,
+					Quiet:   aws.Bool(true),
 				},
 			})
 			if err != nil {
 				return fmt.Errorf("failed to delete objects from S3 bucket %s: %w", bucket.Spec.Name, err)
 			}
+			if len(output.Errors) != 0 {
+				return fmt.Errorf("failed to delete %d objects from S3 bucket %s: %s", len(output.Errors), bucket.Spec.Name, aws.ToString(output.Errors[0].Message))
+			}
 		}
 	}
 
 	// Then we can delete the bucket
 	_, err := s.s3Client.DeleteBucket(ctx, &s3.DeleteBucketInput{
-		Bucket: aws.String(bucket.Spec.Name),
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete S3 bucket %s: %w", bucket.Spec.Name, err)
 	}
+
+	// Connection Secrets in the namespace of the Bucket are garbage collected, but not the ones published elsewhere.
+	err = objectstorage.DeleteConnectionSecret(ctx, s.client, bucket, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete connection secret of S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
 	return nil
 }
 
-func (s S3ObjectStorageAdapter) ConfigureBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
+func (s S3ObjectStorageAdapter) ConfigureBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
 	var err error
+	err = s.setPublicAccess(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to set public access for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+
+	err = s.setVersioning(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to set versioning for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+
+	err = s.setObjectLock(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to set object lock for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+
+	err = s.setEncryption(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to set encryption for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+
 	// If expiration is not set, we remove all lifecycle rules
 	err = s.setLifecycleRules(ctx, bucket)
 	if err != nil {
 		return fmt.Errorf("failed to set lifecycle rules for S3 bucket %s: %w", bucket.Spec.Name, err)
 	}
 
-	// Set the bucket policy (enforce encryption in transit)
+	// Set the bucket policy (enforce encryption in transit, unless disabled by the bucket parameters, and the
+	// statements of the bucket)
 	err = s.setBucketPolicy(ctx, bucket)
 	if err != nil {
 		return fmt.Errorf("failed to set bucket policy for S3 bucket %s: %w", bucket.Spec.Name, err)
@@ -139,59 +252,430 @@ func (s S3ObjectStorageAdapter) ConfigureBucket(ctx context.Context, bucket *v1a
 	if err != nil {
 		return fmt.Errorf("failed to set tags for S3 bucket %s: %w", bucket.Spec.Name, err)
 	}
+
+	err = s.publishConnectionSecret(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to publish connection secret for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
 	return nil
 }
 
-func (s S3ObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	if bucket.Spec.ExpirationPolicy != nil {
-		enabledRuleStatus := types.ExpirationStatusEnabled
-		lifecycleConfiguration := types.BucketLifecycleConfiguration{
-			Rules: []types.LifecycleRule{
-				{
-					Status: enabledRuleStatus,
-					ID:     aws.String("Expiration"),
-					Filter: &types.LifecycleRuleFilter{
-						// Apply to all objects
-						Prefix: aws.String(""),
-					},
-					Expiration: &types.LifecycleExpiration{
-						Days: &bucket.Spec.ExpirationPolicy.Days,
+// setPublicAccess enforces the S3 Block Public Access settings and the object ownership of the bucket, and records
+// them in the status. Settings changed outside of the Bucket since they were last applied are reverted as drift.
+func (s S3ObjectStorageAdapter) setPublicAccess(ctx context.Context, bucket *v1beta1.Bucket) error {
+	publicAccessBlock := publicAccessBlockConfiguration(bucket)
+	objectOwnership := types.ObjectOwnership(bucket.ObjectOwnership())
+	desired := publicAccessStatus(publicAccessBlock, objectOwnership)
+
+	currentPublicAccessBlock := &types.PublicAccessBlockConfiguration{}
+	publicAccessBlockOutput, err := s.s3Client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+	})
+	var apiErr smithy.APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchPublicAccessBlockConfiguration") {
+		return fmt.Errorf("failed to get public access block of S3 bucket %s: %w", bucket.Spec.Name, err)
+	} else if err == nil && publicAccessBlockOutput.PublicAccessBlockConfiguration != nil {
+		currentPublicAccessBlock = publicAccessBlockOutput.PublicAccessBlockConfiguration
+	}
+
+	var currentObjectOwnership types.ObjectOwnership
+	ownershipControlsOutput, err := s.s3Client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+	})
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.ErrorCode() == "OwnershipControlsNotFoundError") {
+		return fmt.Errorf("failed to get ownership controls of S3 bucket %s: %w", bucket.Spec.Name, err)
+	} else if err == nil && ownershipControlsOutput.OwnershipControls != nil && len(ownershipControlsOutput.OwnershipControls.Rules) != 0 {
+		currentObjectOwnership = ownershipControlsOutput.OwnershipControls.Rules[0].ObjectOwnership
+	}
+
+	current := publicAccessStatus(currentPublicAccessBlock, currentObjectOwnership)
+	if !samePublicAccessBlock(current, desired) {
+		_, err = s.s3Client.PutPublicAccessBlock(ctx, &s3.PutPublicAccessBlockInput{
+			Bucket:                         aws.String(bucket.Spec.Name),
+			ExpectedBucketOwner:            aws.String(s.accountId),
+			PublicAccessBlockConfiguration: publicAccessBlock,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put public access block of S3 bucket %s: %w", bucket.Spec.Name, err)
+		}
+	}
+	if current.ObjectOwnership != desired.ObjectOwnership {
+		_, err = s.s3Client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
+			Bucket:              aws.String(bucket.Spec.Name),
+			ExpectedBucketOwner: aws.String(s.accountId),
+			OwnershipControls: &types.OwnershipControls{
+				Rules: []types.OwnershipControlsRule{{ObjectOwnership: objectOwnership}},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put ownership controls of S3 bucket %s: %w", bucket.Spec.Name, err)
+		}
+	}
+
+	if previous := bucket.Status.PublicAccess; previous != nil {
+		desired.LastDriftCorrectionTime = previous.LastDriftCorrectionTime
+		if publicAccessDrifted(previous, current) {
+			s.logger.Info("Reverted public access settings changed outside of the Bucket")
+			now := metav1.Now()
+			desired.LastDriftCorrectionTime = &now
+		}
+	}
+	bucket.Status.PublicAccess = desired
+	return nil
+}
+
+// publicAccessBlockConfiguration returns the S3 Block Public Access settings of the bucket: all enabled unless the
+// bucket opts out.
+func publicAccessBlockConfiguration(bucket *v1beta1.Bucket) *types.PublicAccessBlockConfiguration {
+	blockPublicAccess := bucket.BlockPublicAccess()
+	return &types.PublicAccessBlockConfiguration{
+		BlockPublicAcls:       aws.Bool(blockPublicAccess),
+		IgnorePublicAcls:      aws.Bool(blockPublicAccess),
+		BlockPublicPolicy:     aws.Bool(blockPublicAccess),
+		RestrictPublicBuckets: aws.Bool(blockPublicAccess),
+	}
+}
+
+// publicAccessStatus returns the public access protection of an S3 bucket out of its settings.
+func publicAccessStatus(publicAccessBlock *types.PublicAccessBlockConfiguration, objectOwnership types.ObjectOwnership) *v1beta1.BucketPublicAccessStatus {
+	return &v1beta1.BucketPublicAccessStatus{
+		BlockPublicAcls:       aws.ToBool(publicAccessBlock.BlockPublicAcls),
+		IgnorePublicAcls:      aws.ToBool(publicAccessBlock.IgnorePublicAcls),
+		BlockPublicPolicy:     aws.ToBool(publicAccessBlock.BlockPublicPolicy),
+		RestrictPublicBuckets: aws.ToBool(publicAccessBlock.RestrictPublicBuckets),
+		ObjectOwnership:       v1beta1.ObjectOwnership(objectOwnership),
+	}
+}
+
+// publicAccessDrifted returns true if the current settings of the bucket differ from the ones last applied.
+func publicAccessDrifted(applied, current *v1beta1.BucketPublicAccessStatus) bool {
+	return !samePublicAccessBlock(applied, current) || applied.ObjectOwnership != current.ObjectOwnership
+}
+
+func samePublicAccessBlock(a, b *v1beta1.BucketPublicAccessStatus) bool {
+	return a.BlockPublicAcls == b.BlockPublicAcls &&
+		a.IgnorePublicAcls == b.IgnorePublicAcls &&
+		a.BlockPublicPolicy == b.BlockPublicPolicy &&
+		a.RestrictPublicBuckets == b.RestrictPublicBuckets
+}
+
+// setVersioning enables or suspends the versioning of the bucket. Versioning is left untouched when not set, as a
+// bucket cannot go back to unversioned once versioning was enabled.
+func (s S3ObjectStorageAdapter) setVersioning(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if bucket.Spec.Versioning == "" {
+		return nil
+	}
+
+	_, err := s.s3Client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+		VersioningConfiguration: &types.VersioningConfiguration{
+			Status: types.BucketVersioningStatus(bucket.Spec.Versioning),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put versioning configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+	return nil
+}
+
+// setEncryption sets the default encryption of the objects of the bucket, and records the current encryption in the
+// status. The encryption of buckets that do not set any is left untouched.
+func (s S3ObjectStorageAdapter) setEncryption(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if bucket.Spec.Encryption != nil {
+		rule := serverSideEncryptionRule(bucket)
+		_, err := s.s3Client.PutBucketEncryption(ctx, &s3.PutBucketEncryptionInput{
+			Bucket:              aws.String(bucket.Spec.Name),
+			ExpectedBucketOwner: aws.String(s.accountId),
+			ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{
+				Rules: []types.ServerSideEncryptionRule{rule},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put encryption configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
+		}
+		bucket.Status.Encryption = encryptionStatus([]types.ServerSideEncryptionRule{rule})
+		return nil
+	}
+
+	output, err := s.s3Client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get encryption configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+	var rules []types.ServerSideEncryptionRule
+	if output.ServerSideEncryptionConfiguration != nil {
+		rules = output.ServerSideEncryptionConfiguration.Rules
+	}
+	bucket.Status.Encryption = encryptionStatus(rules)
+	return nil
+}
+
+// serverSideEncryptionRule returns the default encryption of the bucket: SSE-KMS with the key of the bucket when it is
+// customer managed, SSE-S3 otherwise.
+func serverSideEncryptionRule(bucket *v1beta1.Bucket) types.ServerSideEncryptionRule {
+	if bucket.KMSKeyARN() == "" {
+		return types.ServerSideEncryptionRule{
+			ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
+				SSEAlgorithm: types.ServerSideEncryptionAes256,
+			},
+		}
+	}
+	return types.ServerSideEncryptionRule{
+		ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
+			SSEAlgorithm:   types.ServerSideEncryptionAwsKms,
+			KMSMasterKeyID: aws.String(bucket.KMSKeyARN()),
+		},
+		BucketKeyEnabled: aws.Bool(bucket.Spec.Encryption.KMS.BucketKeyEnabled),
+	}
+}
+
+// encryptionStatus returns the encryption of an S3 bucket out of its default encryption rules. Buckets are always
+// encrypted, with SSE-S3 unless a KMS key is set.
+func encryptionStatus(rules []types.ServerSideEncryptionRule) *v1beta1.BucketEncryptionStatus {
+	for _, rule := range rules {
+		sse := rule.ApplyServerSideEncryptionByDefault
+		if sse == nil || sse.SSEAlgorithm == types.ServerSideEncryptionAes256 {
+			continue
+		}
+		return &v1beta1.BucketEncryptionStatus{
+			Mode:  v1beta1.EncryptionModeCustomerManaged,
+			KeyID: aws.ToString(sse.KMSMasterKeyID),
+		}
+	}
+	return &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeProviderManaged}
+}
+
+// setObjectLock sets the default retention of the objects of the bucket, and records the current retention in the status.
+// Object lock cannot be disabled once enabled: the default retention is left untouched when not set.
+func (s S3ObjectStorageAdapter) setObjectLock(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if bucket.Spec.ObjectLock != nil {
+		_, err := s.s3Client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
+			Bucket:              aws.String(bucket.Spec.Name),
+			ExpectedBucketOwner: aws.String(s.accountId),
+			ObjectLockConfiguration: &types.ObjectLockConfiguration{
+				ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+				Rule: &types.ObjectLockRule{
+					DefaultRetention: &types.DefaultRetention{
+						Mode: types.ObjectLockRetentionMode(bucket.Spec.ObjectLock.Mode),
+						Days: aws.Int32(bucket.Spec.ObjectLock.RetentionDays),
 					},
 				},
 			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put object lock configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
+		}
+		bucket.Status.ObjectLock = &v1beta1.BucketObjectLockStatus{
+			Mode:          bucket.Spec.ObjectLock.Mode,
+			RetentionDays: bucket.Spec.ObjectLock.RetentionDays,
 		}
+		return nil
+	}
+
+	output, err := s.s3Client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+	})
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ObjectLockConfigurationNotFoundError" {
+		bucket.Status.ObjectLock = nil
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get object lock configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+	bucket.Status.ObjectLock = objectLockStatus(output.ObjectLockConfiguration)
+	return nil
+}
+
+// objectLockStatus returns the default retention of an S3 object lock configuration, or nil if there is none.
+func objectLockStatus(configuration *types.ObjectLockConfiguration) *v1beta1.BucketObjectLockStatus {
+	if configuration == nil || configuration.ObjectLockEnabled != types.ObjectLockEnabledEnabled {
+		return nil
+	}
+	objectLock := &v1beta1.BucketObjectLockStatus{}
+	if configuration.Rule == nil || configuration.Rule.DefaultRetention == nil {
+		return objectLock
+	}
+	retention := configuration.Rule.DefaultRetention
+	objectLock.Mode = v1beta1.ObjectLockMode(retention.Mode)
+	switch {
+	case retention.Days != nil:
+		objectLock.RetentionDays = *retention.Days
+	case retention.Years != nil:
+		objectLock.RetentionDays = *retention.Years * 365
+	}
+	return objectLock
+}
+
+func (s S3ObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket *v1beta1.Bucket) error {
+	abortIncompleteMultipartUploadDays := bucket.AbortIncompleteMultipartUploadDays(s.abortIncompleteMultipartUploadDays)
+	rules, err := lifecycleRules(bucket, abortIncompleteMultipartUploadDays)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
 		input := &s3.PutBucketLifecycleConfigurationInput{
-			Bucket:                 aws.String(bucket.Spec.Name),
-			LifecycleConfiguration: &lifecycleConfiguration,
+			Bucket:              aws.String(bucket.Spec.Name),
+			ExpectedBucketOwner: aws.String(s.accountId),
+			LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+				Rules: rules,
+			},
 		}
 		_, err := s.s3Client.PutBucketLifecycleConfiguration(ctx, input)
 		if err != nil {
 			return fmt.Errorf("failed to put lifecycle configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
 		}
+		bucket.Status.Lifecycle = &v1beta1.BucketLifecycleStatus{AbortIncompleteMultipartUploadDays: abortIncompleteMultipartUploadDays}
 		return nil
 	}
 
-	_, err := s.s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
-		Bucket: aws.String(bucket.Spec.Name),
+	_, err = s.s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete lifecycle configuration for S3 bucket %s: %w", bucket.Spec.Name, err)
 	}
+	// Without rules, incomplete multipart uploads are kept forever.
+	bucket.Status.Lifecycle = &v1beta1.BucketLifecycleStatus{}
 	return nil
 }
 
-func (s S3ObjectStorageAdapter) setBucketPolicy(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	var policy bytes.Buffer
-	err := s.bucketPolicyTemplate.Execute(&policy, BucketPolicyData{
-		AWSDomain:  awsDomain(s.cluster.Region),
-		BucketName: bucket.Spec.Name,
-	})
+// lifecycleRules returns the S3 lifecycle rules of the bucket: the expiration of all objects and the abort of incomplete
+// multipart uploads after the given days, followed by the rules of the spec.
+func lifecycleRules(bucket *v1beta1.Bucket, abortIncompleteMultipartUploadDays int32) ([]types.LifecycleRule, error) {
+	var rules []types.LifecycleRule
+	if bucket.ExpirationDays() != nil {
+		rules = append(rules, types.LifecycleRule{
+			Status: types.ExpirationStatusEnabled,
+			ID:     aws.String(LifecycleRuleID),
+			Filter: &types.LifecycleRuleFilter{
+				// Apply to all objects
+				Prefix: aws.String(""),
+			},
+			Expiration: &types.LifecycleExpiration{
+				Days: bucket.ExpirationDays(),
+			},
+		})
+	}
+
+	if abortIncompleteMultipartUploadDays > 0 {
+		rules = append(rules, types.LifecycleRule{
+			Status: types.ExpirationStatusEnabled,
+			ID:     aws.String(AbortIncompleteMultipartUploadRuleID),
+			Filter: &types.LifecycleRuleFilter{
+				// Apply to all uploads
+				Prefix: aws.String(""),
+			},
+			AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int32(abortIncompleteMultipartUploadDays),
+			},
+		})
+	}
+
+	for _, rule := range bucket.LifecycleRules() {
+		lifecycleRule := types.LifecycleRule{
+			Status: types.ExpirationStatusDisabled,
+			ID:     aws.String(rule.ID),
+			Filter: lifecycleRuleFilter(rule.Filter),
+		}
+		if rule.IsEnabled() {
+			lifecycleRule.Status = types.ExpirationStatusEnabled
+		}
+
+		if rule.Expiration != nil {
+			lifecycleRule.Expiration = &types.LifecycleExpiration{}
+			if rule.Expiration.Days > 0 {
+				lifecycleRule.Expiration.Days = aws.Int32(rule.Expiration.Days)
+			}
+			if rule.Expiration.Date != "" {
+				// S3 expects the date at midnight UTC.
+				date, err := time.Parse(time.DateOnly, rule.Expiration.Date)
+				if err != nil {
+					return nil, fmt.Errorf("invalid expiration date of lifecycle rule %s: %w", rule.ID, err)
+				}
+				lifecycleRule.Expiration.Date = aws.Time(date)
+			}
+		}
+
+		// S3 does not accept delete markers cleanup together with the expiration of current objects.
+		if rule.ExpiredObjectDeleteMarker {
+			lifecycleRule.Expiration = &types.LifecycleExpiration{ExpiredObjectDeleteMarker: aws.Bool(true)}
+		}
+		if rule.NoncurrentVersionExpiration != nil {
+			lifecycleRule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{
+				NoncurrentDays: aws.Int32(rule.NoncurrentVersionExpiration.Days),
+			}
+			if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 {
+				lifecycleRule.NoncurrentVersionExpiration.NewerNoncurrentVersions = aws.Int32(rule.NoncurrentVersionExpiration.NewerNoncurrentVersions)
+			}
+		}
+
+		for _, transition := range rule.Transitions {
+			lifecycleRule.Transitions = append(lifecycleRule.Transitions, types.Transition{
+				Days:         aws.Int32(transition.Days),
+				StorageClass: types.TransitionStorageClass(transition.StorageClass),
+			})
+		}
+		rules = append(rules, lifecycleRule)
+	}
+	return rules, nil
+}
+
+// lifecycleRuleFilter returns the S3 filter matching the prefix and all the tags of the filter. S3 only accepts a
+// single condition outside of an And operator.
+func lifecycleRuleFilter(filter *v1beta1.BucketLifecycleFilter) *types.LifecycleRuleFilter {
+	if filter == nil || len(filter.Tags) == 0 {
+		prefix := ""
+		if filter != nil {
+			prefix = filter.Prefix
+		}
+		return &types.LifecycleRuleFilter{Prefix: aws.String(prefix)}
+	}
+
+	tags := make([]types.Tag, 0, len(filter.Tags))
+	for _, tag := range filter.Tags {
+		tags = append(tags, types.Tag{Key: aws.String(tag.Key), Value: aws.String(tag.Value)})
+	}
+	if filter.Prefix == "" && len(tags) == 1 {
+		return &types.LifecycleRuleFilter{Tag: &tags[0]}
+	}
+
+	and := &types.LifecycleRuleAndOperator{Tags: tags}
+	if filter.Prefix != "" {
+		and.Prefix = aws.String(filter.Prefix)
+	}
+	return &types.LifecycleRuleFilter{And: and}
+}
+
+func (s S3ObjectStorageAdapter) setBucketPolicy(ctx context.Context, bucket *v1beta1.Bucket) error {
+	policy, err := bucketPolicyDocument(s.bucketPolicyTemplate, awsDomain(s.cluster.Region), bucket)
 	if err != nil {
-		return fmt.Errorf("failed to execute bucket policy template for S3 bucket %s: %w", bucket.Spec.Name, err)
+		return fmt.Errorf("failed to build bucket policy for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+
+	// Without EnforceSSLOnly nor statements of the bucket, there is no policy at all.
+	if policy == "" {
+		_, err := s.s3Client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+			Bucket:              aws.String(bucket.Spec.Name),
+			ExpectedBucketOwner: aws.String(s.accountId),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete bucket policy for S3 bucket %s: %w", bucket.Spec.Name, err)
+		}
+		return nil
 	}
+
 	_, err = s.s3Client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
-		Bucket: aws.String(bucket.Spec.Name),
-		Policy: aws.String(policy.String()),
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+		Policy:              aws.String(policy),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to put bucket policy for S3 bucket %s: %w", bucket.Spec.Name, err)
@@ -199,26 +683,54 @@ func (s S3ObjectStorageAdapter) setBucketPolicy(ctx context.Context, bucket *v1a
 	return nil
 }
 
-func (s S3ObjectStorageAdapter) setTags(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	tags := make([]types.Tag, 0)
-	for _, t := range bucket.Spec.Tags {
-		// We use this to avoid pointer issues in range loops.
-		tag := t
+func (s S3ObjectStorageAdapter) getTags(ctx context.Context, bucket *v1beta1.Bucket) (map[string]string, error) {
+	output, err := s.s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
+	})
+	if err != nil {
+		var apiError smithy.APIError
+		if errors.As(err, &apiError) && apiError.ErrorCode() == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to get tags for S3 bucket %s: %w", bucket.Spec.Name, err)
+	}
+
+	tags := make(map[string]string, len(output.TagSet))
+	for _, tag := range output.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+func (s S3ObjectStorageAdapter) setTags(ctx context.Context, bucket *v1beta1.Bucket) error {
+	// Tags of the spec and of the cluster override the tags the bucket had when it was adopted.
+	tagsByKey := map[string]string{}
+	if bucket.IsAdopted() {
+		for _, tag := range bucket.Status.Adoption.Tags {
+			tagsByKey[tag.Key] = tag.Value
+		}
+	}
+	for _, tag := range bucket.Spec.Tags {
 		if tag.Key != "" && tag.Value != "" {
-			tags = append(tags, types.Tag{Key: &tag.Key, Value: &tag.Value})
+			tagsByKey[tag.Key] = tag.Value
 		}
 	}
-	for k, v := range s.cluster.GetTags() {
-		// We use this to avoid pointer issues in range loops.
-		key := k
-		value := v
+	for key, value := range s.cluster.GetTags() {
 		if key != "" && value != "" {
-			tags = append(tags, types.Tag{Key: &key, Value: &value})
+			tagsByKey[key] = value
 		}
 	}
+	tagsByKey[OwnerTagKey] = getBucketOwner(bucket)
+
+	tags := make([]types.Tag, 0, len(tagsByKey))
+	for _, key := range slices.Sorted(maps.Keys(tagsByKey)) {
+		tags = append(tags, types.Tag{Key: aws.String(key), Value: aws.String(tagsByKey[key])})
+	}
 
 	_, err := s.s3Client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
-		Bucket: aws.String(bucket.Spec.Name),
+		Bucket:              aws.String(bucket.Spec.Name),
+		ExpectedBucketOwner: aws.String(s.accountId),
 		Tagging: &types.Tagging{
 			TagSet: tags,
 		},
@@ -228,3 +740,8 @@ func (s S3ObjectStorageAdapter) setTags(ctx context.Context, bucket *v1alpha1.Bu
 	}
 	return nil
 }
+
+// getBucketOwner returns the value of the owner tag for the given bucket.
+func getBucketOwner(bucket *v1beta1.Bucket) string {
+	return fmt.Sprintf("%s/%s", bucket.Namespace, bucket.Name)
+}
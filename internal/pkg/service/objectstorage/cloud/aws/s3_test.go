@@ -0,0 +1,371 @@
+package aws
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_LifecycleRules(t *testing.T) {
+	testCases := []struct {
+		name                               string
+		lifecycle                          *v1beta1.BucketLifecycle
+		abortIncompleteMultipartUploadDays int32
+		expectedRules                      []types.LifecycleRule
+	}{
+		{
+			name:          "case 0: no lifecycle",
+			expectedRules: nil,
+		},
+		{
+			name:      "case 1: expiration of all objects",
+			lifecycle: &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}},
+			expectedRules: []types.LifecycleRule{
+				{
+					ID:         aws.String(LifecycleRuleID),
+					Status:     types.ExpirationStatusEnabled,
+					Filter:     &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					Expiration: &types.LifecycleExpiration{Days: aws.Int32(30)},
+				},
+			},
+		},
+		{
+			name: "case 2: rules with prefix and tag filters",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Expiration: &v1beta1.BucketExpiration{Days: 100},
+				Rules: []v1beta1.BucketLifecycleRule{
+					{
+						ID:         "index",
+						Filter:     &v1beta1.BucketLifecycleFilter{Prefix: "index/"},
+						Expiration: &v1beta1.BucketLifecycleExpiration{Days: 7},
+					},
+					{
+						ID:         "tenant-a",
+						Enabled:    aws.Bool(false),
+						Filter:     &v1beta1.BucketLifecycleFilter{Tags: []v1beta1.BucketTag{{Key: "tenant", Value: "a"}}},
+						Expiration: &v1beta1.BucketLifecycleExpiration{Date: "2030-01-01"},
+					},
+					{
+						ID: "tenant-b-chunks",
+						Filter: &v1beta1.BucketLifecycleFilter{
+							Prefix: "chunks/",
+							Tags:   []v1beta1.BucketTag{{Key: "tenant", Value: "b"}},
+						},
+						Expiration: &v1beta1.BucketLifecycleExpiration{Days: 14},
+					},
+					{
+						ID: "archive",
+						Transitions: []v1beta1.BucketLifecycleTransition{
+							{Days: 30, StorageClass: v1beta1.StorageClassStandardIA},
+							{Days: 180, StorageClass: v1beta1.StorageClassDeepArchive},
+						},
+					},
+				},
+			},
+			expectedRules: []types.LifecycleRule{
+				{
+					ID:         aws.String(LifecycleRuleID),
+					Status:     types.ExpirationStatusEnabled,
+					Filter:     &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					Expiration: &types.LifecycleExpiration{Days: aws.Int32(100)},
+				},
+				{
+					ID:         aws.String("index"),
+					Status:     types.ExpirationStatusEnabled,
+					Filter:     &types.LifecycleRuleFilter{Prefix: aws.String("index/")},
+					Expiration: &types.LifecycleExpiration{Days: aws.Int32(7)},
+				},
+				{
+					ID:         aws.String("tenant-a"),
+					Status:     types.ExpirationStatusDisabled,
+					Filter:     &types.LifecycleRuleFilter{Tag: &types.Tag{Key: aws.String("tenant"), Value: aws.String("a")}},
+					Expiration: &types.LifecycleExpiration{Date: aws.Time(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))},
+				},
+				{
+					ID:     aws.String("tenant-b-chunks"),
+					Status: types.ExpirationStatusEnabled,
+					Filter: &types.LifecycleRuleFilter{And: &types.LifecycleRuleAndOperator{
+						Prefix: aws.String("chunks/"),
+						Tags:   []types.Tag{{Key: aws.String("tenant"), Value: aws.String("b")}},
+					}},
+					Expiration: &types.LifecycleExpiration{Days: aws.Int32(14)},
+				},
+				{
+					ID:     aws.String("archive"),
+					Status: types.ExpirationStatusEnabled,
+					Filter: &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					Transitions: []types.Transition{
+						{Days: aws.Int32(30), StorageClass: types.TransitionStorageClassStandardIa},
+						{Days: aws.Int32(180), StorageClass: types.TransitionStorageClassDeepArchive},
+					},
+				},
+			},
+		},
+		{
+			name: "case 3: noncurrent versions and delete markers",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Rules: []v1beta1.BucketLifecycleRule{
+					{
+						ID:                          "versions",
+						NoncurrentVersionExpiration: &v1beta1.BucketNoncurrentVersionExpiration{Days: 7, NewerNoncurrentVersions: 3},
+						ExpiredObjectDeleteMarker:   true,
+					},
+					{
+						ID:                          "index-versions",
+						Filter:                      &v1beta1.BucketLifecycleFilter{Prefix: "index/"},
+						Expiration:                  &v1beta1.BucketLifecycleExpiration{Days: 30},
+						NoncurrentVersionExpiration: &v1beta1.BucketNoncurrentVersionExpiration{Days: 1},
+					},
+				},
+			},
+			expectedRules: []types.LifecycleRule{
+				{
+					ID:                          aws.String("versions"),
+					Status:                      types.ExpirationStatusEnabled,
+					Filter:                      &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					Expiration:                  &types.LifecycleExpiration{ExpiredObjectDeleteMarker: aws.Bool(true)},
+					NoncurrentVersionExpiration: &types.NoncurrentVersionExpiration{NoncurrentDays: aws.Int32(7), NewerNoncurrentVersions: aws.Int32(3)},
+				},
+				{
+					ID:                          aws.String("index-versions"),
+					Status:                      types.ExpirationStatusEnabled,
+					Filter:                      &types.LifecycleRuleFilter{Prefix: aws.String("index/")},
+					Expiration:                  &types.LifecycleExpiration{Days: aws.Int32(30)},
+					NoncurrentVersionExpiration: &types.NoncurrentVersionExpiration{NoncurrentDays: aws.Int32(1)},
+				},
+			},
+		},
+		{
+			name:                               "case 4: default abort of incomplete multipart uploads",
+			lifecycle:                          &v1beta1.BucketLifecycle{Expiration: &v1beta1.BucketExpiration{Days: 30}},
+			abortIncompleteMultipartUploadDays: 7,
+			expectedRules: []types.LifecycleRule{
+				{
+					ID:         aws.String(LifecycleRuleID),
+					Status:     types.ExpirationStatusEnabled,
+					Filter:     &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					Expiration: &types.LifecycleExpiration{Days: aws.Int32(30)},
+				},
+				{
+					ID:                             aws.String(AbortIncompleteMultipartUploadRuleID),
+					Status:                         types.ExpirationStatusEnabled,
+					Filter:                         &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int32(7)},
+				},
+			},
+		},
+		{
+			name:                               "case 5: abort of incomplete multipart uploads overridden by the bucket",
+			lifecycle:                          &v1beta1.BucketLifecycle{AbortIncompleteMultipartUploadDays: aws.Int32(2)},
+			abortIncompleteMultipartUploadDays: 7,
+			expectedRules: []types.LifecycleRule{
+				{
+					ID:                             aws.String(AbortIncompleteMultipartUploadRuleID),
+					Status:                         types.ExpirationStatusEnabled,
+					Filter:                         &types.LifecycleRuleFilter{Prefix: aws.String("")},
+					AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int32(2)},
+				},
+			},
+		},
+		{
+			name:                               "case 6: abort of incomplete multipart uploads disabled by the bucket",
+			lifecycle:                          &v1beta1.BucketLifecycle{AbortIncompleteMultipartUploadDays: aws.Int32(0)},
+			abortIncompleteMultipartUploadDays: 7,
+			expectedRules:                      nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{Name: "giantswarm-glippy-loki", Lifecycle: tc.lifecycle}}
+			rules, err := lifecycleRules(bucket, bucket.AbortIncompleteMultipartUploadDays(tc.abortIncompleteMultipartUploadDays))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			opts := cmpopts.IgnoreUnexported(types.LifecycleRule{}, types.LifecycleRuleFilter{}, types.LifecycleRuleAndOperator{}, types.LifecycleExpiration{}, types.Tag{}, types.Transition{}, types.NoncurrentVersionExpiration{}, types.AbortIncompleteMultipartUpload{})
+			if !cmp.Equal(rules, tc.expectedRules, opts) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedRules, rules, opts))
+			}
+		})
+	}
+}
+
+func Test_ObjectLockStatus(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configuration  *types.ObjectLockConfiguration
+		expectedStatus *v1beta1.BucketObjectLockStatus
+	}{
+		{
+			name:           "case 0: no object lock",
+			configuration:  &types.ObjectLockConfiguration{},
+			expectedStatus: nil,
+		},
+		{
+			name:           "case 1: object lock without default retention",
+			configuration:  &types.ObjectLockConfiguration{ObjectLockEnabled: types.ObjectLockEnabledEnabled},
+			expectedStatus: &v1beta1.BucketObjectLockStatus{},
+		},
+		{
+			name: "case 2: default retention in days",
+			configuration: &types.ObjectLockConfiguration{
+				ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+				Rule: &types.ObjectLockRule{DefaultRetention: &types.DefaultRetention{
+					Mode: types.ObjectLockRetentionModeCompliance,
+					Days: aws.Int32(365),
+				}},
+			},
+			expectedStatus: &v1beta1.BucketObjectLockStatus{Mode: v1beta1.ObjectLockModeCompliance, RetentionDays: 365},
+		},
+		{
+			name: "case 3: default retention in years",
+			configuration: &types.ObjectLockConfiguration{
+				ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+				Rule: &types.ObjectLockRule{DefaultRetention: &types.DefaultRetention{
+					Mode:  types.ObjectLockRetentionModeGovernance,
+					Years: aws.Int32(2),
+				}},
+			},
+			expectedStatus: &v1beta1.BucketObjectLockStatus{Mode: v1beta1.ObjectLockModeGovernance, RetentionDays: 730},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			status := objectLockStatus(tc.configuration)
+			if !cmp.Equal(status, tc.expectedStatus) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatus, status))
+			}
+		})
+	}
+}
+
+func Test_ServerSideEncryptionRule(t *testing.T) {
+	testCases := []struct {
+		name           string
+		encryption     *v1beta1.BucketEncryption
+		expectedRule   types.ServerSideEncryptionRule
+		expectedStatus *v1beta1.BucketEncryptionStatus
+	}{
+		{
+			name:       "case 0: provider-managed encryption",
+			encryption: &v1beta1.BucketEncryption{Mode: v1beta1.EncryptionModeProviderManaged},
+			expectedRule: types.ServerSideEncryptionRule{
+				ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{SSEAlgorithm: types.ServerSideEncryptionAes256},
+			},
+			expectedStatus: &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeProviderManaged},
+		},
+		{
+			name: "case 1: KMS key with S3 Bucket Key",
+			encryption: &v1beta1.BucketEncryption{
+				Mode: v1beta1.EncryptionModeCustomerManaged,
+				KMS: &v1beta1.KMSEncryption{
+					KeyARN:           "arn:aws:kms:eu-west-1:123456789012:key/loki",
+					BucketKeyEnabled: true,
+				},
+			},
+			expectedRule: types.ServerSideEncryptionRule{
+				ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
+					SSEAlgorithm:   types.ServerSideEncryptionAwsKms,
+					KMSMasterKeyID: aws.String("arn:aws:kms:eu-west-1:123456789012:key/loki"),
+				},
+				BucketKeyEnabled: aws.Bool(true),
+			},
+			expectedStatus: &v1beta1.BucketEncryptionStatus{
+				Mode:  v1beta1.EncryptionModeCustomerManaged,
+				KeyID: "arn:aws:kms:eu-west-1:123456789012:key/loki",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{Name: "my-bucket", Encryption: tc.encryption}}
+			rule := serverSideEncryptionRule(bucket)
+			opts := cmpopts.IgnoreUnexported(types.ServerSideEncryptionRule{}, types.ServerSideEncryptionByDefault{})
+			if !cmp.Equal(rule, tc.expectedRule, opts) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedRule, rule, opts))
+			}
+
+			status := encryptionStatus([]types.ServerSideEncryptionRule{rule})
+			if !cmp.Equal(status, tc.expectedStatus) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatus, status))
+			}
+		})
+	}
+}
+
+func Test_PublicAccess(t *testing.T) {
+	blockPublicAccess := false
+	blocked := &v1beta1.BucketPublicAccessStatus{
+		BlockPublicAcls:       true,
+		IgnorePublicAcls:      true,
+		BlockPublicPolicy:     true,
+		RestrictPublicBuckets: true,
+		ObjectOwnership:       v1beta1.ObjectOwnershipBucketOwnerEnforced,
+	}
+
+	testCases := []struct {
+		name            string
+		parameters      *v1beta1.BucketParameters
+		current         *v1beta1.BucketPublicAccessStatus
+		expectedStatus  *v1beta1.BucketPublicAccessStatus
+		expectedDrifted bool
+	}{
+		{
+			name:           "case 0: public access blocked by default",
+			current:        blocked,
+			expectedStatus: blocked,
+		},
+		{
+			name:            "case 1: public access block removed outside of the bucket",
+			current:         &v1beta1.BucketPublicAccessStatus{BlockPublicAcls: true, ObjectOwnership: v1beta1.ObjectOwnershipBucketOwnerEnforced},
+			expectedStatus:  blocked,
+			expectedDrifted: true,
+		},
+		{
+			name:            "case 2: ACLs enabled outside of the bucket",
+			current:         &v1beta1.BucketPublicAccessStatus{BlockPublicAcls: true, IgnorePublicAcls: true, BlockPublicPolicy: true, RestrictPublicBuckets: true, ObjectOwnership: v1beta1.ObjectOwnershipObjectWriter},
+			expectedStatus:  blocked,
+			expectedDrifted: true,
+		},
+		{
+			name: "case 3: public access allowed by the bucket",
+			parameters: &v1beta1.BucketParameters{
+				AWS: &v1beta1.AWSBucketParameters{BlockPublicAccess: &blockPublicAccess, ObjectOwnership: v1beta1.ObjectOwnershipBucketOwnerPreferred},
+			},
+			current:        &v1beta1.BucketPublicAccessStatus{ObjectOwnership: v1beta1.ObjectOwnershipBucketOwnerPreferred},
+			expectedStatus: &v1beta1.BucketPublicAccessStatus{ObjectOwnership: v1beta1.ObjectOwnershipBucketOwnerPreferred},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{Name: "my-bucket", Parameters: tc.parameters}}
+			status := publicAccessStatus(publicAccessBlockConfiguration(bucket), types.ObjectOwnership(bucket.ObjectOwnership()))
+			if !cmp.Equal(status, tc.expectedStatus) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatus, status))
+			}
+
+			if drifted := publicAccessDrifted(status, tc.current); drifted != tc.expectedDrifted {
+				t.Fatalf("expected drifted %t, got %t", tc.expectedDrifted, drifted)
+			}
+		})
+	}
+}
@@ -19,6 +19,11 @@ import (
 )
 
 type AWSObjectStorageService struct {
+	// AbortIncompleteMultipartUploadDays is the default number of days after which incomplete multipart uploads are
+	// aborted. 0 keeps them forever.
+	AbortIncompleteMultipartUploadDays int32
+	// AccessRoleDefaults are the default settings of the access roles.
+	AccessRoleDefaults AccessRoleDefaults
 }
 
 func (s AWSObjectStorageService) NewAccessRoleService(ctx context.Context, logger logr.Logger, cluster cluster.Cluster) (objectstorage.AccessRoleService, error) {
@@ -45,7 +50,7 @@ func (s AWSObjectStorageService) NewAccessRoleService(ctx context.Context, logge
 	if !ok {
 		return nil, fmt.Errorf("failed to cast cluster to AWS cluster for cluster %s", cluster.GetName())
 	}
-	return NewIamService(iam.NewFromConfig(cfg), logger, parsedRole.AccountID, awscluster), nil
+	return NewIamService(iam.NewFromConfig(cfg), NewPodIdentityClient(cfg), logger, parsedRole.AccountID, awscluster, s.AccessRoleDefaults), nil
 }
 
 func (s AWSObjectStorageService) NewObjectStorageService(ctx context.Context, logger logr.Logger, cluster cluster.Cluster, client client.Client) (objectstorage.ObjectStorageService, error) {
@@ -63,9 +68,15 @@ func (s AWSObjectStorageService) NewObjectStorageService(ctx context.Context, lo
 	credentials := stscreds.NewAssumeRoleProvider(stsClient, awsCredentials.Role)
 	cfg.Credentials = aws.NewCredentialsCache(credentials)
 
+	// Buckets are expected to be owned by the account of the assumed role
+	parsedRole, err := arn.Parse(awsCredentials.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse AWS role ARN %s for cluster %s: %w", awsCredentials.Role, cluster.GetName(), err)
+	}
+
 	awscluster, ok := cluster.(AWSCluster)
 	if !ok {
 		return nil, fmt.Errorf("failed to cast cluster to AWS cluster for cluster %s", cluster.GetName())
 	}
-	return NewS3Service(s3.NewFromConfig(cfg), logger, awscluster), nil
+	return NewS3Service(s3.NewFromConfig(cfg), logger, parsedRole.AccountID, awscluster, client, s.AbortIncompleteMultipartUploadDays, s.AccessRoleDefaults), nil
 }
@@ -4,12 +4,6 @@ import (
 	"strings"
 )
 
-type RolePolicyData struct {
-	AWSDomain        string
-	BucketName       string
-	ExtraBucketNames []string
-}
-
 func awsDomain(region string) string {
 	domain := "aws"
 
@@ -24,89 +18,6 @@ func isChinaRegion(region string) bool {
 	return strings.Contains(region, "cn-")
 }
 
-const rolePolicy = `{
-	"Version": "2012-10-17",
-	"Statement": [
-		{
-			"Effect": "Allow",
-			"Action": [
-				"s3:ListBucket",
-				"s3:PutObject",
-				"s3:GetObject",
-				"s3:DeleteObject"
-			],
-			"Resource": [
-				{{ range .ExtraBucketNames }}
-				"arn:{{ $.AWSDomain }}:s3:::{{ . }}",
-				"arn:{{ $.AWSDomain }}:s3:::{{ . }}/*",
-				{{ end }}
-				"arn:{{ $.AWSDomain }}:s3:::{{ $.BucketName }}",
-				"arn:{{ $.AWSDomain }}:s3:::{{ $.BucketName }}/*"
-			]
-		},
-		{
-			"Effect": "Allow",
-			"Action": [
-				"s3:GetAccessPoint",
-				"s3:GetAccountPublicAccessBlock",
-				"s3:ListAccessPoints"
-			],
-			"Resource": "*"
-		}
-	]
-}`
-
-type TrustIdentityPolicyData struct {
-	AccountId               string
-	AWSDomain               string
-	CloudFrontDomain        string
-	ServiceAccountName      string
-	ServiceAccountNamespace string
-	IsGrafanaPostgresql     bool
-}
-
-const trustIdentityPolicy = `{
-	"Version": "2012-10-17",
-	"Statement": [
-		{
-			"Effect": "Allow",
-			"Principal": {
-				"Federated": "arn:{{ $.AWSDomain }}:iam::{{ $.AccountId }}:oidc-provider/{{ $.CloudFrontDomain }}"
-			},
-			"Action": "sts:AssumeRoleWithWebIdentity",
-			"Condition": {
-				"StringEquals": {
-					"{{ $.CloudFrontDomain }}:sub": "system:serviceaccount:{{ $.ServiceAccountNamespace }}:{{ $.ServiceAccountName }}"
-				}
-			}
-		}{{ if .IsGrafanaPostgresql }},
-		{
-			"Effect": "Allow",
-			"Principal": {
-				"Federated": "arn:{{ $.AWSDomain }}:iam::{{ $.AccountId }}:oidc-provider/{{ $.CloudFrontDomain }}"
-			},
-			"Action": "sts:AssumeRoleWithWebIdentity",
-			"Condition": {
-				"StringEquals": {
-					"{{ $.CloudFrontDomain }}:sub": "system:serviceaccount:monitoring:grafana-postgresql-recovery-test"
-				}
-			}
-		},
-		{
-			"Effect": "Allow",
-			"Principal": {
-				"Federated": "arn:{{ $.AWSDomain }}:iam::{{ $.AccountId }}:oidc-provider/{{ $.CloudFrontDomain }}"
-			},
-			"Action": "sts:AssumeRoleWithWebIdentity",
-			"Condition": {
-				"StringEquals": {
-					"{{ $.CloudFrontDomain }}:sub": "system:serviceaccount:monitoring:plugin-barman-cloud"
-				}
-			}
-		}{{ end }}
-	]
-}`
-
 type BucketPolicyData struct {
 	AWSDomain  string
 	BucketName string
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// TrustedOIDCProvider is an OIDC provider whose tokens are trusted to assume a role.
+type TrustedOIDCProvider struct {
+	// Domain is the issuer URL of the provider without its scheme, as used in the ARN and the condition keys of the
+	// provider.
+	Domain    string
+	Audiences []string
+}
+
+// trustedOIDCProvider returns the trusted OIDC provider of an issuer URL.
+func trustedOIDCProvider(issuer string, audiences []string) TrustedOIDCProvider {
+	return TrustedOIDCProvider{
+		Domain:    strings.TrimSuffix(strings.TrimPrefix(issuer, "https://"), "/"),
+		Audiences: audiences,
+	}
+}
+
+// TrustedServiceAccount is a service account allowed to assume a role. Its namespace and name may contain wildcards.
+type TrustedServiceAccount struct {
+	Namespace string
+	Name      string
+}
+
+// ConditionOperator returns the operator matching the subject of the service account in the trust policy.
+func (sa TrustedServiceAccount) ConditionOperator() string {
+	if strings.ContainsAny(sa.Namespace+sa.Name, "*?") {
+		return "StringLike"
+	}
+	return "StringEquals"
+}
+
+// trustPolicyDocument returns the trust policy of an access role: one statement per OIDC provider and service account
+// allowing the service account to assume the role with the tokens of the provider.
+func trustPolicyDocument(accountId string, awsDomain string, providers []TrustedOIDCProvider, serviceAccounts []TrustedServiceAccount) (string, error) {
+	policy := policyDocument{Version: "2012-10-17"}
+	for _, provider := range providers {
+		for _, serviceAccount := range serviceAccounts {
+			condition := map[string]map[string]any{
+				serviceAccount.ConditionOperator(): {
+					provider.Domain + ":sub": fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccount.Namespace, serviceAccount.Name),
+				},
+			}
+			if len(provider.Audiences) != 0 {
+				if condition["StringEquals"] == nil {
+					condition["StringEquals"] = map[string]any{}
+				}
+				condition["StringEquals"][provider.Domain+":aud"] = provider.Audiences
+			}
+
+			policy.Statement = append(policy.Statement, PolicyStatement{
+				Effect: string(v1beta1.PolicyEffectAllow),
+				Principal: map[string]string{
+					"Federated": fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", awsDomain, accountId, provider.Domain),
+				},
+				Action:    PolicyStrings{"sts:AssumeRoleWithWebIdentity"},
+				Condition: condition,
+			})
+		}
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal trust policy: %w", err)
+	}
+	return string(data), nil
+}
+
+// podIdentityTrustPolicyDocument returns the trust policy of an access role in the PodIdentity mode: one statement per
+// service account allowing EKS Pod Identity to assume the role for the pods of the service account in the cluster.
+func podIdentityTrustPolicyDocument(clusterName string, serviceAccounts []TrustedServiceAccount) (string, error) {
+	policy := policyDocument{Version: "2012-10-17"}
+	for _, serviceAccount := range serviceAccounts {
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Effect:    string(v1beta1.PolicyEffectAllow),
+			Principal: map[string]string{"Service": "pods.eks.amazonaws.com"},
+			Action:    PolicyStrings{"sts:AssumeRole", "sts:TagSession"},
+			Condition: map[string]map[string]any{
+				"StringEquals": {
+					"aws:RequestTag/eks-cluster-name":           clusterName,
+					"aws:RequestTag/kubernetes-namespace":       serviceAccount.Namespace,
+					"aws:RequestTag/kubernetes-service-account": serviceAccount.Name,
+				},
+			},
+		})
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal trust policy: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,181 @@
+package aws
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_TrustPolicyDocument(t *testing.T) {
+	testCases := []struct {
+		name               string
+		accessRole         v1beta1.BucketAccessRole
+		cluster            AWSCluster
+		expectedStatements []map[string]any
+	}{
+		{
+			name: "case 0: service account of the role with the default IRSA domain",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:           "loki",
+				ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+			},
+			cluster: AWSCluster{Name: "glippy", BaseDomain: "example.com", Region: "eu-west-1"},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Federated": "arn:aws:iam::123456789012:oidc-provider/irsa.glippy.example.com"},
+					"Action":    []any{"sts:AssumeRoleWithWebIdentity"},
+					"Condition": map[string]any{"StringEquals": map[string]any{"irsa.glippy.example.com:sub": "system:serviceaccount:loki:loki"}},
+				},
+			},
+		},
+		{
+			name: "case 1: additional service accounts with wildcards in China",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:           "grafana-postgresql",
+				ServiceAccount: v1beta1.ServiceAccountReference{Name: "grafana-postgresql", Namespace: "monitoring"},
+				ServiceAccounts: []v1beta1.ServiceAccountReference{
+					{Name: "plugin-barman-*", Namespace: "monitoring"},
+				},
+			},
+			cluster: AWSCluster{Name: "glippy", BaseDomain: "example.com", Region: "cn-north-1"},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Federated": "arn:aws-cn:iam::123456789012:oidc-provider/s3.cn-north-1.amazonaws.com.cn/123456789012-g8s-glippy-oidc-pod-identity-v3"},
+					"Action":    []any{"sts:AssumeRoleWithWebIdentity"},
+					"Condition": map[string]any{"StringEquals": map[string]any{"s3.cn-north-1.amazonaws.com.cn/123456789012-g8s-glippy-oidc-pod-identity-v3:sub": "system:serviceaccount:monitoring:grafana-postgresql"}},
+				},
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Federated": "arn:aws-cn:iam::123456789012:oidc-provider/s3.cn-north-1.amazonaws.com.cn/123456789012-g8s-glippy-oidc-pod-identity-v3"},
+					"Action":    []any{"sts:AssumeRoleWithWebIdentity"},
+					"Condition": map[string]any{"StringLike": map[string]any{"s3.cn-north-1.amazonaws.com.cn/123456789012-g8s-glippy-oidc-pod-identity-v3:sub": "system:serviceaccount:monitoring:plugin-barman-*"}},
+				},
+			},
+		},
+		{
+			name: "case 2: OIDC providers of the cluster with an audience",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:           "loki",
+				ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki-*", Namespace: "loki"},
+			},
+			cluster: AWSCluster{
+				Name:       "glippy",
+				BaseDomain: "example.com",
+				Region:     "eu-west-1",
+				OIDCProviders: oidcProvidersFromAnnotations(map[string]string{
+					OIDCIssuersAnnotation:   "https://oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLE",
+					OIDCAudiencesAnnotation: "sts.amazonaws.com",
+				}),
+			},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Federated": "arn:aws:iam::123456789012:oidc-provider/oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLE"},
+					"Action":    []any{"sts:AssumeRoleWithWebIdentity"},
+					"Condition": map[string]any{
+						"StringLike":   map[string]any{"oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLE:sub": "system:serviceaccount:loki:loki-*"},
+						"StringEquals": map[string]any{"oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLE:aud": []any{"sts.amazonaws.com"}},
+					},
+				},
+			},
+		},
+		{
+			name: "case 3: OIDC providers of the role override the cluster during an issuer migration",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:           "loki",
+				ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				OIDCProviders: []v1beta1.OIDCProvider{
+					{Issuer: "https://irsa.glippy.example.com"},
+					{Issuer: "https://issuer.example.com/glippy/", Audiences: []string{"sts.amazonaws.com"}},
+				},
+			},
+			cluster: AWSCluster{
+				Name:          "glippy",
+				BaseDomain:    "example.com",
+				Region:        "eu-west-1",
+				OIDCProviders: oidcProvidersFromAnnotations(map[string]string{OIDCIssuersAnnotation: "https://oidc.example.com"}),
+			},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Federated": "arn:aws:iam::123456789012:oidc-provider/irsa.glippy.example.com"},
+					"Action":    []any{"sts:AssumeRoleWithWebIdentity"},
+					"Condition": map[string]any{"StringEquals": map[string]any{"irsa.glippy.example.com:sub": "system:serviceaccount:loki:loki"}},
+				},
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Federated": "arn:aws:iam::123456789012:oidc-provider/issuer.example.com/glippy"},
+					"Action":    []any{"sts:AssumeRoleWithWebIdentity"},
+					"Condition": map[string]any{"StringEquals": map[string]any{
+						"issuer.example.com/glippy:sub": "system:serviceaccount:loki:loki",
+						"issuer.example.com/glippy:aud": []any{"sts.amazonaws.com"},
+					}},
+				},
+			},
+		},
+		{
+			name: "case 4: PodIdentity mode",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:            "loki",
+				Mode:            v1beta1.AccessRoleModePodIdentity,
+				ServiceAccount:  v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				ServiceAccounts: []v1beta1.ServiceAccountReference{{Name: "loki-canary", Namespace: "loki"}},
+			},
+			cluster: AWSCluster{Name: "glippy", Region: "eu-west-1", EKSClusterName: "glippy-eks"},
+			expectedStatements: []map[string]any{
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Service": "pods.eks.amazonaws.com"},
+					"Action":    []any{"sts:AssumeRole", "sts:TagSession"},
+					"Condition": map[string]any{"StringEquals": map[string]any{
+						"aws:RequestTag/eks-cluster-name":           "glippy-eks",
+						"aws:RequestTag/kubernetes-namespace":       "loki",
+						"aws:RequestTag/kubernetes-service-account": "loki",
+					}},
+				},
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]any{"Service": "pods.eks.amazonaws.com"},
+					"Action":    []any{"sts:AssumeRole", "sts:TagSession"},
+					"Condition": map[string]any{"StringEquals": map[string]any{
+						"aws:RequestTag/eks-cluster-name":           "glippy-eks",
+						"aws:RequestTag/kubernetes-namespace":       "loki",
+						"aws:RequestTag/kubernetes-service-account": "loki-canary",
+					}},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			s := IAMAccessRoleServiceAdapter{accountId: "123456789012", cluster: tc.cluster}
+			policy, err := trustPolicyDocument(s.accountId, awsDomain(tc.cluster.Region), s.oidcProviders(&tc.accessRole), trustedServiceAccounts(&tc.accessRole))
+			if tc.accessRole.Mode == v1beta1.AccessRoleModePodIdentity {
+				policy, err = podIdentityTrustPolicyDocument(tc.cluster.EKSClusterName, trustedServiceAccounts(&tc.accessRole))
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var document struct {
+				Version   string
+				Statement []map[string]any
+			}
+			if err := json.Unmarshal([]byte(policy), &document); err != nil {
+				t.Fatalf("trust policy is not valid JSON: %v\n%s", err, policy)
+			}
+			if !cmp.Equal(document.Statement, tc.expectedStatements) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatements, document.Statement))
+			}
+		})
+	}
+}
@@ -2,21 +2,403 @@ package azure
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/msi/armmsi"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 )
 
+const (
+	// FederatedCredentialAudience is the audience of the service account tokens exchanged for Microsoft Entra
+	// tokens, used when the OIDC provider does not list any audience.
+	FederatedCredentialAudience = "api://AzureADTokenExchange"
+
+	// Built-in roles granted on containers to managed identities and access grants.
+	storageBlobDataReaderRoleID      = "2a2b9908-6ea1-4ae2-8e65-a410df84e7d1"
+	storageBlobDataContributorRoleID = "ba92f5b4-2d11-453d-a403-e96b0029c9fe"
+	storageBlobDataOwnerRoleID       = "b7e6dc6d-f1e8-4753-8033-0f276bb0955b"
+
+	// Federated identity credential names are limited to 120 characters.
+	maxFederatedCredentialNameLength = 120
+)
+
+var federatedCredentialNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// AzureAccessServiceAdapter implements the access role of a bucket as a user-assigned managed identity, trusting the
+// service accounts of the role through federated identity credentials, with a role assignment on the container.
 type AzureAccessServiceAdapter struct {
+	identitiesClient           *armmsi.UserAssignedIdentitiesClient
+	federatedCredentialsClient *armmsi.FederatedIdentityCredentialsClient
+	roleAssignmentsClient      *armauthorization.RoleAssignmentsClient
+	logger                     logr.Logger
+	cluster                    AzureCluster
 }
 
-func NewAzureAccessService() AzureAccessServiceAdapter {
-	return AzureAccessServiceAdapter{}
+func NewAzureAccessService(
+	identitiesClient *armmsi.UserAssignedIdentitiesClient,
+	federatedCredentialsClient *armmsi.FederatedIdentityCredentialsClient,
+	roleAssignmentsClient *armauthorization.RoleAssignmentsClient,
+	logger logr.Logger,
+	cluster AzureCluster) AzureAccessServiceAdapter {
+	return AzureAccessServiceAdapter{
+		identitiesClient:           identitiesClient,
+		federatedCredentialsClient: federatedCredentialsClient,
+		roleAssignmentsClient:      roleAssignmentsClient,
+		logger:                     logger,
+		cluster:                    cluster,
+	}
 }
 
-func (s AzureAccessServiceAdapter) ConfigureRole(ctx context.Context, bucket *v1alpha1.Bucket) error {
+// ConfigureRole creates or updates the managed identity of the access role, its federated identity credentials and
+// its role assignment on the container, and records the identity in the status of the bucket.
+func (s AzureAccessServiceAdapter) ConfigureRole(ctx context.Context, bucket *v1beta1.Bucket) error {
+	accessRole := bucket.AccessRole()
+	providers := s.oidcProviders(accessRole)
+	if len(providers) == 0 {
+		return fmt.Errorf("no OIDC issuer is configured for access role %s, set spec.access.role.oidcProviders or the %s annotation on the AzureCluster", accessRole.Name, OIDCIssuersAnnotation)
+	}
+
+	identity, err := s.upsertIdentity(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	err = s.configureFederatedCredentials(ctx, accessRole.Name, federatedCredentials(providers, accessRole))
+	if err != nil {
+		return err
+	}
+
+	err = s.configureRoleAssignment(ctx, bucket, *identity.Properties.PrincipalID)
+	if err != nil {
+		return err
+	}
+
+	bucket.Status.AccessRole = &v1beta1.BucketAccessRoleStatus{
+		ClientID:    *identity.Properties.ClientID,
+		PrincipalID: *identity.Properties.PrincipalID,
+		TenantID:    *identity.Properties.TenantID,
+	}
 	return nil
 }
 
-func (s AzureAccessServiceAdapter) DeleteRole(ctx context.Context, bucket *v1alpha1.Bucket) error {
+// DeleteRole deletes the role assignment and the managed identity of the access role, which takes its federated
+// identity credentials with it. Identities owned by another bucket are left untouched.
+func (s AzureAccessServiceAdapter) DeleteRole(ctx context.Context, bucket *v1beta1.Bucket) error {
+	identityName := bucket.AccessRole().Name
+	identity, err := s.getIdentity(ctx, identityName)
+	if err != nil {
+		return err
+	}
+	if identity == nil {
+		s.logger.Info(fmt.Sprintf("managed identity %s does not exist, skipping deletion", identityName))
+		return nil
+	}
+	if !isOwnedBy(identity.Tags, bucket) {
+		s.logger.Info(fmt.Sprintf("managed identity %s is not owned by bucket %s, skipping deletion", identityName, getBucketOwner(bucket)))
+		return nil
+	}
+
+	if identity.Properties != nil && identity.Properties.PrincipalID != nil {
+		err = s.deleteRoleAssignments(ctx, bucket, *identity.Properties.PrincipalID, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.identitiesClient.Delete(ctx, s.cluster.GetResourceGroup(), identityName, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete managed identity %s: %w", identityName, err)
+	}
+	s.logger.Info(fmt.Sprintf("managed identity %s deleted", identityName))
 	return nil
 }
+
+// oidcProviders returns the OIDC providers trusted by the access role: its own providers, or else the providers of
+// the management cluster.
+func (s AzureAccessServiceAdapter) oidcProviders(accessRole *v1beta1.BucketAccessRole) []v1beta1.OIDCProvider {
+	if len(accessRole.OIDCProviders) != 0 {
+		return accessRole.OIDCProviders
+	}
+	return s.cluster.OIDCProviders
+}
+
+func (s AzureAccessServiceAdapter) getIdentity(ctx context.Context, identityName string) (*armmsi.Identity, error) {
+	response, err := s.identitiesClient.Get(ctx, s.cluster.GetResourceGroup(), identityName, nil)
+	if isNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get managed identity %s: %w", identityName, err)
+	}
+	return &response.Identity, nil
+}
+
+// upsertIdentity creates or updates the managed identity of the access role in the resource group of the management
+// cluster. An existing identity is only taken over when it is tagged for the bucket.
+func (s AzureAccessServiceAdapter) upsertIdentity(ctx context.Context, bucket *v1beta1.Bucket) (*armmsi.Identity, error) {
+	identityName := bucket.AccessRole().Name
+	existing, err := s.getIdentity(ctx, identityName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !isOwnedBy(existing.Tags, bucket) {
+		return nil, fmt.Errorf("managed identity %s already exists and is not owned by bucket %s", identityName, getBucketOwner(bucket))
+	}
+
+	response, err := s.identitiesClient.CreateOrUpdate(
+		ctx,
+		s.cluster.GetResourceGroup(),
+		identityName,
+		armmsi.Identity{
+			Location: to.Ptr(s.cluster.GetRegion()),
+			Tags:     getResourceTags(bucket, s.cluster.GetTags()),
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update managed identity %s: %w", identityName, err)
+	}
+	identity := &response.Identity
+	if identity.Properties == nil || identity.Properties.ClientID == nil || identity.Properties.PrincipalID == nil || identity.Properties.TenantID == nil {
+		return nil, fmt.Errorf("managed identity %s has no client, principal or tenant ID yet", identityName)
+	}
+	if existing == nil {
+		s.logger.Info(fmt.Sprintf("managed identity %s created", identityName))
+	} else {
+		s.logger.Info(fmt.Sprintf("managed identity %s updated", identityName))
+	}
+	return identity, nil
+}
+
+// federatedCredential is a federated identity credential of a managed identity, trusting the tokens issued to a
+// service account for an audience.
+type federatedCredential struct {
+	Name     string
+	Issuer   string
+	Subject  string
+	Audience string
+}
+
+// federatedCredentials returns the federated identity credentials of the access role: one per OIDC provider, service
+// account and audience, as Azure accepts a single audience and an exact subject per credential.
+func federatedCredentials(providers []v1beta1.OIDCProvider, accessRole *v1beta1.BucketAccessRole) []federatedCredential {
+	serviceAccounts := append([]v1beta1.ServiceAccountReference{accessRole.ServiceAccount}, accessRole.ServiceAccounts...)
+
+	var credentials []federatedCredential
+	for _, provider := range providers {
+		audiences := provider.Audiences
+		if len(audiences) == 0 {
+			audiences = []string{FederatedCredentialAudience}
+		}
+		for _, serviceAccount := range serviceAccounts {
+			subject := fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccount.Namespace, serviceAccount.Name)
+			for _, audience := range audiences {
+				credentials = append(credentials, federatedCredential{
+					Name:     federatedCredentialName(serviceAccount, provider.Issuer, audience),
+					Issuer:   provider.Issuer,
+					Subject:  subject,
+					Audience: audience,
+				})
+			}
+		}
+	}
+	return credentials
+}
+
+// federatedCredentialName returns a name made of the service account, readable in the Azure portal, and of a hash of
+// the whole credential, so that a changed issuer or audience results in a new credential.
+func federatedCredentialName(serviceAccount v1beta1.ServiceAccountReference, issuer string, audience string) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{issuer, serviceAccount.Namespace, serviceAccount.Name, audience}, "\n")))
+	suffix := "-" + hex.EncodeToString(hash[:4])
+
+	name := federatedCredentialNameInvalidChars.ReplaceAllString(serviceAccount.Namespace+"-"+serviceAccount.Name, "-")
+	if len(name) > maxFederatedCredentialNameLength-len(suffix) {
+		name = name[:maxFederatedCredentialNameLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// configureFederatedCredentials creates the missing federated identity credentials of the managed identity and
+// deletes the ones the access role does not trust anymore.
+func (s AzureAccessServiceAdapter) configureFederatedCredentials(ctx context.Context, identityName string, credentials []federatedCredential) error {
+	existing := map[string]federatedCredential{}
+	pager := s.federatedCredentialsClient.NewListPager(s.cluster.GetResourceGroup(), identityName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list federated identity credentials of managed identity %s: %w", identityName, err)
+		}
+		for _, credential := range page.Value {
+			if credential.Name == nil {
+				continue
+			}
+			existing[*credential.Name] = toFederatedCredential(credential)
+		}
+	}
+
+	desired := map[string]bool{}
+	for _, credential := range credentials {
+		desired[credential.Name] = true
+		if existing[credential.Name] == credential {
+			continue
+		}
+		// Azure does not support concurrent changes of the credentials of an identity, so they are made one by one.
+		_, err := s.federatedCredentialsClient.CreateOrUpdate(
+			ctx,
+			s.cluster.GetResourceGroup(),
+			identityName,
+			credential.Name,
+			armmsi.FederatedIdentityCredential{
+				Properties: &armmsi.FederatedIdentityCredentialProperties{
+					Issuer:    to.Ptr(credential.Issuer),
+					Subject:   to.Ptr(credential.Subject),
+					Audiences: []*string{to.Ptr(credential.Audience)},
+				},
+			},
+			nil,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create federated identity credential %s of managed identity %s: %w", credential.Name, identityName, err)
+		}
+		s.logger.Info(fmt.Sprintf("federated identity credential %s of managed identity %s created for %s", credential.Name, identityName, credential.Subject))
+	}
+
+	for name := range existing {
+		if desired[name] {
+			continue
+		}
+		_, err := s.federatedCredentialsClient.Delete(ctx, s.cluster.GetResourceGroup(), identityName, name, nil)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete federated identity credential %s of managed identity %s: %w", name, identityName, err)
+		}
+		s.logger.Info(fmt.Sprintf("federated identity credential %s of managed identity %s deleted", name, identityName))
+	}
+	return nil
+}
+
+func toFederatedCredential(credential *armmsi.FederatedIdentityCredential) federatedCredential {
+	result := federatedCredential{Name: *credential.Name}
+	if credential.Properties == nil {
+		return result
+	}
+	if credential.Properties.Issuer != nil {
+		result.Issuer = *credential.Properties.Issuer
+	}
+	if credential.Properties.Subject != nil {
+		result.Subject = *credential.Properties.Subject
+	}
+	if len(credential.Properties.Audiences) == 1 && credential.Properties.Audiences[0] != nil {
+		result.Audience = *credential.Properties.Audiences[0]
+	}
+	return result
+}
+
+// containerScope returns the resource ID of the container of the bucket, where roles are assigned.
+func containerScope(cluster AzureCluster, bucket *v1beta1.Bucket) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts/%s/blobServices/default/containers/%s",
+		cluster.GetSubscriptionID(), cluster.GetResourceGroup(), SanitizeStorageAccountName(bucket.Spec.Name), bucket.Spec.Name)
+}
+
+// roleDefinitionID returns the resource ID of a built-in role in the subscription of the cluster.
+func roleDefinitionID(cluster AzureCluster, roleID string) string {
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", cluster.GetSubscriptionID(), roleID)
+}
+
+// accessLevelRoleDefinitionID returns the ID of the built-in role matching the access level of the access role. Azure
+// has no role allowing to write blobs without deleting them, so ReadWriteNoDelete gets the Storage Blob Data
+// Contributor role.
+func (s AzureAccessServiceAdapter) accessLevelRoleDefinitionID(accessLevel v1beta1.AccessLevel) string {
+	roleID := storageBlobDataContributorRoleID
+	switch accessLevel {
+	case v1beta1.AccessLevelReadOnly:
+		roleID = storageBlobDataReaderRoleID
+	case v1beta1.AccessLevelAdmin:
+		roleID = storageBlobDataOwnerRoleID
+	}
+	return roleDefinitionID(s.cluster, roleID)
+}
+
+// roleAssignmentName returns the name of a role assignment, which Azure requires to be a GUID. It is derived from
+// the assignment itself so that creating it again is a no-op.
+func roleAssignmentName(scope string, principalID string, roleDefinitionID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(strings.ToLower(scope+"/"+principalID+"/"+roleDefinitionID))).String()
+}
+
+// configureRoleAssignment assigns the role matching the access level to the managed identity on the container, and
+// removes the assignments of other roles to the identity on the container, e.g. after a change of access level.
+func (s AzureAccessServiceAdapter) configureRoleAssignment(ctx context.Context, bucket *v1beta1.Bucket, principalID string) error {
+	scope := containerScope(s.cluster, bucket)
+	roleDefinitionID := s.accessLevelRoleDefinitionID(bucket.AccessRole().BucketPermissions.AccessLevel)
+
+	_, err := s.roleAssignmentsClient.Create(
+		ctx,
+		scope,
+		roleAssignmentName(scope, principalID, roleDefinitionID),
+		armauthorization.RoleAssignmentCreateParameters{
+			Properties: &armauthorization.RoleAssignmentProperties{
+				PrincipalID:      to.Ptr(principalID),
+				RoleDefinitionID: to.Ptr(roleDefinitionID),
+				// The principal type skips the lookup of the principal, which fails while a new identity replicates.
+				PrincipalType: to.Ptr(armauthorization.PrincipalTypeServicePrincipal),
+			},
+		},
+		nil,
+	)
+	if err != nil && !isRoleAssignmentExists(err) {
+		return fmt.Errorf("failed to assign role %s to principal %s on container %s: %w", roleDefinitionID, principalID, bucket.Spec.Name, err)
+	}
+
+	return s.deleteRoleAssignments(ctx, bucket, principalID, roleDefinitionID)
+}
+
+// deleteRoleAssignments deletes the role assignments of the principal on the container of the bucket, except the
+// assignment of the role to keep.
+func (s AzureAccessServiceAdapter) deleteRoleAssignments(ctx context.Context, bucket *v1beta1.Bucket, principalID string, keepRoleDefinitionID string) error {
+	scope := containerScope(s.cluster, bucket)
+	pager := s.roleAssignmentsClient.NewListForSubscriptionPager(&armauthorization.RoleAssignmentsClientListForSubscriptionOptions{
+		Filter: to.Ptr(fmt.Sprintf("principalId eq '%s'", principalID)),
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list role assignments of principal %s: %w", principalID, err)
+		}
+		for _, assignment := range page.Value {
+			if assignment.ID == nil || assignment.Properties == nil || assignment.Properties.Scope == nil || assignment.Properties.RoleDefinitionID == nil {
+				continue
+			}
+			if !strings.EqualFold(*assignment.Properties.Scope, scope) || strings.EqualFold(*assignment.Properties.RoleDefinitionID, keepRoleDefinitionID) {
+				continue
+			}
+			_, err := s.roleAssignmentsClient.DeleteByID(ctx, *assignment.ID, nil)
+			if err != nil && !isNotFound(err) {
+				return fmt.Errorf("failed to delete role assignment %s: %w", *assignment.ID, err)
+			}
+			s.logger.Info(fmt.Sprintf("role assignment %s of principal %s deleted", *assignment.ID, principalID))
+		}
+	}
+	return nil
+}
+
+// isRoleAssignmentExists returns whether the error is returned for a role that is already assigned to the principal
+// on the scope, under another name.
+func isRoleAssignmentExists(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.ErrorCode == "RoleAssignmentExists"
+}
+
+func isNotFound(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
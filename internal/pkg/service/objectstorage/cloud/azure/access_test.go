@@ -0,0 +1,232 @@
+package azure
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_FederatedCredentials(t *testing.T) {
+	testCases := []struct {
+		name                string
+		accessRole          v1beta1.BucketAccessRole
+		cluster             AzureCluster
+		expectedCredentials []federatedCredential
+	}{
+		{
+			name: "case 0: no OIDC provider",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:           "loki",
+				ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+			},
+			expectedCredentials: nil,
+		},
+		{
+			name: "case 1: OIDC provider of the cluster with the default audience",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:            "loki",
+				ServiceAccount:  v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				ServiceAccounts: []v1beta1.ServiceAccountReference{{Name: "loki-canary", Namespace: "loki"}},
+			},
+			cluster: AzureCluster{
+				OIDCProviders: oidcProvidersFromAnnotations(map[string]string{OIDCIssuersAnnotation: "https://oidc.glippy.example.com/"}),
+			},
+			expectedCredentials: []federatedCredential{
+				{
+					Name:     federatedCredentialName(v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"}, "https://oidc.glippy.example.com/", FederatedCredentialAudience),
+					Issuer:   "https://oidc.glippy.example.com/",
+					Subject:  "system:serviceaccount:loki:loki",
+					Audience: FederatedCredentialAudience,
+				},
+				{
+					Name:     federatedCredentialName(v1beta1.ServiceAccountReference{Name: "loki-canary", Namespace: "loki"}, "https://oidc.glippy.example.com/", FederatedCredentialAudience),
+					Issuer:   "https://oidc.glippy.example.com/",
+					Subject:  "system:serviceaccount:loki:loki-canary",
+					Audience: FederatedCredentialAudience,
+				},
+			},
+		},
+		{
+			name: "case 2: OIDC providers of the role override the cluster, one credential per audience",
+			accessRole: v1beta1.BucketAccessRole{
+				Name:           "loki",
+				ServiceAccount: v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				OIDCProviders: []v1beta1.OIDCProvider{
+					{Issuer: "https://issuer.example.com/glippy", Audiences: []string{"api://AzureADTokenExchange", "loki"}},
+				},
+			},
+			cluster: AzureCluster{
+				OIDCProviders: oidcProvidersFromAnnotations(map[string]string{OIDCIssuersAnnotation: "https://oidc.glippy.example.com/"}),
+			},
+			expectedCredentials: []federatedCredential{
+				{
+					Name:     federatedCredentialName(v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"}, "https://issuer.example.com/glippy", "api://AzureADTokenExchange"),
+					Issuer:   "https://issuer.example.com/glippy",
+					Subject:  "system:serviceaccount:loki:loki",
+					Audience: "api://AzureADTokenExchange",
+				},
+				{
+					Name:     federatedCredentialName(v1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"}, "https://issuer.example.com/glippy", "loki"),
+					Issuer:   "https://issuer.example.com/glippy",
+					Subject:  "system:serviceaccount:loki:loki",
+					Audience: "loki",
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			s := AzureAccessServiceAdapter{cluster: tc.cluster}
+			credentials := federatedCredentials(s.oidcProviders(&tc.accessRole), &tc.accessRole)
+
+			if !cmp.Equal(credentials, tc.expectedCredentials) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedCredentials, credentials))
+			}
+		})
+	}
+}
+
+func Test_FederatedCredentialName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceAccount v1beta1.ServiceAccountReference
+		issuer         string
+		audience       string
+		expectedPrefix string
+	}{
+		{
+			name:           "case 0: dots of the service account are replaced",
+			serviceAccount: v1beta1.ServiceAccountReference{Name: "loki.canary", Namespace: "loki"},
+			issuer:         "https://oidc.glippy.example.com/",
+			audience:       FederatedCredentialAudience,
+			expectedPrefix: "loki-loki-canary-",
+		},
+		{
+			name:           "case 1: long service account names are truncated",
+			serviceAccount: v1beta1.ServiceAccountReference{Name: strings.Repeat("a", 253), Namespace: "loki"},
+			issuer:         "https://oidc.glippy.example.com/",
+			audience:       FederatedCredentialAudience,
+			expectedPrefix: "loki-" + strings.Repeat("a", 106),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			name := federatedCredentialName(tc.serviceAccount, tc.issuer, tc.audience)
+
+			if !strings.HasPrefix(name, tc.expectedPrefix) {
+				t.Fatalf("expected name %s to start with %s", name, tc.expectedPrefix)
+			}
+			if len(name) > maxFederatedCredentialNameLength {
+				t.Fatalf("name %s is longer than %d characters", name, maxFederatedCredentialNameLength)
+			}
+			if federatedCredentialNameInvalidChars.MatchString(name) {
+				t.Fatalf("name %s contains invalid characters", name)
+			}
+			if other := federatedCredentialName(tc.serviceAccount, tc.issuer, "other"); other == name {
+				t.Fatalf("expected another audience to result in another name than %s", name)
+			}
+		})
+	}
+}
+
+func Test_AccessLevelRoleDefinitionID(t *testing.T) {
+	testCases := []struct {
+		name        string
+		accessLevel v1beta1.AccessLevel
+		expectedID  string
+	}{
+		{
+			name:       "case 0: default access level",
+			expectedID: "/subscriptions/subscriptionID/providers/Microsoft.Authorization/roleDefinitions/" + storageBlobDataContributorRoleID,
+		},
+		{
+			name:        "case 1: read-only access",
+			accessLevel: v1beta1.AccessLevelReadOnly,
+			expectedID:  "/subscriptions/subscriptionID/providers/Microsoft.Authorization/roleDefinitions/" + storageBlobDataReaderRoleID,
+		},
+		{
+			name:        "case 2: write without delete is not supported by Azure",
+			accessLevel: v1beta1.AccessLevelReadWriteNoDelete,
+			expectedID:  "/subscriptions/subscriptionID/providers/Microsoft.Authorization/roleDefinitions/" + storageBlobDataContributorRoleID,
+		},
+		{
+			name:        "case 3: admin access",
+			accessLevel: v1beta1.AccessLevelAdmin,
+			expectedID:  "/subscriptions/subscriptionID/providers/Microsoft.Authorization/roleDefinitions/" + storageBlobDataOwnerRoleID,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			s := AzureAccessServiceAdapter{cluster: AzureCluster{Credentials: AzureCredentials{SubscriptionID: "subscriptionID"}}}
+			id := s.accessLevelRoleDefinitionID(tc.accessLevel)
+
+			if !cmp.Equal(id, tc.expectedID) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedID, id))
+			}
+		})
+	}
+}
+
+func Test_MergeAccessGrants(t *testing.T) {
+	testCases := []struct {
+		name           string
+		granted        []v1beta1.BucketAccessGrantStatus
+		others         []v1beta1.BucketAccessGrantStatus
+		expectedGrants []v1beta1.BucketAccessGrantStatus
+	}{
+		{
+			name:           "case 0: nothing granted yet",
+			others:         []v1beta1.BucketAccessGrantStatus{{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/assignments/reader"}},
+			expectedGrants: []v1beta1.BucketAccessGrantStatus{{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/assignments/reader"}},
+		},
+		{
+			name: "case 1: assignments granted again are recorded once",
+			granted: []v1beta1.BucketAccessGrantStatus{
+				{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/assignments/reader"},
+				{PrincipalID: "owner", Role: v1beta1.AccessGrantRoleOwner},
+			},
+			others: []v1beta1.BucketAccessGrantStatus{
+				{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/Assignments/Reader"},
+				{PrincipalID: "contributor", Role: v1beta1.AccessGrantRoleContributor, RoleAssignmentID: "/assignments/contributor"},
+			},
+			expectedGrants: []v1beta1.BucketAccessGrantStatus{
+				{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/assignments/reader"},
+				{PrincipalID: "owner", Role: v1beta1.AccessGrantRoleOwner},
+				{PrincipalID: "contributor", Role: v1beta1.AccessGrantRoleContributor, RoleAssignmentID: "/assignments/contributor"},
+			},
+		},
+		{
+			name:    "case 2: assignments not made by the operator are dropped",
+			granted: []v1beta1.BucketAccessGrantStatus{{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/assignments/reader"}},
+			others:  []v1beta1.BucketAccessGrantStatus{{PrincipalID: "owner", Role: v1beta1.AccessGrantRoleOwner}},
+			expectedGrants: []v1beta1.BucketAccessGrantStatus{
+				{PrincipalID: "reader", Role: v1beta1.AccessGrantRoleReader, RoleAssignmentID: "/assignments/reader"},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			grants := mergeAccessGrants(tc.granted, tc.others)
+
+			if !cmp.Equal(grants, tc.expectedGrants) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedGrants, grants))
+			}
+		})
+	}
+}
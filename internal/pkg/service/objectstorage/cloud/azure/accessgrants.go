@@ -0,0 +1,142 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// accessGrantRoleIDs are the built-in roles granted by the roles of access grants.
+var accessGrantRoleIDs = map[v1beta1.AccessGrantRole]string{
+	v1beta1.AccessGrantRoleReader:      storageBlobDataReaderRoleID,
+	v1beta1.AccessGrantRoleContributor: storageBlobDataContributorRoleID,
+	v1beta1.AccessGrantRoleOwner:       storageBlobDataOwnerRoleID,
+}
+
+// configureAccessGrants assigns the roles of the access grants on the container, deletes the role assignments of the
+// grants that were removed and reports the result in the AccessGrantsReady condition.
+func (s AzureObjectStorageAdapter) configureAccessGrants(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if len(bucket.Spec.AccessGrants) == 0 && len(bucket.Status.AccessGrants) == 0 {
+		bucket.RemoveCondition(v1beta1.ConditionAccessGrantsReady)
+		return nil
+	}
+
+	err := s.reconcileAccessGrants(ctx, bucket)
+	if err != nil {
+		bucket.MarkConditionFalse(v1beta1.ConditionAccessGrantsReady, v1beta1.ReasonAccessGrantsFailed, err.Error())
+		return err
+	}
+
+	if len(bucket.Spec.AccessGrants) == 0 {
+		bucket.RemoveCondition(v1beta1.ConditionAccessGrantsReady)
+	} else {
+		bucket.MarkConditionTrue(v1beta1.ConditionAccessGrantsReady, v1beta1.ReasonAccessGrantsConfigured, fmt.Sprintf("%d access grants configured", len(bucket.Spec.AccessGrants)))
+	}
+	return nil
+}
+
+// reconcileAccessGrants records in the status the role assignments made for the access grants of the spec. Only the
+// assignments recorded in the status are ever deleted, the assignments made outside of the Bucket are left untouched.
+func (s AzureObjectStorageAdapter) reconcileAccessGrants(ctx context.Context, bucket *v1beta1.Bucket) error {
+	scope := containerScope(s.cluster, bucket)
+	previous := bucket.Status.AccessGrants
+
+	var granted []v1beta1.BucketAccessGrantStatus
+	desired := map[string]bool{}
+	for _, grant := range bucket.Spec.AccessGrants {
+		status, err := s.assignAccessGrantRole(ctx, bucket, scope, grant)
+		if err != nil {
+			// The assignments made so far must be recorded so that they get deleted if the grant is removed meanwhile.
+			bucket.Status.AccessGrants = mergeAccessGrants(granted, previous)
+			return err
+		}
+		granted = append(granted, status)
+		desired[strings.ToLower(status.RoleAssignmentID)] = true
+	}
+
+	for i, grant := range previous {
+		if grant.RoleAssignmentID == "" || desired[strings.ToLower(grant.RoleAssignmentID)] {
+			continue
+		}
+		_, err := s.roleAssignmentsClient.DeleteByID(ctx, grant.RoleAssignmentID, nil)
+		if err != nil && !isNotFound(err) {
+			bucket.Status.AccessGrants = mergeAccessGrants(granted, previous[i:])
+			return fmt.Errorf("failed to delete role assignment %s of principal %s on container %s: %w", grant.RoleAssignmentID, grant.PrincipalID, bucket.Spec.Name, err)
+		}
+		s.logger.Info(fmt.Sprintf("role %s of principal %s on container %s revoked", grant.Role, grant.PrincipalID, bucket.Spec.Name))
+	}
+
+	bucket.Status.AccessGrants = granted
+	return nil
+}
+
+// assignAccessGrantRole assigns the role of the access grant to its principal on the container. The name of the
+// assignment is derived from the grant, so assigning it again is a no-op.
+func (s AzureObjectStorageAdapter) assignAccessGrantRole(ctx context.Context, bucket *v1beta1.Bucket, scope string, grant v1beta1.BucketAccessGrant) (v1beta1.BucketAccessGrantStatus, error) {
+	status := v1beta1.BucketAccessGrantStatus{PrincipalID: grant.PrincipalID, Role: grant.Role}
+	roleDefinitionID := roleDefinitionID(s.cluster, accessGrantRoleIDs[grant.Role])
+	name := roleAssignmentName(scope, grant.PrincipalID, roleDefinitionID)
+
+	response, err := s.roleAssignmentsClient.Create(
+		ctx,
+		scope,
+		name,
+		armauthorization.RoleAssignmentCreateParameters{
+			Properties: &armauthorization.RoleAssignmentProperties{
+				PrincipalID:      to.Ptr(grant.PrincipalID),
+				RoleDefinitionID: to.Ptr(roleDefinitionID),
+				PrincipalType:    to.Ptr(armauthorization.PrincipalType(grant.PrincipalType)),
+				Description:      to.Ptr(fmt.Sprintf("Granted by Bucket %s", getBucketOwner(bucket))),
+			},
+		},
+		nil,
+	)
+	if isRoleAssignmentExists(err) {
+		s.logger.Info(fmt.Sprintf("principal %s already has the role %s on container %s", grant.PrincipalID, grant.Role, bucket.Spec.Name))
+		return status, nil
+	} else if err != nil {
+		return status, fmt.Errorf("failed to grant role %s to principal %s on container %s: %w", grant.Role, grant.PrincipalID, bucket.Spec.Name, err)
+	}
+
+	status.RoleAssignmentID = fmt.Sprintf("%s/providers/Microsoft.Authorization/roleAssignments/%s", scope, name)
+	if response.ID != nil {
+		status.RoleAssignmentID = *response.ID
+	}
+	return status, nil
+}
+
+// mergeAccessGrants returns the granted role assignments, followed by the other ones that may still exist.
+func mergeAccessGrants(granted []v1beta1.BucketAccessGrantStatus, others []v1beta1.BucketAccessGrantStatus) []v1beta1.BucketAccessGrantStatus {
+	merged := append([]v1beta1.BucketAccessGrantStatus(nil), granted...)
+	recorded := map[string]bool{}
+	for _, grant := range granted {
+		recorded[strings.ToLower(grant.RoleAssignmentID)] = true
+	}
+	for _, grant := range others {
+		if grant.RoleAssignmentID != "" && !recorded[strings.ToLower(grant.RoleAssignmentID)] {
+			merged = append(merged, grant)
+		}
+	}
+	return merged
+}
+
+// DeleteAccessGrants deletes the role assignments recorded for the access grants of the bucket. Role assignments are not
+// deleted along with their scope, so they must be deleted before the storage account.
+func (s AzureObjectStorageAdapter) DeleteAccessGrants(ctx context.Context, bucket *v1beta1.Bucket) error {
+	for _, grant := range bucket.Status.AccessGrants {
+		if grant.RoleAssignmentID == "" {
+			continue
+		}
+		_, err := s.roleAssignmentsClient.DeleteByID(ctx, grant.RoleAssignmentID, nil)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete role assignment %s of principal %s on container %s: %w", grant.RoleAssignmentID, grant.PrincipalID, bucket.Spec.Name, err)
+		}
+	}
+	bucket.Status.AccessGrants = nil
+	return nil
+}
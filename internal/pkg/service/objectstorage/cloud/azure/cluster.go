@@ -3,6 +3,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -12,6 +13,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/flags"
 )
@@ -30,6 +32,12 @@ const (
 	KindClusterIdentity    = "AzureClusterIdentity"
 	VersionClusterIdentity = "v1beta1"
 	ClientSecretKeyName    = "clientSecret"
+	// OIDCIssuersAnnotation lists the comma-separated issuer URLs of the OIDC providers the managed identities of
+	// access roles trust on the AzureCluster of the management cluster.
+	OIDCIssuersAnnotation = "objectstorage.giantswarm.io/oidc-issuers"
+	// OIDCAudiencesAnnotation lists the comma-separated audiences the tokens of the OIDC providers of the
+	// OIDCIssuersAnnotation must be issued for. Defaults to api://AzureADTokenExchange.
+	OIDCAudiencesAnnotation = "objectstorage.giantswarm.io/oidc-audiences"
 )
 
 func (c AzureClusterGetter) GetCluster(ctx context.Context) (cluster.Cluster, error) {
@@ -127,12 +135,13 @@ func (c AzureClusterGetter) GetCluster(ctx context.Context) (cluster.Cluster, er
 	}
 
 	return AzureCluster{
-		Client:     c.Client,
-		Name:       c.ManagementCluster.Name,
-		Namespace:  c.ManagementCluster.Namespace,
-		BaseDomain: c.ManagementCluster.BaseDomain,
-		Region:     c.ManagementCluster.Region,
-		Tags:       clusterTags,
+		Client:        c.Client,
+		Name:          c.ManagementCluster.Name,
+		Namespace:     c.ManagementCluster.Namespace,
+		BaseDomain:    c.ManagementCluster.BaseDomain,
+		Region:        c.ManagementCluster.Region,
+		Tags:          clusterTags,
+		OIDCProviders: oidcProvidersFromAnnotations(cluster.GetAnnotations()),
 		Credentials: AzureCredentials{
 			ResourceGroup:  resourceGroup,
 			SubscriptionID: subscriptionID,
@@ -144,6 +153,27 @@ func (c AzureClusterGetter) GetCluster(ctx context.Context) (cluster.Cluster, er
 	}, nil
 }
 
+// oidcProvidersFromAnnotations returns the OIDC providers listed in the annotations of the AzureCluster.
+func oidcProvidersFromAnnotations(annotations map[string]string) []v1beta1.OIDCProvider {
+	audiences := splitAnnotation(annotations[OIDCAudiencesAnnotation])
+
+	var providers []v1beta1.OIDCProvider
+	for _, issuer := range splitAnnotation(annotations[OIDCIssuersAnnotation]) {
+		providers = append(providers, v1beta1.OIDCProvider{Issuer: issuer, Audiences: audiences})
+	}
+	return providers
+}
+
+func splitAnnotation(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 func (c AzureClusterGetter) getClusterCR(ctx context.Context) (*unstructured.Unstructured, error) {
 	cluster := &unstructured.Unstructured{}
 	cluster.SetGroupVersionKind(schema.GroupVersionKind{
@@ -175,13 +205,15 @@ func (c AzureClusterGetter) getClusterCRIdentity(ctx context.Context, clusterIde
 
 // AzureCluster implements Cluster Interface with Azure data
 type AzureCluster struct {
-	Client      client.Client
-	Name        string
-	Namespace   string
-	BaseDomain  string
-	Region      string
-	Tags        map[string]string
-	Credentials AzureCredentials
+	Client     client.Client
+	Name       string
+	Namespace  string
+	BaseDomain string
+	Region     string
+	Tags       map[string]string
+	// OIDCProviders are trusted by the managed identities of access roles that do not list their own.
+	OIDCProviders []v1beta1.OIDCProvider
+	Credentials   AzureCredentials
 }
 
 type AzureCredentials struct {
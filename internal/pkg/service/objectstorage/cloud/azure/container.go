@@ -10,10 +10,10 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage/v3"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 )
 
-func (s AzureObjectStorageAdapter) existsContainer(ctx context.Context, bucket *v1alpha1.Bucket, storageAccountName string) (bool, error) {
+func (s AzureObjectStorageAdapter) existsContainer(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) (bool, error) {
 	// Check BlobContainer name exists in StorageAccount
 	_, err := s.blobContainerClient.Get(
 		ctx,
@@ -36,7 +36,7 @@ func (s AzureObjectStorageAdapter) existsContainer(ctx context.Context, bucket *
 	return true, nil
 }
 
-func (s AzureObjectStorageAdapter) upsertContainer(ctx context.Context, bucket *v1alpha1.Bucket, storageAccountName string) error {
+func (s AzureObjectStorageAdapter) upsertContainer(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) error {
 	existsContainer, err := s.existsContainer(ctx, bucket, storageAccountName)
 	if err != nil {
 		return fmt.Errorf("failed to check if container %s exists in storage account %s: %w", bucket.Spec.Name, storageAccountName, err)
@@ -81,3 +81,94 @@ func (s AzureObjectStorageAdapter) upsertContainer(ctx context.Context, bucket *
 
 	return nil
 }
+
+// setImmutabilityPolicy applies the time-based retention policy of the container and records the current retention in
+// the status. Locked policies can only be extended, and policies are left untouched when the bucket does not set any.
+func (s AzureObjectStorageAdapter) setImmutabilityPolicy(ctx context.Context, bucket *v1beta1.Bucket) error {
+	storageAccountName := SanitizeStorageAccountName(bucket.Spec.Name)
+	policy, err := s.getImmutabilityPolicy(ctx, bucket, storageAccountName)
+	if err != nil {
+		return err
+	}
+
+	objectLock := bucket.Spec.ObjectLock
+	if objectLock == nil {
+		bucket.Status.ObjectLock = immutabilityPolicyStatus(policy)
+		return nil
+	}
+
+	parameters := &armstorage.ImmutabilityPolicy{
+		Properties: &armstorage.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: to.Ptr(objectLock.RetentionDays),
+		},
+	}
+	switch {
+	case isLocked(policy):
+		if *policy.Properties.ImmutabilityPeriodSinceCreationInDays < objectLock.RetentionDays {
+			response, err := s.blobContainerClient.ExtendImmutabilityPolicy(ctx, s.cluster.GetResourceGroup(), storageAccountName, bucket.Spec.Name, *policy.Etag,
+				&armstorage.BlobContainersClientExtendImmutabilityPolicyOptions{Parameters: parameters})
+			if err != nil {
+				return fmt.Errorf("failed to extend immutability policy of container %s in storage account %s: %w", bucket.Spec.Name, storageAccountName, err)
+			}
+			policy = &response.ImmutabilityPolicy
+		}
+	default:
+		if policy == nil || *policy.Properties.ImmutabilityPeriodSinceCreationInDays != objectLock.RetentionDays {
+			options := &armstorage.BlobContainersClientCreateOrUpdateImmutabilityPolicyOptions{Parameters: parameters}
+			if policy != nil {
+				options.IfMatch = policy.Etag
+			}
+			response, err := s.blobContainerClient.CreateOrUpdateImmutabilityPolicy(ctx, s.cluster.GetResourceGroup(), storageAccountName, bucket.Spec.Name, options)
+			if err != nil {
+				return fmt.Errorf("failed to set immutability policy of container %s in storage account %s: %w", bucket.Spec.Name, storageAccountName, err)
+			}
+			policy = &response.ImmutabilityPolicy
+		}
+		if objectLock.Mode == v1beta1.ObjectLockModeLocked {
+			response, err := s.blobContainerClient.LockImmutabilityPolicy(ctx, s.cluster.GetResourceGroup(), storageAccountName, bucket.Spec.Name, *policy.Etag, nil)
+			if err != nil {
+				return fmt.Errorf("failed to lock immutability policy of container %s in storage account %s: %w", bucket.Spec.Name, storageAccountName, err)
+			}
+			policy = &response.ImmutabilityPolicy
+		}
+	}
+
+	bucket.Status.ObjectLock = immutabilityPolicyStatus(policy)
+	return nil
+}
+
+// getImmutabilityPolicy returns the time-based retention policy of the container, or nil if there is none.
+func (s AzureObjectStorageAdapter) getImmutabilityPolicy(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) (*armstorage.ImmutabilityPolicy, error) {
+	response, err := s.blobContainerClient.GetImmutabilityPolicy(ctx, s.cluster.GetResourceGroup(), storageAccountName, bucket.Spec.Name, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get immutability policy of container %s in storage account %s: %w", bucket.Spec.Name, storageAccountName, err)
+	}
+	policy := &response.ImmutabilityPolicy
+	if policy.Etag == nil || policy.Properties == nil || policy.Properties.ImmutabilityPeriodSinceCreationInDays == nil {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+func isLocked(policy *armstorage.ImmutabilityPolicy) bool {
+	return policy != nil && policy.Properties.State != nil && *policy.Properties.State == armstorage.ImmutabilityPolicyStateLocked
+}
+
+// immutabilityPolicyStatus returns the retention of the container, or nil if it has no immutability policy.
+func immutabilityPolicyStatus(policy *armstorage.ImmutabilityPolicy) *v1beta1.BucketObjectLockStatus {
+	if policy == nil || policy.Properties == nil {
+		return nil
+	}
+	status := &v1beta1.BucketObjectLockStatus{Mode: v1beta1.ObjectLockModeUnlocked}
+	if isLocked(policy) {
+		status.Mode = v1beta1.ObjectLockModeLocked
+	}
+	if policy.Properties.ImmutabilityPeriodSinceCreationInDays != nil {
+		status.RetentionDays = *policy.Properties.ImmutabilityPeriodSinceCreationInDays
+	}
+	return status
+}
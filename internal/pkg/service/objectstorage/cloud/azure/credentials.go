@@ -0,0 +1,213 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
+)
+
+const (
+	// Keys of the connection Secret holding the SAS token of the container and its expiry time.
+	SASTokenKey  = "sasToken"
+	SASExpiryKey = "sasExpiry"
+
+	// userDelegationKeyClockSkew backdates the start of user delegation keys, which must not start in the future of
+	// the storage service.
+	userDelegationKeyClockSkew = 5 * time.Minute
+)
+
+// connectionCredentials returns the credentials published in the connection Secret of the bucket: the key of the
+// storage account, or a SAS token of the container that is renewed when a third of its lifetime is left.
+func (s AzureObjectStorageAdapter) connectionCredentials(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) (map[string]string, error) {
+	credentials := bucket.AzureCredentials()
+	if credentials.Type == v1beta1.AzureCredentialsTypeAccountKey {
+		bucket.Status.Credentials = nil
+		accountKey, err := s.getAccountKey(ctx, storageAccountName)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"accountKey": accountKey}, nil
+	}
+
+	now := time.Now()
+	token, err := s.publishedSASToken(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !isSASTokenReusable(token, bucket.Status.Credentials, credentials, now) {
+		token, err = s.newSASToken(ctx, bucket, storageAccountName, credentials, now)
+		if err != nil {
+			return nil, err
+		}
+		s.logger.Info(fmt.Sprintf("issued %s token for container %s", credentials.Type, bucket.Spec.Name))
+	}
+
+	expiry := sasTokenExpiry(token)
+	bucket.Status.Credentials = &v1beta1.BucketCredentialsStatus{
+		Type:      credentials.Type,
+		ExpiresAt: metav1.NewTime(expiry),
+		RenewAt:   metav1.NewTime(sasTokenRenewAt(expiry, credentials.Lifetime.Duration)),
+	}
+	return map[string]string{
+		SASTokenKey:  token,
+		SASExpiryKey: expiry.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// getAccountKey returns the value of key1 of the storage account.
+func (s AzureObjectStorageAdapter) getAccountKey(ctx context.Context, storageAccountName string) (string, error) {
+	listKeys, err := s.storageAccountClient.ListKeys(
+		ctx,
+		s.cluster.GetResourceGroup(),
+		storageAccountName,
+		nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve access keys from storage account %s: %w", storageAccountName, err)
+	}
+
+	for _, k := range listKeys.Keys {
+		if k.KeyName != nil && *k.KeyName == "key1" && k.Value != nil {
+			return *k.Value, nil
+		}
+	}
+	return "", fmt.Errorf("unable to retrieve access keys 'key1' from storage account %s", storageAccountName)
+}
+
+// publishedSASToken returns the SAS token of the connection Secret of the bucket, if any.
+func (s AzureObjectStorageAdapter) publishedSASToken(ctx context.Context, bucket *v1beta1.Bucket) (string, error) {
+	secret, err := objectstorage.GetConnectionSecret(ctx, s.client, bucket)
+	if err != nil || secret == nil {
+		return "", err
+	}
+	return string(secret.Data[SASTokenKey]), nil
+}
+
+// newSASToken returns a new SAS token of the container, restricted to HTTPS and to the access level of the bucket.
+func (s AzureObjectStorageAdapter) newSASToken(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string, credentials v1beta1.AzureBucketCredentials, now time.Time) (string, error) {
+	// The expiry is truncated to the second like in the token, so that it can be compared with the status.
+	expiry := now.Add(credentials.Lifetime.Duration).UTC().Truncate(time.Second)
+	values := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		ExpiryTime:    expiry,
+		Permissions:   sasPermissions(credentials.AccessLevel),
+		ContainerName: bucket.Spec.Name,
+	}
+
+	var parameters sas.QueryParameters
+	switch credentials.Type {
+	case v1beta1.AzureCredentialsTypeServiceSAS:
+		accountKey, err := s.getAccountKey(ctx, storageAccountName)
+		if err != nil {
+			return "", err
+		}
+		sharedKeyCredential, err := service.NewSharedKeyCredential(storageAccountName, accountKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to create shared key credential of storage account %s: %w", storageAccountName, err)
+		}
+		parameters, err = values.SignWithSharedKey(sharedKeyCredential)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign service SAS of container %s: %w", bucket.Spec.Name, err)
+		}
+	case v1beta1.AzureCredentialsTypeUserDelegationSAS:
+		userDelegationCredential, err := s.getUserDelegationCredential(ctx, storageAccountName, now, expiry)
+		if err != nil {
+			return "", err
+		}
+		parameters, err = values.SignWithUserDelegation(userDelegationCredential)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign user delegation SAS of container %s: %w", bucket.Spec.Name, err)
+		}
+	default:
+		return "", fmt.Errorf("unsupported credentials type %s for bucket %s", credentials.Type, bucket.Spec.Name)
+	}
+	return parameters.Encode(), nil
+}
+
+// getUserDelegationCredential returns a user delegation key of the identity of the operator, valid until the expiry
+// of the SAS token it signs.
+func (s AzureObjectStorageAdapter) getUserDelegationCredential(ctx context.Context, storageAccountName string, now time.Time, expiry time.Time) (*service.UserDelegationCredential, error) {
+	storageAccount, err := s.storageAccountClient.GetProperties(ctx, s.cluster.GetResourceGroup(), storageAccountName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage account %s: %w", storageAccountName, err)
+	}
+	if storageAccount.Properties == nil || storageAccount.Properties.PrimaryEndpoints == nil || storageAccount.Properties.PrimaryEndpoints.Blob == nil {
+		return nil, fmt.Errorf("storage account %s has no blob endpoint", storageAccountName)
+	}
+
+	serviceClient, err := service.NewClient(*storageAccount.Properties.PrimaryEndpoints.Blob, s.credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob service client of storage account %s: %w", storageAccountName, err)
+	}
+	userDelegationCredential, err := serviceClient.GetUserDelegationCredential(ctx, service.KeyInfo{
+		Start:  to.Ptr(now.Add(-userDelegationKeyClockSkew).UTC().Format(sas.TimeFormat)),
+		Expiry: to.Ptr(expiry.UTC().Format(sas.TimeFormat)),
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user delegation key of storage account %s: %w", storageAccountName, err)
+	}
+	return userDelegationCredential, nil
+}
+
+// sasPermissions returns the permissions of the SAS token of a container matching the access level.
+func sasPermissions(accessLevel v1beta1.AccessLevel) string {
+	permissions := sas.ContainerPermissions{Read: true, List: true}
+	switch accessLevel {
+	case v1beta1.AccessLevelReadOnly:
+	case v1beta1.AccessLevelReadWriteNoDelete:
+		permissions.Add, permissions.Create, permissions.Write = true, true, true
+	case v1beta1.AccessLevelAdmin:
+		permissions.Add, permissions.Create, permissions.Write, permissions.Delete = true, true, true, true
+		permissions.DeletePreviousVersion, permissions.Tag, permissions.FilterByTags, permissions.SetImmutabilityPolicy = true, true, true, true
+	default:
+		permissions.Add, permissions.Create, permissions.Write, permissions.Delete = true, true, true, true
+	}
+	return permissions.String()
+}
+
+// sasTokenExpiry returns the expiry time of the SAS token, which is zero when the token cannot be parsed.
+func sasTokenExpiry(token string) time.Time {
+	values, err := url.ParseQuery(token)
+	if err != nil {
+		return time.Time{}
+	}
+	parameters := sas.NewQueryParameters(values, false)
+	return parameters.ExpiryTime()
+}
+
+// sasTokenRenewAt returns the time a SAS token is renewed: when a third of its lifetime is left.
+func sasTokenRenewAt(expiry time.Time, lifetime time.Duration) time.Time {
+	return expiry.Add(-lifetime / 3)
+}
+
+// isSASTokenReusable returns true if the published SAS token can be published again: it was issued by the operator
+// for the same type of credentials and the same access level, it is not due for renewal, and it does not outlive a
+// shortened lifetime.
+func isSASTokenReusable(token string, status *v1beta1.BucketCredentialsStatus, credentials v1beta1.AzureBucketCredentials, now time.Time) bool {
+	if token == "" || status == nil || status.Type != credentials.Type {
+		return false
+	}
+	values, err := url.ParseQuery(token)
+	if err != nil {
+		return false
+	}
+	parameters := sas.NewQueryParameters(values, false)
+	expiry := parameters.ExpiryTime()
+	if expiry.IsZero() || !expiry.Equal(status.ExpiresAt.Time) {
+		return false
+	}
+	if parameters.Resource() != "c" || parameters.Permissions() != sasPermissions(credentials.AccessLevel) {
+		return false
+	}
+	lifetime := credentials.Lifetime.Duration
+	return now.Before(sasTokenRenewAt(expiry, lifetime)) && !expiry.After(now.Add(lifetime))
+}
@@ -0,0 +1,172 @@
+package azure
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_SASPermissions(t *testing.T) {
+	testCases := []struct {
+		name                string
+		accessLevel         v1beta1.AccessLevel
+		expectedPermissions string
+	}{
+		{
+			name:                "case 0: default access level",
+			expectedPermissions: "racwdl",
+		},
+		{
+			name:                "case 1: read-only access",
+			accessLevel:         v1beta1.AccessLevelReadOnly,
+			expectedPermissions: "rl",
+		},
+		{
+			name:                "case 2: write without delete",
+			accessLevel:         v1beta1.AccessLevelReadWriteNoDelete,
+			expectedPermissions: "racwl",
+		},
+		{
+			name:                "case 3: admin access",
+			accessLevel:         v1beta1.AccessLevelAdmin,
+			expectedPermissions: "racwdxltfi",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			permissions := sasPermissions(tc.accessLevel)
+
+			if !cmp.Equal(permissions, tc.expectedPermissions) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedPermissions, permissions))
+			}
+		})
+	}
+}
+
+func Test_IsSASTokenReusable(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	expiry := now.Add(12 * time.Hour)
+	credentials := v1beta1.AzureBucketCredentials{
+		Type:        v1beta1.AzureCredentialsTypeServiceSAS,
+		AccessLevel: v1beta1.AccessLevelReadWrite,
+		Lifetime:    &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	status := &v1beta1.BucketCredentialsStatus{
+		Type:      v1beta1.AzureCredentialsTypeServiceSAS,
+		ExpiresAt: metav1.NewTime(expiry),
+		RenewAt:   metav1.NewTime(expiry.Add(-8 * time.Hour)),
+	}
+
+	testCases := []struct {
+		name             string
+		token            string
+		status           *v1beta1.BucketCredentialsStatus
+		credentials      v1beta1.AzureBucketCredentials
+		now              time.Time
+		expectedReusable bool
+	}{
+		{
+			name:             "case 0: token issued by the operator and not due for renewal",
+			token:            newTestSASToken(t, expiry, "racwdl"),
+			status:           status,
+			credentials:      credentials,
+			now:              now,
+			expectedReusable: true,
+		},
+		{
+			name:        "case 1: no token published yet",
+			status:      status,
+			credentials: credentials,
+			now:         now,
+		},
+		{
+			name:        "case 2: token due for renewal",
+			token:       newTestSASToken(t, expiry, "racwdl"),
+			status:      status,
+			credentials: credentials,
+			now:         expiry.Add(-7 * time.Hour),
+		},
+		{
+			name:        "case 3: token with another expiry than the status",
+			token:       newTestSASToken(t, expiry.Add(time.Hour), "racwdl"),
+			status:      status,
+			credentials: credentials,
+			now:         now,
+		},
+		{
+			name:   "case 4: access level changed",
+			token:  newTestSASToken(t, expiry, "racwdl"),
+			status: status,
+			credentials: v1beta1.AzureBucketCredentials{
+				Type:        v1beta1.AzureCredentialsTypeServiceSAS,
+				AccessLevel: v1beta1.AccessLevelReadOnly,
+				Lifetime:    &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			now: now,
+		},
+		{
+			name:   "case 5: type of credentials changed",
+			token:  newTestSASToken(t, expiry, "racwdl"),
+			status: status,
+			credentials: v1beta1.AzureBucketCredentials{
+				Type:        v1beta1.AzureCredentialsTypeUserDelegationSAS,
+				AccessLevel: v1beta1.AccessLevelReadWrite,
+				Lifetime:    &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			now: now,
+		},
+		{
+			name:   "case 6: lifetime shortened below the remaining lifetime of the token",
+			token:  newTestSASToken(t, expiry, "racwdl"),
+			status: status,
+			credentials: v1beta1.AzureBucketCredentials{
+				Type:        v1beta1.AzureCredentialsTypeServiceSAS,
+				AccessLevel: v1beta1.AccessLevelReadWrite,
+				Lifetime:    &metav1.Duration{Duration: 6 * time.Hour},
+			},
+			now: now,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			reusable := isSASTokenReusable(tc.token, tc.status, tc.credentials, tc.now)
+
+			if reusable != tc.expectedReusable {
+				t.Fatalf("expected reusable to be %t, got %t", tc.expectedReusable, reusable)
+			}
+		})
+	}
+}
+
+func newTestSASToken(t *testing.T, expiry time.Time, permissions string) string {
+	t.Helper()
+
+	credential, err := service.NewSharedKeyCredential("giantswarmglippyloki", base64.StdEncoding.EncodeToString([]byte("key1")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parameters, err := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		ExpiryTime:    expiry,
+		Permissions:   permissions,
+		ContainerName: "giantswarm-glippy-loki",
+	}.SignWithSharedKey(credential)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return parameters.Encode()
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v9"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 )
 
 const (
@@ -19,7 +19,7 @@ const (
 	subnetID      = vnetID + "/subnets/%s"
 )
 
-func (s AzureObjectStorageAdapter) upsertPrivateEndpointARecords(ctx context.Context, bucket *v1alpha1.Bucket, privateEndpoint *armnetwork.PrivateEndpoint, storageAccountName string) (*armprivatedns.RecordSet, error) {
+func (s AzureObjectStorageAdapter) upsertPrivateEndpointARecords(ctx context.Context, bucket *v1beta1.Bucket, privateEndpoint *armnetwork.PrivateEndpoint, storageAccountName string) (*armprivatedns.RecordSet, error) {
 	s.logger.Info("Creating A record for private endpoint", "private-endpoint", *privateEndpoint.Name)
 
 	ips := make([]string, 0)
@@ -59,7 +59,7 @@ func (s AzureObjectStorageAdapter) upsertPrivateEndpointARecords(ctx context.Con
 	return &resp.RecordSet, nil
 }
 
-func (s AzureObjectStorageAdapter) upsertPrivateEndpoint(ctx context.Context, bucket *v1alpha1.Bucket, storageAccountName string) (*armnetwork.PrivateEndpoint, error) {
+func (s AzureObjectStorageAdapter) upsertPrivateEndpoint(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) (*armnetwork.PrivateEndpoint, error) {
 	// Create or Update Private endpoint
 	pollersResp, err := s.privateEndpointsClient.BeginCreateOrUpdate(
 		ctx,
@@ -6,6 +6,8 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/msi/armmsi"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v9"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage/v3"
@@ -20,44 +22,40 @@ type AzureObjectStorageService struct {
 }
 
 func (s AzureObjectStorageService) NewAccessRoleService(ctx context.Context, logger logr.Logger, cluster cluster.Cluster) (objectstorage.AccessRoleService, error) {
-	return NewAzureAccessService(), nil
-}
+	cred, azureCredentials, err := newTokenCredential(cluster)
+	if err != nil {
+		return nil, err
+	}
 
-func (s AzureObjectStorageService) NewObjectStorageService(ctx context.Context, logger logr.Logger, cluster cluster.Cluster, client client.Client) (objectstorage.ObjectStorageService, error) {
-	var cred azcore.TokenCredential
-	var err error
+	var msiClientFactory *armmsi.ClientFactory
+	msiClientFactory, err = armmsi.NewClientFactory(azureCredentials.SubscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed identity client factory for cluster %s with subscription ID %s: %w", cluster.GetName(), azureCredentials.SubscriptionID, err)
+	}
 
-	azureCredentials, ok := cluster.GetCredentials().(AzureCredentials)
+	var authorizationClientFactory *armauthorization.ClientFactory
+	authorizationClientFactory, err = armauthorization.NewClientFactory(azureCredentials.SubscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization client factory for cluster %s with subscription ID %s: %w", cluster.GetName(), azureCredentials.SubscriptionID, err)
+	}
+
+	azurecluster, ok := cluster.(AzureCluster)
 	if !ok {
-		return nil, fmt.Errorf("failed to cast cluster credentials to Azure credentials for cluster %s", cluster.GetName())
+		return nil, fmt.Errorf("failed to cast cluster to Azure cluster for cluster %s", cluster.GetName())
 	}
-	switch azureCredentials.TypeIdentity {
-	case "UserAssignedMSI":
-		cred, err = azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{
-			ID: azidentity.ClientID(azureCredentials.ClientID),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create managed identity credential for cluster %s with client ID %s: %w", cluster.GetName(), azureCredentials.ClientID, err)
-		}
-	case "ManualServicePrincipal":
-		cred, err = azidentity.NewClientSecretCredential(
-			azureCredentials.TenantID,
-			azureCredentials.ClientID,
-			string(azureCredentials.SecretRef.Data[ClientSecretKeyName]),
-			nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create client secret credential for cluster %s with tenant ID %s: %w", cluster.GetName(), azureCredentials.TenantID, err)
-		}
-	case "WorkloadIdentity":
-		cred, err = azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
-			TenantID: azureCredentials.TenantID,
-			ClientID: azureCredentials.ClientID,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create workload identity credential for cluster %s with tenant ID %s: %w", cluster.GetName(), azureCredentials.TenantID, err)
-		}
-	default:
-		return nil, fmt.Errorf("unknown identity type %s for cluster %s", azureCredentials.TypeIdentity, cluster.GetName())
+	return NewAzureAccessService(
+		msiClientFactory.NewUserAssignedIdentitiesClient(),
+		msiClientFactory.NewFederatedIdentityCredentialsClient(),
+		authorizationClientFactory.NewRoleAssignmentsClient(),
+		logger,
+		azurecluster,
+	), nil
+}
+
+func (s AzureObjectStorageService) NewObjectStorageService(ctx context.Context, logger logr.Logger, cluster cluster.Cluster, client client.Client) (objectstorage.ObjectStorageService, error) {
+	cred, azureCredentials, err := newTokenCredential(cluster)
+	if err != nil {
+		return nil, err
 	}
 
 	var storageClientFactory *armstorage.ClientFactory
@@ -78,6 +76,12 @@ func (s AzureObjectStorageService) NewObjectStorageService(ctx context.Context,
 		return nil, fmt.Errorf("failed to create private DNS client factory for cluster %s with subscription ID %s: %w", cluster.GetName(), azureCredentials.SubscriptionID, err)
 	}
 
+	var authorizationClientFactory *armauthorization.ClientFactory
+	authorizationClientFactory, err = armauthorization.NewClientFactory(azureCredentials.SubscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization client factory for cluster %s with subscription ID %s: %w", cluster.GetName(), azureCredentials.SubscriptionID, err)
+	}
+
 	azurecluster, ok := cluster.(AzureCluster)
 	if !ok {
 		return nil, fmt.Errorf("failed to cast cluster to Azure cluster for cluster %s", cluster.GetName())
@@ -86,12 +90,55 @@ func (s AzureObjectStorageService) NewObjectStorageService(ctx context.Context,
 		storageClientFactory.NewAccountsClient(),
 		storageClientFactory.NewBlobContainersClient(),
 		storageClientFactory.NewManagementPoliciesClient(),
+		storageClientFactory.NewBlobServicesClient(),
 		networkClientFactory.NewPrivateEndpointsClient(),
 		privateZonesClientFactory.NewPrivateZonesClient(),
 		privateZonesClientFactory.NewRecordSetsClient(),
 		privateZonesClientFactory.NewVirtualNetworkLinksClient(),
+		authorizationClientFactory.NewRoleAssignmentsClient(),
+		cred,
 		logger,
 		azurecluster,
 		client,
 	), nil
 }
+
+// newTokenCredential returns the credential of the identity of the management cluster.
+func newTokenCredential(cluster cluster.Cluster) (azcore.TokenCredential, AzureCredentials, error) {
+	var cred azcore.TokenCredential
+	var err error
+
+	azureCredentials, ok := cluster.GetCredentials().(AzureCredentials)
+	if !ok {
+		return nil, AzureCredentials{}, fmt.Errorf("failed to cast cluster credentials to Azure credentials for cluster %s", cluster.GetName())
+	}
+	switch azureCredentials.TypeIdentity {
+	case "UserAssignedMSI":
+		cred, err = azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{
+			ID: azidentity.ClientID(azureCredentials.ClientID),
+		})
+		if err != nil {
+			return nil, AzureCredentials{}, fmt.Errorf("failed to create managed identity credential for cluster %s with client ID %s: %w", cluster.GetName(), azureCredentials.ClientID, err)
+		}
+	case "ManualServicePrincipal":
+		cred, err = azidentity.NewClientSecretCredential(
+			azureCredentials.TenantID,
+			azureCredentials.ClientID,
+			string(azureCredentials.SecretRef.Data[ClientSecretKeyName]),
+			nil)
+		if err != nil {
+			return nil, AzureCredentials{}, fmt.Errorf("failed to create client secret credential for cluster %s with tenant ID %s: %w", cluster.GetName(), azureCredentials.TenantID, err)
+		}
+	case "WorkloadIdentity":
+		cred, err = azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			TenantID: azureCredentials.TenantID,
+			ClientID: azureCredentials.ClientID,
+		})
+		if err != nil {
+			return nil, AzureCredentials{}, fmt.Errorf("failed to create workload identity credential for cluster %s with tenant ID %s: %w", cluster.GetName(), azureCredentials.TenantID, err)
+		}
+	default:
+		return nil, AzureCredentials{}, fmt.Errorf("unknown identity type %s for cluster %s", azureCredentials.TypeIdentity, cluster.GetName())
+	}
+	return cred, azureCredentials, nil
+}
@@ -3,7 +3,10 @@ package azure
 import (
 	"context"
 	"fmt"
+	"maps"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v9"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage/v3"
@@ -11,26 +14,31 @@ import (
 	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 )
 
 const (
 	LifecycleRuleName = "ExpirationLogging"
+	// UncommittedBlocksRetentionDays is the number of days after which Azure discards uncommitted blocks, the
+	// equivalent of incomplete multipart uploads. It cannot be configured.
+	UncommittedBlocksRetentionDays = 7
 )
 
 type AzureObjectStorageAdapter struct {
 	storageAccountClient      *armstorage.AccountsClient
 	blobContainerClient       *armstorage.BlobContainersClient
 	managementPoliciesClient  *armstorage.ManagementPoliciesClient
+	blobServicesClient        *armstorage.BlobServicesClient
 	privateEndpointsClient    *armnetwork.PrivateEndpointsClient
 	privateZonesClient        *armprivatedns.PrivateZonesClient
 	recordSetsClient          *armprivatedns.RecordSetsClient
 	virtualNetworkLinksClient *armprivatedns.VirtualNetworkLinksClient
+	roleAssignmentsClient     *armauthorization.RoleAssignmentsClient
+	credential                azcore.TokenCredential
 	logger                    logr.Logger
 	cluster                   AzureCluster
 	client                    client.Client
@@ -38,7 +46,7 @@ type AzureObjectStorageAdapter struct {
 
 // NewAzureStorageService creates a new instance of AzureObjectStorageAdapter.
 // It takes in the necessary parameters to initialize the adapter and returns the created instance.
-// The storageAccountClient, blobContainerClient, and managementPoliciesClient are clients for interacting with Azure storage resources.
+// The storageAccountClient, blobContainerClient, managementPoliciesClient, and blobServicesClient are clients for interacting with Azure storage resources.
 // The logger is used for logging purposes.
 // The cluster represents the Azure cluster.
 // The client is the Kubernetes client used for interacting with the Kubernetes API.
@@ -46,10 +54,13 @@ func NewAzureStorageService(
 	storageAccountClient *armstorage.AccountsClient,
 	blobContainerClient *armstorage.BlobContainersClient,
 	managementPoliciesClient *armstorage.ManagementPoliciesClient,
+	blobServicesClient *armstorage.BlobServicesClient,
 	privateEndpointsClient *armnetwork.PrivateEndpointsClient,
 	privateZonesClient *armprivatedns.PrivateZonesClient,
 	recordSetsClient *armprivatedns.RecordSetsClient,
 	virtualNetworkLinksClient *armprivatedns.VirtualNetworkLinksClient,
+	roleAssignmentsClient *armauthorization.RoleAssignmentsClient,
+	credential azcore.TokenCredential,
 	logger logr.Logger,
 	cluster AzureCluster,
 	client client.Client) AzureObjectStorageAdapter {
@@ -57,10 +68,13 @@ func NewAzureStorageService(
 		storageAccountClient:      storageAccountClient,
 		blobContainerClient:       blobContainerClient,
 		managementPoliciesClient:  managementPoliciesClient,
+		blobServicesClient:        blobServicesClient,
 		privateEndpointsClient:    privateEndpointsClient,
 		privateZonesClient:        privateZonesClient,
 		recordSetsClient:          recordSetsClient,
 		virtualNetworkLinksClient: virtualNetworkLinksClient,
+		roleAssignmentsClient:     roleAssignmentsClient,
+		credential:                credential,
 		logger:                    logger,
 		cluster:                   cluster,
 		client:                    client,
@@ -72,13 +86,13 @@ func NewAzureStorageService(
 // it means the bucket does not exist either, so it returns false.
 // If the storage account exists, it then checks if the BlobContainer with the specified name exists in the storage account.
 // If the BlobContainer does not exist, it returns false. Otherwise, it returns true.
-func (s AzureObjectStorageAdapter) ExistsBucket(ctx context.Context, bucket *v1alpha1.Bucket) (bool, error) {
-	storageAccountName := sanitizeStorageAccountName(bucket.Spec.Name)
+func (s AzureObjectStorageAdapter) ExistsBucket(ctx context.Context, bucket *v1beta1.Bucket) (bool, error) {
+	storageAccountName := SanitizeStorageAccountName(bucket.Spec.Name)
 
 	// Check if storage account exists on Azure
-	existsStorageAccount, err := s.existsStorageAccount(ctx, storageAccountName)
+	existsStorageAccount, err := s.existsStorageAccount(ctx, bucket, storageAccountName)
 	if err != nil {
-		return false, err
+		return existsStorageAccount, err
 	}
 	// If StorageAccount does not exists that means the bucket does not exists too, so we return false
 	if !existsStorageAccount {
@@ -88,6 +102,12 @@ func (s AzureObjectStorageAdapter) ExistsBucket(ctx context.Context, bucket *v1a
 	return s.existsContainer(ctx, bucket, storageAccountName)
 }
 
+// AdoptBucket records the tags of the existing storage account in the status of the bucket.
+// The settings of the storage account are left as they were, only its tags are managed by the operator.
+func (s AzureObjectStorageAdapter) AdoptBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return s.adoptStorageAccount(ctx, bucket, SanitizeStorageAccountName(bucket.Spec.Name))
+}
+
 // isManagementClusterPrivate checks if the management cluster is private by reading the cluster user-values CM
 func (s AzureObjectStorageAdapter) isManagementClusterPrivate(ctx context.Context) (bool, error) {
 	key := types.NamespacedName{
@@ -127,11 +147,11 @@ func (s AzureObjectStorageAdapter) isManagementClusterPrivate(ctx context.Contex
 // CreateBucket creates the Storage Account if it not exists AND the Storage Container
 // It checks if the storage account exists, and if not, it creates it.
 // Then, it creates a storage container within the storage account.
-// Finally, it retrieves the access key for 'key1' and creates a K8S Secret to store the storage account access key.
+// Finally, it retrieves the access key for 'key1' or a SAS token of the container, and creates a K8S Secret to store it.
 // The Secret is created in the same namespace as the bucket.
 // The function returns an error if any of the operations fail.
-func (s AzureObjectStorageAdapter) CreateBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	storageAccountName := sanitizeStorageAccountName(bucket.Spec.Name)
+func (s AzureObjectStorageAdapter) CreateBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
+	storageAccountName := SanitizeStorageAccountName(bucket.Spec.Name)
 
 	isPrivateManagementCluster, err := s.isManagementClusterPrivate(ctx)
 	if err != nil {
@@ -149,108 +169,88 @@ func (s AzureObjectStorageAdapter) CreateBucket(ctx context.Context, bucket *v1a
 	if isPrivateManagementCluster {
 		privateEndpoint, err := s.upsertPrivateEndpoint(ctx, bucket, storageAccountName)
 		if err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionPrivateEndpointReady, v1beta1.ReasonPrivateEndpointFailed, err.Error())
 			return fmt.Errorf("failed to upsert private endpoint for bucket %s in storage account %s: %w", bucket.Spec.Name, storageAccountName, err)
 		}
 
 		if _, err = s.upsertPrivateEndpointARecords(ctx, bucket, privateEndpoint, storageAccountName); err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionPrivateEndpointReady, v1beta1.ReasonPrivateEndpointFailed, err.Error())
 			return fmt.Errorf("failed to upsert private endpoint A records for bucket %s: %w", bucket.Spec.Name, err)
 		}
+		bucket.MarkConditionTrue(v1beta1.ConditionPrivateEndpointReady, v1beta1.ReasonPrivateEndpointConfigured, "")
+	} else {
+		bucket.RemoveCondition(v1beta1.ConditionPrivateEndpointReady)
 	}
 
-	// Create a K8S Secret to store Storage Account Access Key
-	// First, we retrieve Storage Account Access Key on Azure
-	listKeys, err := s.storageAccountClient.ListKeys(
-		ctx,
-		s.cluster.GetResourceGroup(),
-		storageAccountName,
-		nil,
-	)
+	// Create a K8S Secret to store the credentials of the Storage Container
+	// First, we retrieve the Storage Account Access Key or a SAS token of the container on Azure
+	credentials, err := s.connectionCredentials(ctx, bucket, storageAccountName)
 	if err != nil {
-		return fmt.Errorf("unable to retrieve access keys from storage account %s", storageAccountName)
+		bucket.MarkConditionFalse(v1beta1.ConditionCredentialsPublished, v1beta1.ReasonCredentialsPublishFailed, err.Error())
+		return err
 	}
 
-	secret := v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      bucket.Spec.Name,
-			Namespace: bucket.Namespace,
-			Labels: map[string]string{
-				"giantswarm.io/managed-by": "object-storage-operator",
-			},
-			Finalizers: []string{
-				v1alpha1.AzureSecretFinalizer,
-			},
-		},
+	// Finally, we publish the connection Secret, by default into the bucket namespace
+	details := map[string]string{
+		"accountName": storageAccountName,
+		"bucketName":  bucket.Spec.Name,
 	}
-
-	_, err = controllerutil.CreateOrUpdate(ctx, s.client, &secret, func() error {
-		// Then, we retrieve the Access Key for 'key1'
-		for _, k := range listKeys.Keys {
-			if *k.KeyName == "key1" {
-				// Finally, we create the Secret into the bucket namespace
-				secret.Data = map[string][]byte{
-					"accountName": []byte(storageAccountName),
-					"accountKey":  []byte(*k.Value),
-				}
-				return nil
-			}
-		}
-
-		return fmt.Errorf("unable to retrieve access keys 'key1' from storage account %s", storageAccountName)
-	})
-
+	maps.Copy(details, credentials)
+	// The managed identity of the access role is created after the bucket, its client ID is published by the
+	// reconciliation following the one that recorded it in the status.
+	if bucket.AccessRole() != nil && bucket.Status.AccessRole != nil && bucket.Status.AccessRole.ClientID != "" {
+		details["clientId"] = bucket.Status.AccessRole.ClientID
+		details["tenantId"] = bucket.Status.AccessRole.TenantID
+	}
+	err = objectstorage.PublishConnectionSecret(ctx, s.client, bucket, details, v1beta1.AzureSecretFinalizer)
 	if err != nil {
-		return fmt.Errorf("failed to create or update secret %s for bucket %s: %w", bucket.Spec.Name, bucket.Spec.Name, err)
+		return fmt.Errorf("failed to publish connection secret for bucket %s: %w", bucket.Spec.Name, err)
 	}
 
-	s.logger.Info(fmt.Sprintf("upserted secret %s", bucket.Spec.Name))
+	ref := bucket.ConnectionSecret()
+	s.logger.Info(fmt.Sprintf("upserted secret %s/%s", ref.Namespace, ref.Name))
 	return nil
 }
 
 // UpdateBucket creates or updates the Storage Account AND the Storage Container
-func (s AzureObjectStorageAdapter) UpdateBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
+func (s AzureObjectStorageAdapter) UpdateBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
 	return s.CreateBucket(ctx, bucket)
 }
 
 // DeleteBucket deletes the Storage Account (Storage Container will be deleted by cascade)
 // Here, we decided to have a Storage Account dedicated to a Storage Container (relation 1 - 1)
 // We want to prevent the Storage Account from being used by anyone
-func (s AzureObjectStorageAdapter) DeleteBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	storageAccountName := sanitizeStorageAccountName(bucket.Spec.Name)
+func (s AzureObjectStorageAdapter) DeleteBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
+	storageAccountName := SanitizeStorageAccountName(bucket.Spec.Name)
 
 	if err := s.deleteStorageAccount(ctx, bucket, storageAccountName); err != nil {
 		return fmt.Errorf("failed to delete storage account %s for bucket %s: %w", storageAccountName, bucket.Spec.Name, err)
 	}
 
-	// We delete the Azure Credentials secret
-	var secret = v1.Secret{}
-	err := s.client.Get(
-		ctx,
-		types.NamespacedName{
-			Name:      bucket.Spec.Name,
-			Namespace: bucket.Namespace,
-		},
-		&secret)
-	if err != nil {
-		return fmt.Errorf("failed to get secret %s for bucket %s: %w", bucket.Spec.Name, bucket.Spec.Name, err)
-	}
-	// We remove the finalizer to allow the secret to be deleted
-	originalSecret := secret.DeepCopy()
-	controllerutil.RemoveFinalizer(&secret, v1alpha1.AzureSecretFinalizer)
-	err = s.client.Patch(ctx, &secret, client.MergeFrom(originalSecret))
-	if err != nil {
-		return fmt.Errorf("failed to remove finalizer from secret %s for bucket %s: %w", bucket.Spec.Name, bucket.Spec.Name, err)
-	}
-	// We delete the secret
-	err = s.client.Delete(ctx, &secret)
+	// We delete the Azure Credentials secret, removing the finalizer that protects it
+	err := objectstorage.DeleteConnectionSecret(ctx, s.client, bucket, v1beta1.AzureSecretFinalizer)
 	if err != nil {
-		return fmt.Errorf("failed to delete secret %s for bucket %s: %w", bucket.Spec.Name, bucket.Spec.Name, err)
+		return fmt.Errorf("failed to delete connection secret for bucket %s: %w", bucket.Spec.Name, err)
 	}
-	s.logger.Info(fmt.Sprintf("deleted secret %s", bucket.Spec.Name))
+	s.logger.Info(fmt.Sprintf("deleted connection secret of bucket %s", bucket.Spec.Name))
 
 	return nil
 }
 
-// ConfigureBucket set lifecycle rules (expiration on blob) and tags on the Storage Container
-func (s AzureObjectStorageAdapter) ConfigureBucket(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	return s.setLifecycleRules(ctx, bucket)
+// ConfigureBucket set blob versioning and lifecycle rules (expiration on blob) on the Storage Account, the immutability policy of the Storage Container
+// and the role assignments of the access grants on the Storage Container
+func (s AzureObjectStorageAdapter) ConfigureBucket(ctx context.Context, bucket *v1beta1.Bucket) error {
+	err := s.setVersioning(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	err = s.setImmutabilityPolicy(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	err = s.setLifecycleRules(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	return s.configureAccessGrants(ctx, bucket)
 }
@@ -4,24 +4,52 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage/v3"
 	"github.com/aquilax/truncate"
 	sanitize "github.com/mrz1836/go-sanitize"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 )
 
-func (s AzureObjectStorageAdapter) upsertStorageAccount(ctx context.Context, bucket *v1alpha1.Bucket, storageAccountName string, isPrivateManagementCluster bool) error {
+func (s AzureObjectStorageAdapter) upsertStorageAccount(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string, isPrivateManagementCluster bool) error {
 	// Check if Storage Account exists on Azure
-	existsStorageAccount, err := s.existsStorageAccount(ctx, storageAccountName)
+	existsStorageAccount, err := s.existsStorageAccount(ctx, bucket, storageAccountName)
 	if err != nil {
 		return fmt.Errorf("failed to check if storage account %s exists: %w", storageAccountName, err)
 	}
 
+	// The settings of an adopted storage account are left as they were, the operator only stamps its tags.
+	if existsStorageAccount && bucket.IsAdopted() {
+		storageAccount, err := s.storageAccountClient.Update(
+			ctx,
+			s.cluster.GetResourceGroup(),
+			storageAccountName,
+			armstorage.AccountUpdateParameters{
+				Tags: s.getBucketTags(bucket),
+			}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to update tags of storage account %s: %w", storageAccountName, err)
+		}
+		bucket.Status.Encryption = storageAccountEncryptionStatus(storageAccount.Account)
+		s.logger.Info(fmt.Sprintf("adopted storage account %s updated", storageAccountName))
+		return nil
+	}
+
+	encryption, identity, err := storageAccountEncryption(bucket)
+	if err != nil {
+		return err
+	}
+
 	publicNetworkAccess := armstorage.PublicNetworkAccessEnabled
 	if isPrivateManagementCluster {
 		publicNetworkAccess = armstorage.PublicNetworkAccessDisabled
@@ -35,34 +63,28 @@ func (s AzureObjectStorageAdapter) upsertStorageAccount(ctx context.Context, buc
 		armstorage.AccountCreateParameters{
 			Kind: to.Ptr(armstorage.KindBlobStorage),
 			SKU: &armstorage.SKU{
-				Name: to.Ptr(armstorage.SKUNameStandardLRS),
+				Name: to.Ptr(armstorage.SKUName(bucket.AzureSKU())),
 			},
 			Location: to.Ptr(s.cluster.GetRegion()),
 			Properties: &armstorage.AccountPropertiesCreateParameters{
-				AllowSharedKeyAccess: to.Ptr(true),
-				AccessTier:           to.Ptr(armstorage.AccessTierHot),
-				Encryption: &armstorage.Encryption{
-					Services: &armstorage.EncryptionServices{
-						Blob: &armstorage.EncryptionService{
-							KeyType: to.Ptr(armstorage.KeyTypeAccount),
-							Enabled: to.Ptr(true),
-						},
-					},
-					KeySource: to.Ptr(armstorage.KeySourceMicrosoftStorage),
-				},
+				AllowSharedKeyAccess:   to.Ptr(true),
+				AccessTier:             to.Ptr(armstorage.AccessTier(bucket.AzureAccessTier())),
+				Encryption:             encryption,
 				EnableHTTPSTrafficOnly: to.Ptr(true),
 				MinimumTLSVersion:      to.Ptr(armstorage.MinimumTLSVersionTLS12),
 				PublicNetworkAccess:    to.Ptr(publicNetworkAccess),
 			},
-			Tags: s.getBucketTags(bucket),
+			Identity: identity,
+			Tags:     s.getBucketTags(bucket),
 		}, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin create storage account %s: %w", storageAccountName, err)
 	}
-	_, err = pollerStorageAccount.PollUntilDone(ctx, nil)
+	storageAccount, err := pollerStorageAccount.PollUntilDone(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to complete storage account %s creation: %w", storageAccountName, err)
 	}
+	bucket.Status.Encryption = storageAccountEncryptionStatus(storageAccount.Account)
 
 	if !existsStorageAccount {
 		s.logger.Info(fmt.Sprintf("storage account %s created", storageAccountName))
@@ -72,7 +94,85 @@ func (s AzureObjectStorageAdapter) upsertStorageAccount(ctx context.Context, buc
 	return nil
 }
 
-func (s AzureObjectStorageAdapter) deleteStorageAccount(ctx context.Context, bucket *v1alpha1.Bucket, storageAccountName string) error {
+// storageAccountEncryption returns the encryption of the storage account of the bucket: Microsoft-managed keys, or the
+// Key Vault key of the bucket read with its user-assigned identity when it is customer managed.
+func storageAccountEncryption(bucket *v1beta1.Bucket) (*armstorage.Encryption, *armstorage.Identity, error) {
+	encryption := &armstorage.Encryption{
+		Services: &armstorage.EncryptionServices{
+			Blob: &armstorage.EncryptionService{
+				KeyType: to.Ptr(armstorage.KeyTypeAccount),
+				Enabled: to.Ptr(true),
+			},
+		},
+		KeySource: to.Ptr(armstorage.KeySourceMicrosoftStorage),
+	}
+	if bucket.EncryptionMode() != v1beta1.EncryptionModeCustomerManaged || bucket.Spec.Encryption.KeyVault == nil {
+		return encryption, nil, nil
+	}
+
+	keyVault := bucket.Spec.Encryption.KeyVault
+	keyVaultProperties, err := ParseKeyVaultKeyURI(keyVault.KeyURI)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryption.KeySource = to.Ptr(armstorage.KeySourceMicrosoftKeyvault)
+	encryption.KeyVaultProperties = keyVaultProperties
+	encryption.EncryptionIdentity = &armstorage.EncryptionIdentity{
+		EncryptionUserAssignedIdentity: to.Ptr(keyVault.UserAssignedIdentityID),
+	}
+	identity := &armstorage.Identity{
+		Type: to.Ptr(armstorage.IdentityTypeUserAssigned),
+		UserAssignedIdentities: map[string]*armstorage.UserAssignedIdentity{
+			keyVault.UserAssignedIdentityID: {},
+		},
+	}
+	return encryption, identity, nil
+}
+
+// ParseKeyVaultKeyURI splits a key URI like https://my-vault.vault.azure.net/keys/my-key/version into the properties of
+// the key. Keys without version are rotated automatically.
+func ParseKeyVaultKeyURI(keyURI string) (*armstorage.KeyVaultProperties, error) {
+	parsed, err := url.Parse(keyURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key vault key URI %s: %w", keyURI, err)
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if parsed.Scheme != "https" || parsed.Host == "" || len(segments) < 2 || len(segments) > 3 || segments[0] != "keys" || segments[1] == "" {
+		return nil, fmt.Errorf("invalid key vault key URI %s: expected https://<vault>/keys/<name>[/<version>]", keyURI)
+	}
+
+	properties := &armstorage.KeyVaultProperties{
+		KeyName:     to.Ptr(segments[1]),
+		KeyVaultURI: to.Ptr(fmt.Sprintf("https://%s/", parsed.Host)),
+	}
+	if len(segments) == 3 {
+		properties.KeyVersion = to.Ptr(segments[2])
+	}
+	return properties, nil
+}
+
+// storageAccountEncryptionStatus returns the encryption of the storage account.
+func storageAccountEncryptionStatus(storageAccount armstorage.Account) *v1beta1.BucketEncryptionStatus {
+	if storageAccount.Properties == nil || storageAccount.Properties.Encryption == nil {
+		return nil
+	}
+	encryption := storageAccount.Properties.Encryption
+	if encryption.KeySource == nil || *encryption.KeySource != armstorage.KeySourceMicrosoftKeyvault || encryption.KeyVaultProperties == nil {
+		return &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeProviderManaged}
+	}
+
+	properties := encryption.KeyVaultProperties
+	if properties.KeyVaultURI == nil || properties.KeyName == nil {
+		return &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeCustomerManaged}
+	}
+	keyID := fmt.Sprintf("%s/keys/%s", strings.TrimSuffix(*properties.KeyVaultURI, "/"), *properties.KeyName)
+	if properties.KeyVersion != nil && *properties.KeyVersion != "" {
+		keyID = fmt.Sprintf("%s/%s", keyID, *properties.KeyVersion)
+	}
+	return &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeCustomerManaged, KeyID: keyID}
+}
+
+func (s AzureObjectStorageAdapter) deleteStorageAccount(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) error {
 	// Delete Storage Account
 	// We delete the Storage Account, which delete the Storage Container
 	_, err := s.storageAccountClient.Delete(
@@ -88,9 +188,29 @@ func (s AzureObjectStorageAdapter) deleteStorageAccount(ctx context.Context, buc
 	return nil
 }
 
-// existsStorageAccount checks if a storage account exists for the given bucket name in Azure Object Storage.
-// It returns a boolean indicating whether the storage account exists or not, along with any error encountered.
-func (s AzureObjectStorageAdapter) existsStorageAccount(ctx context.Context, storageAccountName string) (bool, error) {
+// existsStorageAccount checks if the storage account of the bucket exists in the resource group of the management cluster.
+// Storage account names are global, so a name that is taken outside of the resource group, or a storage account
+// tagged as owned by another Bucket, is reported as a conflict.
+// Storage accounts without owner tag are considered ours if the operator created them before it tagged storage
+// accounts, or adopted them. They are reported as unmanaged otherwise.
+func (s AzureObjectStorageAdapter) existsStorageAccount(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) (bool, error) {
+	storageAccount, err := s.storageAccountClient.GetProperties(ctx, s.cluster.GetResourceGroup(), storageAccountName, nil)
+	if err == nil {
+		owner, ok := storageAccount.Tags[OwnerTagKey]
+		switch {
+		case ok && owner != nil && *owner != getBucketOwner(bucket):
+			return false, fmt.Errorf("%w: storage account %s is owned by bucket %s", objectstorage.ErrBucketConflict, storageAccountName, *owner)
+		case !ok && !bucket.IsManaged():
+			return true, fmt.Errorf("%w: storage account %s already exists in resource group %s", objectstorage.ErrBucketUnmanaged, storageAccountName, s.cluster.GetResourceGroup())
+		}
+		return true, nil
+	}
+
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusNotFound {
+		return false, fmt.Errorf("failed to get storage account %s in resource group %s: %w", storageAccountName, s.cluster.GetResourceGroup(), err)
+	}
+
 	availability, err := s.storageAccountClient.CheckNameAvailability(
 		ctx,
 		armstorage.AccountCheckNameAvailabilityParameters{
@@ -101,13 +221,75 @@ func (s AzureObjectStorageAdapter) existsStorageAccount(ctx context.Context, sto
 	if err != nil {
 		return false, fmt.Errorf("failed to check name availability for storage account %s: %w", storageAccountName, err)
 	}
-	return !*availability.NameAvailable, nil
+	if !*availability.NameAvailable {
+		return false, fmt.Errorf("%w: storage account %s exists outside of resource group %s", objectstorage.ErrBucketConflict, storageAccountName, s.cluster.GetResourceGroup())
+	}
+	return false, nil
+}
+
+// adoptStorageAccount records the tags of the existing storage account so that they are kept once the operator manages its tags.
+func (s AzureObjectStorageAdapter) adoptStorageAccount(ctx context.Context, bucket *v1beta1.Bucket, storageAccountName string) error {
+	storageAccount, err := s.storageAccountClient.GetProperties(ctx, s.cluster.GetResourceGroup(), storageAccountName, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get storage account %s in resource group %s: %w", storageAccountName, s.cluster.GetResourceGroup(), err)
+	}
+
+	adoption := &v1beta1.BucketAdoption{AdoptedAt: metav1.Now()}
+	for _, key := range slices.Sorted(maps.Keys(storageAccount.Tags)) {
+		tag := v1beta1.BucketTag{Key: key}
+		if value := storageAccount.Tags[key]; value != nil {
+			tag.Value = *value
+		}
+		adoption.Tags = append(adoption.Tags, tag)
+	}
+	bucket.Status.Adoption = adoption
+	return nil
 }
 
-// setLifecycleRules set a lifecycle rule on the Storage Account to delete Blobs older than X days
-func (s AzureObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket *v1alpha1.Bucket) error {
-	storageAccountName := sanitizeStorageAccountName(bucket.Spec.Name)
-	if bucket.Spec.ExpirationPolicy != nil {
+// setVersioning enables or disables the blob versioning of the Storage Account. Versioning is left untouched when not set.
+func (s AzureObjectStorageAdapter) setVersioning(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if bucket.Spec.Versioning == "" {
+		return nil
+	}
+
+	storageAccountName := SanitizeStorageAccountName(bucket.Spec.Name)
+	properties, err := s.blobServicesClient.GetServiceProperties(ctx, s.cluster.GetResourceGroup(), storageAccountName, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get blob service properties of storage account %s: %w", storageAccountName, err)
+	}
+
+	enabled := bucket.Spec.Versioning == v1beta1.VersioningEnabled
+	current := properties.BlobServiceProperties.BlobServiceProperties
+	if current != nil && current.IsVersioningEnabled != nil && *current.IsVersioningEnabled == enabled {
+		return nil
+	}
+
+	_, err = s.blobServicesClient.SetServiceProperties(
+		ctx,
+		s.cluster.GetResourceGroup(),
+		storageAccountName,
+		armstorage.BlobServiceProperties{
+			BlobServiceProperties: &armstorage.BlobServicePropertiesProperties{
+				IsVersioningEnabled: to.Ptr(enabled),
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set blob versioning of storage account %s: %w", storageAccountName, err)
+	}
+	return nil
+}
+
+// setLifecycleRules set the lifecycle rules on the Storage Account, e.g. to delete Blobs older than X days
+func (s AzureObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket *v1beta1.Bucket) error {
+	storageAccountName := SanitizeStorageAccountName(bucket.Spec.Name)
+	rules, err := managementPolicyRules(bucket)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
 		_, err := s.managementPoliciesClient.CreateOrUpdate(
 			ctx,
 			s.cluster.GetResourceGroup(),
@@ -116,27 +298,7 @@ func (s AzureObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket
 			armstorage.ManagementPolicy{
 				Properties: &armstorage.ManagementPolicyProperties{
 					Policy: &armstorage.ManagementPolicySchema{
-						Rules: []*armstorage.ManagementPolicyRule{
-							{
-								Enabled: to.Ptr(true),
-								Name:    to.Ptr(LifecycleRuleName),
-								Type:    to.Ptr(armstorage.RuleTypeLifecycle),
-								Definition: &armstorage.ManagementPolicyDefinition{
-									Actions: &armstorage.ManagementPolicyAction{
-										BaseBlob: &armstorage.ManagementPolicyBaseBlob{
-											Delete: &armstorage.DateAfterModification{
-												DaysAfterModificationGreaterThan: to.Ptr(float32(bucket.Spec.ExpirationPolicy.Days)),
-											},
-										},
-									},
-									Filters: &armstorage.ManagementPolicyFilter{
-										BlobTypes: []*string{
-											to.Ptr("blockBlob"),
-										},
-									},
-								},
-							},
-						},
+						Rules: rules,
 					},
 				},
 			},
@@ -145,15 +307,16 @@ func (s AzureObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket
 		if err != nil {
 			return fmt.Errorf("failed to create/update lifecycle policy for storage account %s: %w", storageAccountName, err)
 		}
+		bucket.Status.Lifecycle = lifecycleStatus()
 		return nil
 	}
 
 	// No Lifecycle Policy defines in the bucket CR, we delete it in the Storage Account
-	_, err := s.managementPoliciesClient.Delete(
+	_, err = s.managementPoliciesClient.Delete(
 		ctx,
 		s.cluster.GetResourceGroup(),
 		storageAccountName,
-		LifecycleRuleName,
+		armstorage.ManagementPolicyNameDefault,
 		nil,
 	)
 	if err != nil {
@@ -161,16 +324,121 @@ func (s AzureObjectStorageAdapter) setLifecycleRules(ctx context.Context, bucket
 		if errors.As(err, &respErr) {
 			// If the Lifecycle policy does not exists, it's not an error
 			if respErr.StatusCode == http.StatusNotFound {
+				bucket.Status.Lifecycle = lifecycleStatus()
 				return nil
 			}
 		}
 		return fmt.Errorf("failed to delete lifecycle policy for storage account %s: %w", storageAccountName, err)
 	}
+	bucket.Status.Lifecycle = lifecycleStatus()
 	return nil
 }
 
-// sanitizeStorageAccountName sanitizes the given name by removing any non-alphanumeric characters and truncating it to a maximum length of 24 characters.
+// lifecycleStatus returns the cleanup Azure applies on its own. Uncommitted blocks cannot be discarded through the
+// management policy, Azure always discards them after UncommittedBlocksRetentionDays whatever the spec says.
+func lifecycleStatus() *v1beta1.BucketLifecycleStatus {
+	return &v1beta1.BucketLifecycleStatus{AbortIncompleteMultipartUploadDays: UncommittedBlocksRetentionDays}
+}
+
+// managementPolicyRules returns the rules of the management policy of the storage account: the expiration of all
+// blobs, followed by the rules of the spec.
+func managementPolicyRules(bucket *v1beta1.Bucket) ([]*armstorage.ManagementPolicyRule, error) {
+	var rules []*armstorage.ManagementPolicyRule
+	if bucket.ExpirationDays() != nil {
+		rules = append(rules, &armstorage.ManagementPolicyRule{
+			Enabled: to.Ptr(true),
+			Name:    to.Ptr(LifecycleRuleName),
+			Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+			Definition: &armstorage.ManagementPolicyDefinition{
+				Actions: &armstorage.ManagementPolicyAction{
+					BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+						Delete: &armstorage.DateAfterModification{
+							DaysAfterModificationGreaterThan: to.Ptr(float32(*bucket.ExpirationDays())),
+						},
+					},
+				},
+				Filters: &armstorage.ManagementPolicyFilter{
+					BlobTypes: []*string{
+						to.Ptr("blockBlob"),
+					},
+				},
+			},
+		})
+	}
+
+	for _, rule := range bucket.LifecycleRules() {
+		filters := &armstorage.ManagementPolicyFilter{
+			BlobTypes: []*string{
+				to.Ptr("blockBlob"),
+			},
+		}
+		if rule.Filter != nil {
+			// Prefixes of management policies start with the name of the container.
+			if rule.Filter.Prefix != "" {
+				filters.PrefixMatch = []*string{to.Ptr(fmt.Sprintf("%s/%s", bucket.Spec.Name, rule.Filter.Prefix))}
+			}
+			for _, tag := range rule.Filter.Tags {
+				filters.BlobIndexMatch = append(filters.BlobIndexMatch, &armstorage.TagFilter{
+					Name:  to.Ptr(tag.Key),
+					Op:    to.Ptr("=="),
+					Value: to.Ptr(tag.Value),
+				})
+			}
+		}
+
+		baseBlob := &armstorage.ManagementPolicyBaseBlob{}
+		if rule.Expiration != nil {
+			if rule.Expiration.Date != "" {
+				return nil, fmt.Errorf("expiration date of lifecycle rule %s is not supported on Azure", rule.ID)
+			}
+			baseBlob.Delete = &armstorage.DateAfterModification{
+				DaysAfterModificationGreaterThan: to.Ptr(float32(rule.Expiration.Days)),
+			}
+		}
+		for _, transition := range rule.Transitions {
+			tier := &armstorage.DateAfterModification{
+				DaysAfterModificationGreaterThan: to.Ptr(float32(transition.Days)),
+			}
+			switch transition.StorageClass {
+			case v1beta1.StorageClassCool:
+				baseBlob.TierToCool = tier
+			case v1beta1.StorageClassCold:
+				baseBlob.TierToCold = tier
+			case v1beta1.StorageClassArchive:
+				baseBlob.TierToArchive = tier
+			default:
+				return nil, fmt.Errorf("storage class %s of lifecycle rule %s is not supported on Azure", transition.StorageClass, rule.ID)
+			}
+		}
+
+		actions := &armstorage.ManagementPolicyAction{}
+		if *baseBlob != (armstorage.ManagementPolicyBaseBlob{}) {
+			actions.BaseBlob = baseBlob
+		}
+		// Azure has no delete markers: deleted blobs only leave their previous versions behind.
+		if rule.NoncurrentVersionExpiration != nil {
+			actions.Version = &armstorage.ManagementPolicyVersion{
+				Delete: &armstorage.DateAfterCreation{
+					DaysAfterCreationGreaterThan: to.Ptr(float32(rule.NoncurrentVersionExpiration.Days)),
+				},
+			}
+		}
+
+		rules = append(rules, &armstorage.ManagementPolicyRule{
+			Enabled: to.Ptr(rule.IsEnabled()),
+			Name:    to.Ptr(rule.ID),
+			Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+			Definition: &armstorage.ManagementPolicyDefinition{
+				Actions: actions,
+				Filters: filters,
+			},
+		})
+	}
+	return rules, nil
+}
+
+// SanitizeStorageAccountName sanitizes the given name by removing any non-alphanumeric characters and truncating it to a maximum length of 24 characters.
 // more details https://learn.microsoft.com/en-us/rest/api/storagerp/storage-accounts/get-properties?view=rest-storagerp-2023-01-01&tabs=HTTP#uri-parameters
-func sanitizeStorageAccountName(name string) string {
+func SanitizeStorageAccountName(name string) string {
 	return truncate.Truncate(sanitize.AlphaNumeric(name, false), 24, "", truncate.PositionEnd)
 }
@@ -0,0 +1,255 @@
+package azure
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage/v3"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_ManagementPolicyRules(t *testing.T) {
+	blockBlobs := []*string{to.Ptr("blockBlob")}
+
+	testCases := []struct {
+		name          string
+		lifecycle     *v1beta1.BucketLifecycle
+		expectedRules []*armstorage.ManagementPolicyRule
+		expectError   bool
+	}{
+		{
+			name:          "case 0: no lifecycle",
+			expectedRules: nil,
+		},
+		{
+			name: "case 1: expiration and rules with prefix and tag filters",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Expiration: &v1beta1.BucketExpiration{Days: 100},
+				Rules: []v1beta1.BucketLifecycleRule{
+					{
+						ID:         "index",
+						Filter:     &v1beta1.BucketLifecycleFilter{Prefix: "index/"},
+						Expiration: &v1beta1.BucketLifecycleExpiration{Days: 7},
+					},
+					{
+						ID:         "tenanta",
+						Enabled:    to.Ptr(false),
+						Filter:     &v1beta1.BucketLifecycleFilter{Tags: []v1beta1.BucketTag{{Key: "tenant", Value: "a"}}},
+						Expiration: &v1beta1.BucketLifecycleExpiration{Days: 14},
+					},
+				},
+			},
+			expectedRules: []*armstorage.ManagementPolicyRule{
+				{
+					Enabled: to.Ptr(true),
+					Name:    to.Ptr(LifecycleRuleName),
+					Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+					Definition: &armstorage.ManagementPolicyDefinition{
+						Actions: &armstorage.ManagementPolicyAction{BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+							Delete: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(100))},
+						}},
+						Filters: &armstorage.ManagementPolicyFilter{BlobTypes: blockBlobs},
+					},
+				},
+				{
+					Enabled: to.Ptr(true),
+					Name:    to.Ptr("index"),
+					Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+					Definition: &armstorage.ManagementPolicyDefinition{
+						Actions: &armstorage.ManagementPolicyAction{BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+							Delete: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(7))},
+						}},
+						Filters: &armstorage.ManagementPolicyFilter{
+							BlobTypes:   blockBlobs,
+							PrefixMatch: []*string{to.Ptr("giantswarm-glippy-loki/index/")},
+						},
+					},
+				},
+				{
+					Enabled: to.Ptr(false),
+					Name:    to.Ptr("tenanta"),
+					Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+					Definition: &armstorage.ManagementPolicyDefinition{
+						Actions: &armstorage.ManagementPolicyAction{BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+							Delete: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(14))},
+						}},
+						Filters: &armstorage.ManagementPolicyFilter{
+							BlobTypes:      blockBlobs,
+							BlobIndexMatch: []*armstorage.TagFilter{{Name: to.Ptr("tenant"), Op: to.Ptr("=="), Value: to.Ptr("a")}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "case 2: transitions to cool, cold and archive",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Rules: []v1beta1.BucketLifecycleRule{
+					{
+						ID:         "chunks",
+						Expiration: &v1beta1.BucketLifecycleExpiration{Days: 365},
+						Transitions: []v1beta1.BucketLifecycleTransition{
+							{Days: 30, StorageClass: v1beta1.StorageClassCool},
+							{Days: 90, StorageClass: v1beta1.StorageClassCold},
+							{Days: 180, StorageClass: v1beta1.StorageClassArchive},
+						},
+					},
+				},
+			},
+			expectedRules: []*armstorage.ManagementPolicyRule{
+				{
+					Enabled: to.Ptr(true),
+					Name:    to.Ptr("chunks"),
+					Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+					Definition: &armstorage.ManagementPolicyDefinition{
+						Actions: &armstorage.ManagementPolicyAction{BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+							Delete:        &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(365))},
+							TierToCool:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(30))},
+							TierToCold:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(90))},
+							TierToArchive: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr(float32(180))},
+						}},
+						Filters: &armstorage.ManagementPolicyFilter{BlobTypes: blockBlobs},
+					},
+				},
+			},
+		},
+		{
+			name: "case 3: storage class of another provider",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Rules: []v1beta1.BucketLifecycleRule{
+					{ID: "chunks", Transitions: []v1beta1.BucketLifecycleTransition{{Days: 30, StorageClass: v1beta1.StorageClassGlacierIR}}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "case 4: expiration date",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Rules: []v1beta1.BucketLifecycleRule{
+					{ID: "index", Expiration: &v1beta1.BucketLifecycleExpiration{Date: "2030-01-01"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "case 5: noncurrent version expiration",
+			lifecycle: &v1beta1.BucketLifecycle{
+				Rules: []v1beta1.BucketLifecycleRule{
+					{ID: "versions", NoncurrentVersionExpiration: &v1beta1.BucketNoncurrentVersionExpiration{Days: 7}},
+				},
+			},
+			expectedRules: []*armstorage.ManagementPolicyRule{
+				{
+					Enabled: to.Ptr(true),
+					Name:    to.Ptr("versions"),
+					Type:    to.Ptr(armstorage.RuleTypeLifecycle),
+					Definition: &armstorage.ManagementPolicyDefinition{
+						Actions: &armstorage.ManagementPolicyAction{
+							Version: &armstorage.ManagementPolicyVersion{
+								Delete: &armstorage.DateAfterCreation{DaysAfterCreationGreaterThan: to.Ptr(float32(7))},
+							},
+						},
+						Filters: &armstorage.ManagementPolicyFilter{BlobTypes: blockBlobs},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{Name: "giantswarm-glippy-loki", Lifecycle: tc.lifecycle}}
+			rules, err := managementPolicyRules(bucket)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(rules, tc.expectedRules) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedRules, rules))
+			}
+		})
+	}
+}
+
+func Test_StorageAccountEncryption(t *testing.T) {
+	identityID := "/subscriptions/1234/resourceGroups/glippy/providers/Microsoft.ManagedIdentity/userAssignedIdentities/loki"
+
+	testCases := []struct {
+		name           string
+		encryption     *v1beta1.BucketEncryption
+		expectedStatus *v1beta1.BucketEncryptionStatus
+		expectError    bool
+	}{
+		{
+			name:           "case 0: no encryption",
+			expectedStatus: &v1beta1.BucketEncryptionStatus{Mode: v1beta1.EncryptionModeProviderManaged},
+		},
+		{
+			name: "case 1: Key Vault key without version",
+			encryption: &v1beta1.BucketEncryption{
+				Mode:     v1beta1.EncryptionModeCustomerManaged,
+				KeyVault: &v1beta1.KeyVaultEncryption{KeyURI: "https://glippy.vault.azure.net/keys/loki", UserAssignedIdentityID: identityID},
+			},
+			expectedStatus: &v1beta1.BucketEncryptionStatus{
+				Mode:  v1beta1.EncryptionModeCustomerManaged,
+				KeyID: "https://glippy.vault.azure.net/keys/loki",
+			},
+		},
+		{
+			name: "case 2: Key Vault key with version",
+			encryption: &v1beta1.BucketEncryption{
+				Mode:     v1beta1.EncryptionModeCustomerManaged,
+				KeyVault: &v1beta1.KeyVaultEncryption{KeyURI: "https://glippy.vault.azure.net/keys/loki/0123456789abcdef", UserAssignedIdentityID: identityID},
+			},
+			expectedStatus: &v1beta1.BucketEncryptionStatus{
+				Mode:  v1beta1.EncryptionModeCustomerManaged,
+				KeyID: "https://glippy.vault.azure.net/keys/loki/0123456789abcdef",
+			},
+		},
+		{
+			name: "case 3: Key Vault secret instead of key",
+			encryption: &v1beta1.BucketEncryption{
+				Mode:     v1beta1.EncryptionModeCustomerManaged,
+				KeyVault: &v1beta1.KeyVaultEncryption{KeyURI: "https://glippy.vault.azure.net/secrets/loki", UserAssignedIdentityID: identityID},
+			},
+			expectError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			bucket := &v1beta1.Bucket{Spec: v1beta1.BucketSpec{Name: "giantswarm-glippy-loki", Encryption: tc.encryption}}
+			encryption, identity, err := storageAccountEncryption(bucket)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (identity != nil) != (bucket.EncryptionMode() == v1beta1.EncryptionModeCustomerManaged) {
+				t.Fatalf("unexpected identity: %v", identity)
+			}
+
+			status := storageAccountEncryptionStatus(armstorage.Account{Properties: &armstorage.AccountProperties{Encryption: encryption}})
+			if !cmp.Equal(status, tc.expectedStatus) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStatus, status))
+			}
+		})
+	}
+}
@@ -1,26 +1,58 @@
 package azure
 
 import (
+	"fmt"
+	"maps"
 	"strings"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 )
 
-func sanitizeTagKey(tagName string) string {
+// OwnerTagKey is the tag set on storage accounts and managed identities to record the Bucket that owns them.
+const OwnerTagKey = "giantswarm_io_bucket"
+
+// SanitizeTagKey replaces the characters of a tag key that are not allowed by Azure.
+func SanitizeTagKey(tagName string) string {
 	return strings.ReplaceAll(tagName, "-", "_")
 }
 
-func (s AzureObjectStorageAdapter) getBucketTags(bucket *v1alpha1.Bucket) map[string]*string {
+func (s AzureObjectStorageAdapter) getBucketTags(bucket *v1beta1.Bucket) map[string]*string {
+	tags := make(map[string]*string)
+	// Tags of the spec and of the cluster override the tags the storage account had when it was adopted.
+	if bucket.IsAdopted() {
+		for _, tag := range bucket.Status.Adoption.Tags {
+			tags[tag.Key] = &tag.Value
+		}
+	}
+	maps.Copy(tags, getResourceTags(bucket, s.cluster.GetTags()))
+	return tags
+}
+
+// getResourceTags returns the tags of the spec and of the cluster, and the owner tag of the bucket.
+func getResourceTags(bucket *v1beta1.Bucket, clusterTags map[string]string) map[string]*string {
 	tags := make(map[string]*string)
 	for _, tag := range bucket.Spec.Tags {
 		if tag.Key != "" && tag.Value != "" {
-			tags[sanitizeTagKey(tag.Key)] = &tag.Value
+			tags[SanitizeTagKey(tag.Key)] = &tag.Value
 		}
 	}
-	for key, value := range s.cluster.GetTags() {
+	for key, value := range clusterTags {
 		if key != "" && value != "" {
-			tags[sanitizeTagKey(key)] = &value
+			tags[SanitizeTagKey(key)] = &value
 		}
 	}
+	owner := getBucketOwner(bucket)
+	tags[OwnerTagKey] = &owner
 	return tags
 }
+
+// isOwnedBy returns whether the tags of a resource record the given bucket as its owner.
+func isOwnedBy(tags map[string]*string, bucket *v1beta1.Bucket) bool {
+	owner, ok := tags[OwnerTagKey]
+	return ok && owner != nil && *owner == getBucketOwner(bucket)
+}
+
+// getBucketOwner returns the value of the owner tag for the given bucket.
+func getBucketOwner(bucket *v1beta1.Bucket) string {
+	return fmt.Sprintf("%s/%s", bucket.Namespace, bucket.Name)
+}
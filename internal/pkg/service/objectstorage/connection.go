@@ -0,0 +1,202 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"text/template"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+const (
+	// ManagedByLabel is set on the Secrets published by the operator.
+	ManagedByLabel = "giantswarm.io/managed-by"
+	ManagedByValue = "object-storage-operator"
+	// ConnectionSecretOwnerAnnotation records the Bucket a connection Secret is published for, as Secrets in another
+	// namespace cannot be owned by the Bucket.
+	ConnectionSecretOwnerAnnotation = "objectstorage.giantswarm.io/bucket"
+)
+
+// RenderConnectionDetails returns the data of the connection Secret of the bucket: the connection details, and the
+// keys rendered by the templates of spec.writeConnectionSecretToRef, which win over the details with the same key.
+func RenderConnectionDetails(bucket *v1beta1.Bucket, details map[string]string) (map[string][]byte, error) {
+	data := make(map[string][]byte, len(details))
+	for key, value := range details {
+		data[key] = []byte(value)
+	}
+	if bucket.Spec.WriteConnectionSecretToRef == nil {
+		return data, nil
+	}
+
+	templates := bucket.Spec.WriteConnectionSecretToRef.Templates
+	for _, key := range slices.Sorted(maps.Keys(templates)) {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(templates[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template of key %s: %w", key, err)
+		}
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, details); err != nil {
+			return nil, fmt.Errorf("failed to render template of key %s: %w", key, err)
+		}
+		data[key] = rendered.Bytes()
+	}
+	return data, nil
+}
+
+// PublishConnectionSecret creates or updates the connection Secret of the bucket with the given connection details,
+// and records it in the status of the bucket. The Secret published by a previous reference is deleted.
+// Secrets with a finalizer are kept until the finalizer is removed by DeleteConnectionSecret; other Secrets are owned by
+// the Bucket when they live in its namespace.
+func PublishConnectionSecret(ctx context.Context, c client.Client, bucket *v1beta1.Bucket, details map[string]string, finalizer string) error {
+	ref := bucket.ConnectionSecret()
+	if previous := bucket.Status.ConnectionSecretRef; previous != nil && *previous != ref {
+		if err := deleteConnectionSecret(ctx, c, bucket, *previous, finalizer); err != nil {
+			bucket.MarkConditionFalse(v1beta1.ConditionCredentialsPublished, v1beta1.ReasonCredentialsPublishFailed, err.Error())
+			return err
+		}
+	}
+
+	data, err := RenderConnectionDetails(bucket, details)
+	if err != nil {
+		bucket.MarkConditionFalse(v1beta1.ConditionCredentialsPublished, v1beta1.ReasonCredentialsPublishFailed, err.Error())
+		return fmt.Errorf("failed to render connection secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		if !isConnectionSecretOf(secret, bucket) {
+			return fmt.Errorf("secret %s/%s already exists and is not managed by bucket %s/%s", ref.Namespace, ref.Name, bucket.Namespace, bucket.Name)
+		}
+
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		if bucket.Spec.WriteConnectionSecretToRef != nil {
+			maps.Copy(secret.Labels, bucket.Spec.WriteConnectionSecretToRef.Labels)
+			maps.Copy(secret.Annotations, bucket.Spec.WriteConnectionSecretToRef.Annotations)
+		}
+		secret.Labels[ManagedByLabel] = ManagedByValue
+		secret.Annotations[ConnectionSecretOwnerAnnotation] = connectionSecretOwner(bucket)
+		secret.Data = data
+
+		if finalizer != "" {
+			controllerutil.AddFinalizer(secret, finalizer)
+			return nil
+		}
+		// Buckets built by the COSI driver are not Kubernetes objects and cannot own the Secret.
+		if secret.Namespace != bucket.Namespace || bucket.UID == "" {
+			return nil
+		}
+		return controllerutil.SetControllerReference(bucket, secret, c.Scheme())
+	})
+	if err != nil {
+		bucket.MarkConditionFalse(v1beta1.ConditionCredentialsPublished, v1beta1.ReasonCredentialsPublishFailed, err.Error())
+		return fmt.Errorf("failed to create or update connection secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	bucket.Status.ConnectionSecretRef = &ref
+	bucket.MarkConditionTrue(v1beta1.ConditionCredentialsPublished, v1beta1.ReasonCredentialsPublished, fmt.Sprintf("Connection details published in secret %s/%s", ref.Namespace, ref.Name))
+	return nil
+}
+
+// GetConnectionSecret returns the connection Secret published for the bucket, or nil when it has not been published
+// yet or was published by someone else in the meantime.
+func GetConnectionSecret(ctx context.Context, c client.Client, bucket *v1beta1.Bucket) (*v1.Secret, error) {
+	if bucket.Status.ConnectionSecretRef == nil {
+		return nil, nil
+	}
+	ref := *bucket.Status.ConnectionSecretRef
+
+	secret := &v1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get connection secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	if !isConnectionSecretOf(secret, bucket) {
+		return nil, nil
+	}
+	return secret, nil
+}
+
+// DeleteConnectionSecret deletes the connection Secret published for the bucket, after removing the given finalizer.
+func DeleteConnectionSecret(ctx context.Context, c client.Client, bucket *v1beta1.Bucket, finalizer string) error {
+	ref := bucket.ConnectionSecret()
+	if bucket.Status.ConnectionSecretRef != nil {
+		ref = *bucket.Status.ConnectionSecretRef
+	}
+	return deleteConnectionSecret(ctx, c, bucket, ref, finalizer)
+}
+
+func deleteConnectionSecret(ctx context.Context, c client.Client, bucket *v1beta1.Bucket, ref v1beta1.SecretReference, finalizer string) error {
+	secret := &v1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get connection secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	// Never delete a Secret published by someone else in the meantime.
+	if !isConnectionSecretOf(secret, bucket) {
+		return nil
+	}
+
+	if finalizer != "" && controllerutil.ContainsFinalizer(secret, finalizer) {
+		originalSecret := secret.DeepCopy()
+		controllerutil.RemoveFinalizer(secret, finalizer)
+		err = c.Patch(ctx, secret, client.MergeFrom(originalSecret))
+		if err != nil {
+			return fmt.Errorf("failed to remove finalizer from connection secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+	}
+
+	err = c.Delete(ctx, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete connection secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	return nil
+}
+
+// ConnectionSecretNamespaceAllowed returns true if the connection Secret of the bucket is published in the namespace
+// of the bucket or in one of the given namespaces.
+func ConnectionSecretNamespaceAllowed(bucket *v1beta1.Bucket, namespaces []string) bool {
+	namespace := bucket.ConnectionSecret().Namespace
+	return namespace == bucket.Namespace || slices.Contains(namespaces, namespace)
+}
+
+// isConnectionSecretOf returns true if the Secret does not exist yet or was published for the bucket. Secrets
+// published before the owner annotation existed only carry the managed-by label, and are only recognised under the
+// name and namespace they were published with: the name of the bucket in the namespace of the Bucket.
+func isConnectionSecretOf(secret *v1.Secret, bucket *v1beta1.Bucket) bool {
+	if secret.ResourceVersion == "" {
+		return true
+	}
+	owner, ok := secret.Annotations[ConnectionSecretOwnerAnnotation]
+	if !ok {
+		return secret.Labels[ManagedByLabel] == ManagedByValue &&
+			secret.Namespace == bucket.Namespace && secret.Name == bucket.Spec.Name
+	}
+	return owner == connectionSecretOwner(bucket)
+}
+
+func connectionSecretOwner(bucket *v1beta1.Bucket) string {
+	return fmt.Sprintf("%s/%s", bucket.Namespace, bucket.Name)
+}
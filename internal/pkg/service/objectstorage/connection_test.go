@@ -0,0 +1,190 @@
+package objectstorage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_PublishConnectionSecret(t *testing.T) {
+	details := map[string]string{"bucketName": "giantswarm-glippy-loki", "region": "eu-west-1"}
+
+	testCases := []struct {
+		name                string
+		ref                 *v1beta1.ConnectionSecretReference
+		previousRef         *v1beta1.SecretReference
+		existing            []client.Object
+		expectedError       string
+		expectedSecret      client.ObjectKey
+		expectedData        map[string]string
+		expectedLabels      map[string]string
+		expectOwner         bool
+		expectDeletedSecret *client.ObjectKey
+	}{
+		{
+			name:           "case 0: default secret",
+			expectedSecret: client.ObjectKey{Name: "giantswarm-glippy-loki", Namespace: "loki"},
+			expectedData:   details,
+			expectedLabels: map[string]string{ManagedByLabel: ManagedByValue},
+			expectOwner:    true,
+		},
+		{
+			name: "case 1: secret with templates in another namespace",
+			ref: &v1beta1.ConnectionSecretReference{
+				Name:      "loki-object-store",
+				Namespace: "monitoring",
+				Labels:    map[string]string{"app": "loki"},
+				Templates: map[string]string{
+					"config.yaml": "s3:\n  bucket_name: {{ .bucketName }}\n  region: {{ .region }}",
+					"region":      "{{ .region | printf \"%s-override\" }}",
+				},
+			},
+			expectedSecret: client.ObjectKey{Name: "loki-object-store", Namespace: "monitoring"},
+			expectedData: map[string]string{
+				"bucketName":  "giantswarm-glippy-loki",
+				"region":      "eu-west-1-override",
+				"config.yaml": "s3:\n  bucket_name: giantswarm-glippy-loki\n  region: eu-west-1",
+			},
+			expectedLabels: map[string]string{ManagedByLabel: ManagedByValue, "app": "loki"},
+			expectOwner:    false,
+		},
+		{
+			name: "case 2: template referencing a missing detail",
+			ref: &v1beta1.ConnectionSecretReference{
+				Templates: map[string]string{"config.yaml": "account_key: {{ .accountKey }}"},
+			},
+			expectedError: "failed to render template of key config.yaml",
+		},
+		{
+			name: "case 3: secret owned by someone else",
+			existing: []client.Object{
+				&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "giantswarm-glippy-loki", Namespace: "loki"}},
+			},
+			expectedError: "already exists and is not managed by bucket loki/loki",
+		},
+		{
+			name: "case 4: secret moved to another name",
+			ref:  &v1beta1.ConnectionSecretReference{Name: "loki-object-store"},
+			previousRef: &v1beta1.SecretReference{
+				Name:      "giantswarm-glippy-loki",
+				Namespace: "loki",
+			},
+			existing: []client.Object{
+				&v1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:        "giantswarm-glippy-loki",
+					Namespace:   "loki",
+					Annotations: map[string]string{ConnectionSecretOwnerAnnotation: "loki/loki"},
+				}},
+			},
+			expectedSecret:      client.ObjectKey{Name: "loki-object-store", Namespace: "loki"},
+			expectedData:        details,
+			expectedLabels:      map[string]string{ManagedByLabel: ManagedByValue},
+			expectOwner:         true,
+			expectDeletedSecret: &client.ObjectKey{Name: "giantswarm-glippy-loki", Namespace: "loki"},
+		},
+		{
+			name: "case 5: legacy secret under the default name",
+			existing: []client.Object{
+				&v1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      "giantswarm-glippy-loki",
+					Namespace: "loki",
+					Labels:    map[string]string{ManagedByLabel: ManagedByValue},
+				}},
+			},
+			expectedSecret: client.ObjectKey{Name: "giantswarm-glippy-loki", Namespace: "loki"},
+			expectedData:   details,
+			expectedLabels: map[string]string{ManagedByLabel: ManagedByValue},
+			expectOwner:    true,
+		},
+		{
+			name: "case 6: secret of another bucket only carrying the managed-by label",
+			ref:  &v1beta1.ConnectionSecretReference{Name: "mimir-object-store", Namespace: "mimir"},
+			existing: []client.Object{
+				&v1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      "mimir-object-store",
+					Namespace: "mimir",
+					Labels:    map[string]string{ManagedByLabel: ManagedByValue},
+				}},
+			},
+			expectedError: "already exists and is not managed by bucket loki/loki",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := v1beta1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existing...).Build()
+
+			bucket := &v1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki", UID: "3f1c2a4e-6a1b-4f5e-9c1d-2b7e8f9a0c1d"},
+				Spec:       v1beta1.BucketSpec{Name: "giantswarm-glippy-loki", WriteConnectionSecretToRef: tc.ref},
+				Status:     v1beta1.BucketStatus{ConnectionSecretRef: tc.previousRef},
+			}
+
+			err := PublishConnectionSecret(ctx, fakeClient, bucket, details, "")
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				if bucket.IsConditionTrue(v1beta1.ConditionCredentialsPublished) {
+					t.Fatalf("expected condition %s not to be true", v1beta1.ConditionCredentialsPublished)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expectedRef := v1beta1.SecretReference{Name: tc.expectedSecret.Name, Namespace: tc.expectedSecret.Namespace}
+			if !cmp.Equal(bucket.Status.ConnectionSecretRef, &expectedRef) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(&expectedRef, bucket.Status.ConnectionSecretRef))
+			}
+
+			secret := &v1.Secret{}
+			if err := fakeClient.Get(ctx, tc.expectedSecret, secret); err != nil {
+				t.Fatal(err)
+			}
+			data := map[string]string{}
+			for key, value := range secret.Data {
+				data[key] = string(value)
+			}
+			if !cmp.Equal(data, tc.expectedData) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedData, data))
+			}
+			if !cmp.Equal(secret.Labels, tc.expectedLabels) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedLabels, secret.Labels))
+			}
+			if owned := metav1.IsControlledBy(secret, bucket); owned != tc.expectOwner {
+				t.Fatalf("secret owned by bucket: %t, expected %t", owned, tc.expectOwner)
+			}
+
+			if tc.expectDeletedSecret != nil {
+				err := fakeClient.Get(ctx, *tc.expectDeletedSecret, &v1.Secret{})
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected secret %s to be deleted, got %v", tc.expectDeletedSecret, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package objectstorage
+
+import "errors"
+
+// ErrBucketConflict is returned when the bucket exists in the cloud provider but is owned by someone else.
+// The bucket must not be touched and retrying will not help until the conflict is resolved by a human.
+var ErrBucketConflict = errors.New("bucket is owned by someone else")
+
+// ErrBucketUnmanaged is returned when the bucket exists in the account of the management cluster but was not
+// created by the operator. It can only be managed once adopted.
+var ErrBucketUnmanaged = errors.New("bucket exists but is not managed by the operator")
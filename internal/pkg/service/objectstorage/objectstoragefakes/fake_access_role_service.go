@@ -5,16 +5,16 @@ import (
 	"context"
 	"sync"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 )
 
 type FakeAccessRoleService struct {
-	ConfigureRoleStub        func(context.Context, *v1alpha1.Bucket) error
+	ConfigureRoleStub        func(context.Context, *v1beta1.Bucket) error
 	configureRoleMutex       sync.RWMutex
 	configureRoleArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	configureRoleReturns struct {
 		result1 error
@@ -22,11 +22,11 @@ type FakeAccessRoleService struct {
 	configureRoleReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteRoleStub        func(context.Context, *v1alpha1.Bucket) error
+	DeleteRoleStub        func(context.Context, *v1beta1.Bucket) error
 	deleteRoleMutex       sync.RWMutex
 	deleteRoleArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	deleteRoleReturns struct {
 		result1 error
@@ -38,12 +38,12 @@ type FakeAccessRoleService struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeAccessRoleService) ConfigureRole(arg1 context.Context, arg2 *v1alpha1.Bucket) error {
+func (fake *FakeAccessRoleService) ConfigureRole(arg1 context.Context, arg2 *v1beta1.Bucket) error {
 	fake.configureRoleMutex.Lock()
 	ret, specificReturn := fake.configureRoleReturnsOnCall[len(fake.configureRoleArgsForCall)]
 	fake.configureRoleArgsForCall = append(fake.configureRoleArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.ConfigureRoleStub
 	fakeReturns := fake.configureRoleReturns
@@ -64,13 +64,13 @@ func (fake *FakeAccessRoleService) ConfigureRoleCallCount() int {
 	return len(fake.configureRoleArgsForCall)
 }
 
-func (fake *FakeAccessRoleService) ConfigureRoleCalls(stub func(context.Context, *v1alpha1.Bucket) error) {
+func (fake *FakeAccessRoleService) ConfigureRoleCalls(stub func(context.Context, *v1beta1.Bucket) error) {
 	fake.configureRoleMutex.Lock()
 	defer fake.configureRoleMutex.Unlock()
 	fake.ConfigureRoleStub = stub
 }
 
-func (fake *FakeAccessRoleService) ConfigureRoleArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeAccessRoleService) ConfigureRoleArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.configureRoleMutex.RLock()
 	defer fake.configureRoleMutex.RUnlock()
 	argsForCall := fake.configureRoleArgsForCall[i]
@@ -100,12 +100,12 @@ func (fake *FakeAccessRoleService) ConfigureRoleReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeAccessRoleService) DeleteRole(arg1 context.Context, arg2 *v1alpha1.Bucket) error {
+func (fake *FakeAccessRoleService) DeleteRole(arg1 context.Context, arg2 *v1beta1.Bucket) error {
 	fake.deleteRoleMutex.Lock()
 	ret, specificReturn := fake.deleteRoleReturnsOnCall[len(fake.deleteRoleArgsForCall)]
 	fake.deleteRoleArgsForCall = append(fake.deleteRoleArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.DeleteRoleStub
 	fakeReturns := fake.deleteRoleReturns
@@ -126,13 +126,13 @@ func (fake *FakeAccessRoleService) DeleteRoleCallCount() int {
 	return len(fake.deleteRoleArgsForCall)
 }
 
-func (fake *FakeAccessRoleService) DeleteRoleCalls(stub func(context.Context, *v1alpha1.Bucket) error) {
+func (fake *FakeAccessRoleService) DeleteRoleCalls(stub func(context.Context, *v1beta1.Bucket) error) {
 	fake.deleteRoleMutex.Lock()
 	defer fake.deleteRoleMutex.Unlock()
 	fake.DeleteRoleStub = stub
 }
 
-func (fake *FakeAccessRoleService) DeleteRoleArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeAccessRoleService) DeleteRoleArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.deleteRoleMutex.RLock()
 	defer fake.deleteRoleMutex.RUnlock()
 	argsForCall := fake.deleteRoleArgsForCall[i]
@@ -5,16 +5,28 @@ import (
 	"context"
 	"sync"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 )
 
 type FakeObjectStorageService struct {
-	ConfigureBucketStub        func(context.Context, *v1alpha1.Bucket) error
+	AdoptBucketStub        func(context.Context, *v1beta1.Bucket) error
+	adoptBucketMutex       sync.RWMutex
+	adoptBucketArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1beta1.Bucket
+	}
+	adoptBucketReturns struct {
+		result1 error
+	}
+	adoptBucketReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ConfigureBucketStub        func(context.Context, *v1beta1.Bucket) error
 	configureBucketMutex       sync.RWMutex
 	configureBucketArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	configureBucketReturns struct {
 		result1 error
@@ -22,11 +34,11 @@ type FakeObjectStorageService struct {
 	configureBucketReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateBucketStub        func(context.Context, *v1alpha1.Bucket) error
+	CreateBucketStub        func(context.Context, *v1beta1.Bucket) error
 	createBucketMutex       sync.RWMutex
 	createBucketArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	createBucketReturns struct {
 		result1 error
@@ -34,11 +46,23 @@ type FakeObjectStorageService struct {
 	createBucketReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteBucketStub        func(context.Context, *v1alpha1.Bucket) error
+	DeleteAccessGrantsStub        func(context.Context, *v1beta1.Bucket) error
+	deleteAccessGrantsMutex       sync.RWMutex
+	deleteAccessGrantsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1beta1.Bucket
+	}
+	deleteAccessGrantsReturns struct {
+		result1 error
+	}
+	deleteAccessGrantsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteBucketStub        func(context.Context, *v1beta1.Bucket) error
 	deleteBucketMutex       sync.RWMutex
 	deleteBucketArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	deleteBucketReturns struct {
 		result1 error
@@ -46,11 +70,11 @@ type FakeObjectStorageService struct {
 	deleteBucketReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ExistsBucketStub        func(context.Context, *v1alpha1.Bucket) (bool, error)
+	ExistsBucketStub        func(context.Context, *v1beta1.Bucket) (bool, error)
 	existsBucketMutex       sync.RWMutex
 	existsBucketArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	existsBucketReturns struct {
 		result1 bool
@@ -60,11 +84,11 @@ type FakeObjectStorageService struct {
 		result1 bool
 		result2 error
 	}
-	UpdateBucketStub        func(context.Context, *v1alpha1.Bucket) error
+	UpdateBucketStub        func(context.Context, *v1beta1.Bucket) error
 	updateBucketMutex       sync.RWMutex
 	updateBucketArgsForCall []struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}
 	updateBucketReturns struct {
 		result1 error
@@ -76,12 +100,74 @@ type FakeObjectStorageService struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeObjectStorageService) ConfigureBucket(arg1 context.Context, arg2 *v1alpha1.Bucket) error {
+func (fake *FakeObjectStorageService) AdoptBucket(arg1 context.Context, arg2 *v1beta1.Bucket) error {
+	fake.adoptBucketMutex.Lock()
+	ret, specificReturn := fake.adoptBucketReturnsOnCall[len(fake.adoptBucketArgsForCall)]
+	fake.adoptBucketArgsForCall = append(fake.adoptBucketArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1beta1.Bucket
+	}{arg1, arg2})
+	stub := fake.AdoptBucketStub
+	fakeReturns := fake.adoptBucketReturns
+	fake.recordInvocation("AdoptBucket", []interface{}{arg1, arg2})
+	fake.adoptBucketMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeObjectStorageService) AdoptBucketCallCount() int {
+	fake.adoptBucketMutex.RLock()
+	defer fake.adoptBucketMutex.RUnlock()
+	return len(fake.adoptBucketArgsForCall)
+}
+
+func (fake *FakeObjectStorageService) AdoptBucketCalls(stub func(context.Context, *v1beta1.Bucket) error) {
+	fake.adoptBucketMutex.Lock()
+	defer fake.adoptBucketMutex.Unlock()
+	fake.AdoptBucketStub = stub
+}
+
+func (fake *FakeObjectStorageService) AdoptBucketArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
+	fake.adoptBucketMutex.RLock()
+	defer fake.adoptBucketMutex.RUnlock()
+	argsForCall := fake.adoptBucketArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeObjectStorageService) AdoptBucketReturns(result1 error) {
+	fake.adoptBucketMutex.Lock()
+	defer fake.adoptBucketMutex.Unlock()
+	fake.AdoptBucketStub = nil
+	fake.adoptBucketReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeObjectStorageService) AdoptBucketReturnsOnCall(i int, result1 error) {
+	fake.adoptBucketMutex.Lock()
+	defer fake.adoptBucketMutex.Unlock()
+	fake.AdoptBucketStub = nil
+	if fake.adoptBucketReturnsOnCall == nil {
+		fake.adoptBucketReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.adoptBucketReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeObjectStorageService) ConfigureBucket(arg1 context.Context, arg2 *v1beta1.Bucket) error {
 	fake.configureBucketMutex.Lock()
 	ret, specificReturn := fake.configureBucketReturnsOnCall[len(fake.configureBucketArgsForCall)]
 	fake.configureBucketArgsForCall = append(fake.configureBucketArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.ConfigureBucketStub
 	fakeReturns := fake.configureBucketReturns
@@ -102,13 +188,13 @@ func (fake *FakeObjectStorageService) ConfigureBucketCallCount() int {
 	return len(fake.configureBucketArgsForCall)
 }
 
-func (fake *FakeObjectStorageService) ConfigureBucketCalls(stub func(context.Context, *v1alpha1.Bucket) error) {
+func (fake *FakeObjectStorageService) ConfigureBucketCalls(stub func(context.Context, *v1beta1.Bucket) error) {
 	fake.configureBucketMutex.Lock()
 	defer fake.configureBucketMutex.Unlock()
 	fake.ConfigureBucketStub = stub
 }
 
-func (fake *FakeObjectStorageService) ConfigureBucketArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeObjectStorageService) ConfigureBucketArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.configureBucketMutex.RLock()
 	defer fake.configureBucketMutex.RUnlock()
 	argsForCall := fake.configureBucketArgsForCall[i]
@@ -138,12 +224,12 @@ func (fake *FakeObjectStorageService) ConfigureBucketReturnsOnCall(i int, result
 	}{result1}
 }
 
-func (fake *FakeObjectStorageService) CreateBucket(arg1 context.Context, arg2 *v1alpha1.Bucket) error {
+func (fake *FakeObjectStorageService) CreateBucket(arg1 context.Context, arg2 *v1beta1.Bucket) error {
 	fake.createBucketMutex.Lock()
 	ret, specificReturn := fake.createBucketReturnsOnCall[len(fake.createBucketArgsForCall)]
 	fake.createBucketArgsForCall = append(fake.createBucketArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.CreateBucketStub
 	fakeReturns := fake.createBucketReturns
@@ -164,13 +250,13 @@ func (fake *FakeObjectStorageService) CreateBucketCallCount() int {
 	return len(fake.createBucketArgsForCall)
 }
 
-func (fake *FakeObjectStorageService) CreateBucketCalls(stub func(context.Context, *v1alpha1.Bucket) error) {
+func (fake *FakeObjectStorageService) CreateBucketCalls(stub func(context.Context, *v1beta1.Bucket) error) {
 	fake.createBucketMutex.Lock()
 	defer fake.createBucketMutex.Unlock()
 	fake.CreateBucketStub = stub
 }
 
-func (fake *FakeObjectStorageService) CreateBucketArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeObjectStorageService) CreateBucketArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.createBucketMutex.RLock()
 	defer fake.createBucketMutex.RUnlock()
 	argsForCall := fake.createBucketArgsForCall[i]
@@ -200,12 +286,74 @@ func (fake *FakeObjectStorageService) CreateBucketReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
-func (fake *FakeObjectStorageService) DeleteBucket(arg1 context.Context, arg2 *v1alpha1.Bucket) error {
+func (fake *FakeObjectStorageService) DeleteAccessGrants(arg1 context.Context, arg2 *v1beta1.Bucket) error {
+	fake.deleteAccessGrantsMutex.Lock()
+	ret, specificReturn := fake.deleteAccessGrantsReturnsOnCall[len(fake.deleteAccessGrantsArgsForCall)]
+	fake.deleteAccessGrantsArgsForCall = append(fake.deleteAccessGrantsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1beta1.Bucket
+	}{arg1, arg2})
+	stub := fake.DeleteAccessGrantsStub
+	fakeReturns := fake.deleteAccessGrantsReturns
+	fake.recordInvocation("DeleteAccessGrants", []interface{}{arg1, arg2})
+	fake.deleteAccessGrantsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeObjectStorageService) DeleteAccessGrantsCallCount() int {
+	fake.deleteAccessGrantsMutex.RLock()
+	defer fake.deleteAccessGrantsMutex.RUnlock()
+	return len(fake.deleteAccessGrantsArgsForCall)
+}
+
+func (fake *FakeObjectStorageService) DeleteAccessGrantsCalls(stub func(context.Context, *v1beta1.Bucket) error) {
+	fake.deleteAccessGrantsMutex.Lock()
+	defer fake.deleteAccessGrantsMutex.Unlock()
+	fake.DeleteAccessGrantsStub = stub
+}
+
+func (fake *FakeObjectStorageService) DeleteAccessGrantsArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
+	fake.deleteAccessGrantsMutex.RLock()
+	defer fake.deleteAccessGrantsMutex.RUnlock()
+	argsForCall := fake.deleteAccessGrantsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeObjectStorageService) DeleteAccessGrantsReturns(result1 error) {
+	fake.deleteAccessGrantsMutex.Lock()
+	defer fake.deleteAccessGrantsMutex.Unlock()
+	fake.DeleteAccessGrantsStub = nil
+	fake.deleteAccessGrantsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeObjectStorageService) DeleteAccessGrantsReturnsOnCall(i int, result1 error) {
+	fake.deleteAccessGrantsMutex.Lock()
+	defer fake.deleteAccessGrantsMutex.Unlock()
+	fake.DeleteAccessGrantsStub = nil
+	if fake.deleteAccessGrantsReturnsOnCall == nil {
+		fake.deleteAccessGrantsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAccessGrantsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeObjectStorageService) DeleteBucket(arg1 context.Context, arg2 *v1beta1.Bucket) error {
 	fake.deleteBucketMutex.Lock()
 	ret, specificReturn := fake.deleteBucketReturnsOnCall[len(fake.deleteBucketArgsForCall)]
 	fake.deleteBucketArgsForCall = append(fake.deleteBucketArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.DeleteBucketStub
 	fakeReturns := fake.deleteBucketReturns
@@ -226,13 +374,13 @@ func (fake *FakeObjectStorageService) DeleteBucketCallCount() int {
 	return len(fake.deleteBucketArgsForCall)
 }
 
-func (fake *FakeObjectStorageService) DeleteBucketCalls(stub func(context.Context, *v1alpha1.Bucket) error) {
+func (fake *FakeObjectStorageService) DeleteBucketCalls(stub func(context.Context, *v1beta1.Bucket) error) {
 	fake.deleteBucketMutex.Lock()
 	defer fake.deleteBucketMutex.Unlock()
 	fake.DeleteBucketStub = stub
 }
 
-func (fake *FakeObjectStorageService) DeleteBucketArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeObjectStorageService) DeleteBucketArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.deleteBucketMutex.RLock()
 	defer fake.deleteBucketMutex.RUnlock()
 	argsForCall := fake.deleteBucketArgsForCall[i]
@@ -262,12 +410,12 @@ func (fake *FakeObjectStorageService) DeleteBucketReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
-func (fake *FakeObjectStorageService) ExistsBucket(arg1 context.Context, arg2 *v1alpha1.Bucket) (bool, error) {
+func (fake *FakeObjectStorageService) ExistsBucket(arg1 context.Context, arg2 *v1beta1.Bucket) (bool, error) {
 	fake.existsBucketMutex.Lock()
 	ret, specificReturn := fake.existsBucketReturnsOnCall[len(fake.existsBucketArgsForCall)]
 	fake.existsBucketArgsForCall = append(fake.existsBucketArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.ExistsBucketStub
 	fakeReturns := fake.existsBucketReturns
@@ -288,13 +436,13 @@ func (fake *FakeObjectStorageService) ExistsBucketCallCount() int {
 	return len(fake.existsBucketArgsForCall)
 }
 
-func (fake *FakeObjectStorageService) ExistsBucketCalls(stub func(context.Context, *v1alpha1.Bucket) (bool, error)) {
+func (fake *FakeObjectStorageService) ExistsBucketCalls(stub func(context.Context, *v1beta1.Bucket) (bool, error)) {
 	fake.existsBucketMutex.Lock()
 	defer fake.existsBucketMutex.Unlock()
 	fake.ExistsBucketStub = stub
 }
 
-func (fake *FakeObjectStorageService) ExistsBucketArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeObjectStorageService) ExistsBucketArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.existsBucketMutex.RLock()
 	defer fake.existsBucketMutex.RUnlock()
 	argsForCall := fake.existsBucketArgsForCall[i]
@@ -327,12 +475,12 @@ func (fake *FakeObjectStorageService) ExistsBucketReturnsOnCall(i int, result1 b
 	}{result1, result2}
 }
 
-func (fake *FakeObjectStorageService) UpdateBucket(arg1 context.Context, arg2 *v1alpha1.Bucket) error {
+func (fake *FakeObjectStorageService) UpdateBucket(arg1 context.Context, arg2 *v1beta1.Bucket) error {
 	fake.updateBucketMutex.Lock()
 	ret, specificReturn := fake.updateBucketReturnsOnCall[len(fake.updateBucketArgsForCall)]
 	fake.updateBucketArgsForCall = append(fake.updateBucketArgsForCall, struct {
 		arg1 context.Context
-		arg2 *v1alpha1.Bucket
+		arg2 *v1beta1.Bucket
 	}{arg1, arg2})
 	stub := fake.UpdateBucketStub
 	fakeReturns := fake.updateBucketReturns
@@ -353,13 +501,13 @@ func (fake *FakeObjectStorageService) UpdateBucketCallCount() int {
 	return len(fake.updateBucketArgsForCall)
 }
 
-func (fake *FakeObjectStorageService) UpdateBucketCalls(stub func(context.Context, *v1alpha1.Bucket) error) {
+func (fake *FakeObjectStorageService) UpdateBucketCalls(stub func(context.Context, *v1beta1.Bucket) error) {
 	fake.updateBucketMutex.Lock()
 	defer fake.updateBucketMutex.Unlock()
 	fake.UpdateBucketStub = stub
 }
 
-func (fake *FakeObjectStorageService) UpdateBucketArgsForCall(i int) (context.Context, *v1alpha1.Bucket) {
+func (fake *FakeObjectStorageService) UpdateBucketArgsForCall(i int) (context.Context, *v1beta1.Bucket) {
 	fake.updateBucketMutex.RLock()
 	defer fake.updateBucketMutex.RUnlock()
 	argsForCall := fake.updateBucketArgsForCall[i]
@@ -392,10 +540,14 @@ func (fake *FakeObjectStorageService) UpdateBucketReturnsOnCall(i int, result1 e
 func (fake *FakeObjectStorageService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.adoptBucketMutex.RLock()
+	defer fake.adoptBucketMutex.RUnlock()
 	fake.configureBucketMutex.RLock()
 	defer fake.configureBucketMutex.RUnlock()
 	fake.createBucketMutex.RLock()
 	defer fake.createBucketMutex.RUnlock()
+	fake.deleteAccessGrantsMutex.RLock()
+	defer fake.deleteAccessGrantsMutex.RUnlock()
 	fake.deleteBucketMutex.RLock()
 	defer fake.deleteBucketMutex.RUnlock()
 	fake.existsBucketMutex.RLock()
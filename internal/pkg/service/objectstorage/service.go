@@ -3,23 +3,31 @@ package objectstorage
 import (
 	"context"
 
-	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ObjectStorageService
 type ObjectStorageService interface {
 	// Configure all bucket related configurations (Tags, ...)
-	ConfigureBucket(ctx context.Context, bucket *v1alpha1.Bucket) error
-	CreateBucket(ctx context.Context, bucket *v1alpha1.Bucket) error
-	UpdateBucket(ctx context.Context, bucket *v1alpha1.Bucket) error
-	DeleteBucket(ctx context.Context, bucket *v1alpha1.Bucket) error
+	ConfigureBucket(ctx context.Context, bucket *v1beta1.Bucket) error
+	CreateBucket(ctx context.Context, bucket *v1beta1.Bucket) error
+	UpdateBucket(ctx context.Context, bucket *v1beta1.Bucket) error
+	DeleteBucket(ctx context.Context, bucket *v1beta1.Bucket) error
+	// DeleteAccessGrants revokes the access granted on the bucket to existing principals. The bucket itself may be
+	// retained.
+	DeleteAccessGrants(ctx context.Context, bucket *v1beta1.Bucket) error
 	// Exists checks whether a bucket exists in the current account.
-	ExistsBucket(ctx context.Context, bucket *v1alpha1.Bucket) (bool, error)
+	// It returns ErrBucketConflict when the bucket exists but is owned by someone else, and
+	// ErrBucketUnmanaged when the bucket exists in the current account but is not managed by the operator.
+	ExistsBucket(ctx context.Context, bucket *v1beta1.Bucket) (bool, error)
+	// AdoptBucket records the state of an existing bucket in the status of the Bucket before the operator manages it.
+	// The ownership tags are stamped on the bucket by the following update and configuration.
+	AdoptBucket(ctx context.Context, bucket *v1beta1.Bucket) error
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . AccessRoleService
 type AccessRoleService interface {
 	// Configure the role to access the bucket
-	ConfigureRole(ctx context.Context, bucket *v1alpha1.Bucket) error
-	DeleteRole(ctx context.Context, bucket *v1alpha1.Bucket) error
+	ConfigureRole(ctx context.Context, bucket *v1beta1.Bucket) error
+	DeleteRole(ctx context.Context, bucket *v1beta1.Bucket) error
 }
@@ -0,0 +1,86 @@
+package storageversion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+// BucketCRDName is the name of the Bucket CustomResourceDefinition.
+const BucketCRDName = "buckets.objectstorage.giantswarm.io"
+
+// BucketMigrator rewrites all Buckets in the storage version and then removes the previous
+// versions from the stored versions of the CRD, so that they can be dropped in a future release.
+type BucketMigrator struct {
+	Client client.Client
+	// APIReader reads objects directly from the api-server, so no informer is started on CRDs.
+	APIReader client.Reader
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so only one replica migrates the Buckets.
+func (m BucketMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable. A failed migration is logged and retried on the next start
+// as it does not prevent Buckets from being reconciled.
+func (m BucketMigrator) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("storage-version-migrator")
+	if err := m.Migrate(ctx); err != nil {
+		logger.Error(err, "failed to migrate buckets to the storage version")
+		return nil
+	}
+	logger.Info("Buckets are stored in the storage version", "version", v1beta1.GroupVersion.Version)
+	return nil
+}
+
+// Migrate rewrites all Buckets in the storage version if the CRD still lists other stored versions.
+func (m BucketMigrator) Migrate(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	err := m.APIReader.Get(ctx, client.ObjectKey{Name: BucketCRDName}, crd)
+	if err != nil {
+		return fmt.Errorf("failed to get CRD %s: %w", BucketCRDName, err)
+	}
+
+	storageVersion := v1beta1.GroupVersion.Version
+	if slices.Equal(crd.Status.StoredVersions, []string{storageVersion}) {
+		return nil
+	}
+
+	buckets := &v1beta1.BucketList{}
+	err = m.APIReader.List(ctx, buckets)
+	if err != nil {
+		return fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	for _, item := range buckets.Items {
+		key := client.ObjectKeyFromObject(&item)
+		// An update without changes is enough for the api-server to write the object in the storage version.
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			bucket := &v1beta1.Bucket{}
+			if err := m.APIReader.Get(ctx, key, bucket); err != nil {
+				return client.IgnoreNotFound(err)
+			}
+			return client.IgnoreNotFound(m.Client.Update(ctx, bucket))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate bucket %s: %w", key, err)
+		}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := m.APIReader.Get(ctx, client.ObjectKey{Name: BucketCRDName}, crd)
+		if err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		return m.Client.Status().Update(ctx, crd)
+	})
+}
@@ -0,0 +1,106 @@
+package storageversion
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
+)
+
+func Test_Migrate(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		storedVersions         []string
+		expectedStoredVersions []string
+		expectRewrite          bool
+	}{
+		{
+			name:                   "case 0: buckets stored in v1alpha1 are rewritten",
+			storedVersions:         []string{"v1alpha1", "v1beta1"},
+			expectedStoredVersions: []string{"v1beta1"},
+			expectRewrite:          true,
+		},
+		{
+			name:                   "case 1: buckets only stored in v1alpha1 are rewritten",
+			storedVersions:         []string{"v1alpha1"},
+			expectedStoredVersions: []string{"v1beta1"},
+			expectRewrite:          true,
+		},
+		{
+			name:                   "case 2: buckets already migrated are left untouched",
+			storedVersions:         []string{"v1beta1"},
+			expectedStoredVersions: []string{"v1beta1"},
+			expectRewrite:          false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			if err := v1beta1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			crd := &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: BucketCRDName},
+				Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: tc.storedVersions},
+			}
+			buckets := []client.Object{
+				&v1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "loki"}, Spec: v1beta1.BucketSpec{Name: "giantswarm-glippy-loki"}},
+				&v1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: "mimir", Namespace: "mimir"}, Spec: v1beta1.BucketSpec{Name: "giantswarm-glippy-mimir"}},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(crd).
+				WithObjects(buckets...).
+				WithStatusSubresource(crd).
+				Build()
+
+			resourceVersions := map[client.ObjectKey]string{}
+			for _, bucket := range buckets {
+				existing := &v1beta1.Bucket{}
+				if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(bucket), existing); err != nil {
+					t.Fatal(err)
+				}
+				resourceVersions[client.ObjectKeyFromObject(bucket)] = existing.ResourceVersion
+			}
+
+			migrator := BucketMigrator{Client: fakeClient, APIReader: fakeClient}
+			if err := migrator.Migrate(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for key, resourceVersion := range resourceVersions {
+				existing := &v1beta1.Bucket{}
+				if err := fakeClient.Get(ctx, key, existing); err != nil {
+					t.Fatal(err)
+				}
+				if rewritten := existing.ResourceVersion != resourceVersion; rewritten != tc.expectRewrite {
+					t.Fatalf("bucket %s rewritten: %t, expected %t", key, rewritten, tc.expectRewrite)
+				}
+			}
+
+			existingCRD := &apiextensionsv1.CustomResourceDefinition{}
+			if err := fakeClient.Get(ctx, client.ObjectKey{Name: BucketCRDName}, existingCRD); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(existingCRD.Status.StoredVersions, tc.expectedStoredVersions) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedStoredVersions, existingCRD.Status.StoredVersions))
+			}
+		})
+	}
+}
@@ -0,0 +1,1086 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	objectstoragev1beta1 "github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/aws"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/azure"
+)
+
+const (
+	ProviderCAPA = "capa"
+	ProviderCAPZ = "capz"
+
+	// maxTags is the maximum number of tags both S3 and Azure accept on a resource.
+	maxTags = 50
+	// maxLifecycleRuleTags is the maximum number of tags both S3 and Azure accept in the filter of a lifecycle rule.
+	maxLifecycleRuleTags = 10
+
+	// userAssignedIdentityResourceType is the resource type of the identities storage accounts read Key Vault keys with.
+	userAssignedIdentityResourceType = "Microsoft.ManagedIdentity/userAssignedIdentities"
+
+	// Bounds of the lifetime of SAS tokens. User delegation keys are valid for 7 days at most.
+	minSASLifetime = time.Hour
+	maxSASLifetime = 7 * 24 * time.Hour
+)
+
+var (
+	bucketlog = logf.Log.WithName("bucket-resource")
+
+	// See https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html
+	s3BucketNameRegexp      = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	s3ForbiddenNamePrefixes = []string{"xn--", "sthree-", "amzn-s3-demo-"}
+	s3ForbiddenNameSuffixes = []string{"-s3alias", "--ol-s3", ".mrap", "--x-s3", "--table-s3"}
+
+	// See https://learn.microsoft.com/en-us/rest/api/storageservices/naming-and-referencing-containers--blobs--and-metadata#container-names
+	azureContainerNameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{1,61}[a-z0-9])$`)
+	azureForbiddenTagChars   = `<>%&\?/`
+
+	// See https://learn.microsoft.com/en-us/azure/storage/blobs/lifecycle-management-overview#lifecycle-management-rule-definition
+	azureLifecycleRuleIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+	// Storage classes lifecycle rules transition objects to on S3 and Azure.
+	awsStorageClasses = []objectstoragev1beta1.StorageClass{
+		objectstoragev1beta1.StorageClassStandardIA,
+		objectstoragev1beta1.StorageClassIntelligentTiering,
+		objectstoragev1beta1.StorageClassGlacierIR,
+		objectstoragev1beta1.StorageClassDeepArchive,
+	}
+	azureStorageClasses = []objectstoragev1beta1.StorageClass{
+		objectstoragev1beta1.StorageClassCool,
+		objectstoragev1beta1.StorageClassCold,
+		objectstoragev1beta1.StorageClassArchive,
+	}
+	// IDs of the rules implementing spec.lifecycle.expiration on S3 and Azure.
+	reservedLifecycleRuleIDs = []string{aws.LifecycleRuleID, aws.AbortIncompleteMultipartUploadRuleID, azure.LifecycleRuleName}
+
+	// Object lock modes of S3 buckets and states of Azure container immutability policies.
+	awsObjectLockModes   = []objectstoragev1beta1.ObjectLockMode{objectstoragev1beta1.ObjectLockModeGovernance, objectstoragev1beta1.ObjectLockModeCompliance}
+	azureObjectLockModes = []objectstoragev1beta1.ObjectLockMode{objectstoragev1beta1.ObjectLockModeUnlocked, objectstoragev1beta1.ObjectLockModeLocked}
+
+	// See https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateRole.html
+	iamRoleNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
+	// See https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateRole.html
+	iamPathRegexp = regexp.MustCompile(`^/([\x21-\x7E]*/)?$`)
+	// See https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules#microsoftmanagedidentity
+	managedIdentityNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{2,127}$`)
+	// Object IDs of Microsoft Entra principals.
+	principalIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// SetupBucketWebhookWithManager registers the webhook for Bucket in the manager.
+// The validator reads the other buckets through the API reader of the manager.
+func SetupBucketWebhookWithManager(mgr ctrl.Manager, validator BucketCustomValidator) error {
+	validator.Client = mgr.GetAPIReader()
+	return ctrl.NewWebhookManagedBy(mgr, &objectstoragev1beta1.Bucket{}).
+		WithValidator(&validator).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-objectstorage-giantswarm-io-v1beta1-bucket,mutating=false,failurePolicy=fail,sideEffects=None,groups=objectstorage.giantswarm.io,resources=buckets,verbs=create;update,versions=v1beta1,name=vbucket-v1beta1.objectstorage.giantswarm.io,admissionReviewVersions=v1
+
+// BucketCustomValidator validates Bucket specs against the naming rules of the management cluster provider
+// and against the other buckets of the cluster.
+type BucketCustomValidator struct {
+	Client   client.Reader
+	Provider string
+	// ConnectionSecretNamespaces are the namespaces connection Secrets may be published in besides the namespace of
+	// the Bucket.
+	ConnectionSecretNamespaces []string
+	// AccessRoleDefaults allow the permissions boundaries and managed policies of the access roles on CAPA.
+	AccessRoleDefaults aws.AccessRoleDefaults
+}
+
+var _ admission.Validator[*objectstoragev1beta1.Bucket] = &BucketCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type Bucket.
+func (v *BucketCustomValidator) ValidateCreate(ctx context.Context, bucket *objectstoragev1beta1.Bucket) (admission.Warnings, error) {
+	bucketlog.Info("validation for Bucket upon creation", "name", bucket.GetName(), "namespace", bucket.GetNamespace())
+
+	warnings, allErrs := v.validateSpec(bucket)
+	if len(allErrs) == 0 {
+		collisionErrs, err := v.validateCollisions(ctx, bucket)
+		if err != nil {
+			return warnings, apierrors.NewInternalError(err)
+		}
+		allErrs = append(allErrs, collisionErrs...)
+	}
+
+	return warnings, v.toError(bucket, allErrs)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type Bucket.
+func (v *BucketCustomValidator) ValidateUpdate(ctx context.Context, oldBucket, newBucket *objectstoragev1beta1.Bucket) (admission.Warnings, error) {
+	bucketlog.Info("validation for Bucket upon update", "name", newBucket.GetName(), "namespace", newBucket.GetNamespace())
+
+	// Buckets created before this webhook existed may not satisfy all the rules. Metadata only updates,
+	// like the finalizer being removed on deletion, must never be blocked.
+	if apiequality.Semantic.DeepEqual(oldBucket.Spec, newBucket.Spec) {
+		return nil, nil
+	}
+
+	var allErrs field.ErrorList
+	if oldBucket.Spec.Name != newBucket.Spec.Name {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "name"), "field is immutable"))
+	}
+
+	allErrs = append(allErrs, validateObjectLockUpdate(field.NewPath("spec", "objectLock"), oldBucket.Spec.ObjectLock, newBucket.Spec.ObjectLock)...)
+
+	warnings, specErrs := v.validateSpec(newBucket)
+	allErrs = append(allErrs, specErrs...)
+
+	return warnings, v.toError(newBucket, allErrs)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type Bucket.
+func (v *BucketCustomValidator) ValidateDelete(ctx context.Context, bucket *objectstoragev1beta1.Bucket) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *BucketCustomValidator) validateSpec(bucket *objectstoragev1beta1.Bucket) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	allErrs = append(allErrs, v.validateBucketName(specPath.Child("name"), bucket.Spec.Name)...)
+	if v.Provider == ProviderCAPZ && len(allErrs) == 0 {
+		storageAccountName := azure.SanitizeStorageAccountName(bucket.Spec.Name)
+		if storageAccountName != strings.ReplaceAll(bucket.Spec.Name, "-", "") {
+			warnings = append(warnings, fmt.Sprintf("spec.name is truncated to %q to name the Azure storage account", storageAccountName))
+		}
+	}
+
+	if days := bucket.ExpirationDays(); days != nil && *days <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("lifecycle", "expiration", "days"), *days, "must be greater than 0"))
+	}
+
+	if lifecycle := bucket.Spec.Lifecycle; lifecycle != nil && lifecycle.AbortIncompleteMultipartUploadDays != nil {
+		if days := *lifecycle.AbortIncompleteMultipartUploadDays; days < 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("lifecycle", "abortIncompleteMultipartUploadDays"), days, "must be greater than or equal to 0"))
+		}
+	}
+
+	allErrs = append(allErrs, v.validateLifecycleRules(specPath.Child("lifecycle", "rules"), bucket.LifecycleRules())...)
+
+	switch bucket.Spec.Versioning {
+	case "", objectstoragev1beta1.VersioningEnabled, objectstoragev1beta1.VersioningSuspended:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("versioning"), bucket.Spec.Versioning,
+			[]objectstoragev1beta1.VersioningStatus{objectstoragev1beta1.VersioningEnabled, objectstoragev1beta1.VersioningSuspended}))
+	}
+	if bucket.Spec.Versioning == "" && slices.ContainsFunc(bucket.LifecycleRules(), func(rule objectstoragev1beta1.BucketLifecycleRule) bool {
+		return rule.NoncurrentVersionExpiration != nil || rule.ExpiredObjectDeleteMarker
+	}) {
+		warnings = append(warnings, "spec.lifecycle.rules clean up previous versions but spec.versioning is not set")
+	}
+
+	if bucket.Spec.ObjectLock != nil {
+		objectLockWarnings, objectLockErrs := v.validateObjectLock(specPath.Child("objectLock"), bucket)
+		warnings = append(warnings, objectLockWarnings...)
+		allErrs = append(allErrs, objectLockErrs...)
+	}
+
+	if bucket.Spec.BucketPolicy != nil {
+		if v.Provider == ProviderCAPZ {
+			warnings = append(warnings, "spec.bucketPolicy is ignored on Azure")
+		} else {
+			allErrs = append(allErrs, validateBucketPolicy(specPath.Child("bucketPolicy"), bucket.Spec.Name, bucket.Spec.BucketPolicy)...)
+		}
+	}
+
+	if bucket.Spec.Encryption != nil {
+		encryptionWarnings, encryptionErrs := v.validateEncryption(specPath.Child("encryption"), bucket.Spec.Encryption)
+		warnings = append(warnings, encryptionWarnings...)
+		allErrs = append(allErrs, encryptionErrs...)
+	}
+
+	switch bucket.Spec.ReclaimPolicy {
+	case "", objectstoragev1beta1.ReclaimPolicyRetain, objectstoragev1beta1.ReclaimPolicyDelete:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("reclaimPolicy"), bucket.Spec.ReclaimPolicy,
+			[]objectstoragev1beta1.ReclaimPolicy{objectstoragev1beta1.ReclaimPolicyRetain, objectstoragev1beta1.ReclaimPolicyDelete}))
+	}
+
+	if bucket.Spec.BucketClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(bucket.Spec.BucketClassName) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("bucketClassName"), bucket.Spec.BucketClassName, msg))
+		}
+	}
+	if parameters := bucket.Spec.Parameters; parameters != nil {
+		if v.Provider == ProviderCAPA && parameters.Azure != nil {
+			warnings = append(warnings, "spec.parameters.azure is ignored on AWS")
+		}
+		if v.Provider == ProviderCAPZ && parameters.AWS != nil {
+			warnings = append(warnings, "spec.parameters.aws is ignored on Azure")
+		}
+		if parameters.AWS != nil {
+			awsWarnings, awsErrs := v.validateAWSParameters(specPath.Child("parameters", "aws"), parameters.AWS)
+			warnings = append(warnings, awsWarnings...)
+			allErrs = append(allErrs, awsErrs...)
+		}
+		if parameters.Azure != nil && parameters.Azure.Credentials != nil {
+			credentialsWarnings, credentialsErrs := validateAzureCredentials(specPath.Child("parameters", "azure", "credentials"), parameters.Azure.Credentials)
+			warnings = append(warnings, credentialsWarnings...)
+			allErrs = append(allErrs, credentialsErrs...)
+		}
+	}
+
+	switch bucket.Spec.AdoptionPolicy {
+	case "", objectstoragev1beta1.AdoptionPolicyAdopt, objectstoragev1beta1.AdoptionPolicyFailIfExists:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("adoptionPolicy"), bucket.Spec.AdoptionPolicy,
+			[]objectstoragev1beta1.AdoptionPolicy{objectstoragev1beta1.AdoptionPolicyAdopt, objectstoragev1beta1.AdoptionPolicyFailIfExists}))
+	}
+	if bucket.Spec.AllowAdoptedBucketDeletion && bucket.Spec.ReclaimPolicy != objectstoragev1beta1.ReclaimPolicyDelete {
+		warnings = append(warnings, "spec.allowAdoptedBucketDeletion has no effect unless spec.reclaimPolicy is Delete")
+	}
+
+	allErrs = append(allErrs, v.validateTags(specPath.Child("tags"), bucket.Spec.Tags)...)
+
+	if len(bucket.Spec.AccessGrants) != 0 {
+		if v.Provider == ProviderCAPA {
+			warnings = append(warnings, "spec.accessGrants is ignored on AWS")
+		}
+		allErrs = append(allErrs, validateAccessGrants(specPath.Child("accessGrants"), bucket.Spec.AccessGrants)...)
+	}
+
+	if bucket.Spec.Access != nil && bucket.Spec.Access.Role != nil {
+		accessRoleWarnings, accessRoleErrs := v.validateAccessRole(specPath.Child("access", "role"), bucket.Spec.Access.Role)
+		warnings = append(warnings, accessRoleWarnings...)
+		allErrs = append(allErrs, accessRoleErrs...)
+	}
+
+	if bucket.Spec.WriteConnectionSecretToRef != nil {
+		connectionSecretWarnings, connectionSecretErrs := v.validateConnectionSecretRef(specPath.Child("writeConnectionSecretToRef"), bucket)
+		warnings = append(warnings, connectionSecretWarnings...)
+		allErrs = append(allErrs, connectionSecretErrs...)
+	}
+
+	return warnings, allErrs
+}
+
+func (v *BucketCustomValidator) validateLifecycleRules(path *field.Path, rules []objectstoragev1beta1.BucketLifecycleRule) field.ErrorList {
+	var allErrs field.ErrorList
+	ids := map[string]bool{}
+	for i, rule := range rules {
+		rulePath := path.Index(i)
+		idPath := rulePath.Child("id")
+		switch {
+		case rule.ID == "":
+			allErrs = append(allErrs, field.Required(idPath, ""))
+		case ids[rule.ID]:
+			allErrs = append(allErrs, field.Duplicate(idPath, rule.ID))
+		case slices.Contains(reservedLifecycleRuleIDs, rule.ID):
+			allErrs = append(allErrs, field.Invalid(idPath, rule.ID, "is reserved for spec.lifecycle.expiration"))
+		case v.Provider == ProviderCAPZ && !azureLifecycleRuleIDRegexp.MatchString(rule.ID):
+			allErrs = append(allErrs, field.Invalid(idPath, rule.ID, "must consist of alphanumeric characters on Azure"))
+		}
+		ids[rule.ID] = true
+
+		if rule.Filter != nil {
+			tagKeys := map[string]bool{}
+			for j, tag := range rule.Filter.Tags {
+				keyPath := rulePath.Child("filter", "tags").Index(j).Child("key")
+				if tag.Key == "" {
+					allErrs = append(allErrs, field.Required(keyPath, ""))
+				} else if tagKeys[tag.Key] {
+					allErrs = append(allErrs, field.Duplicate(keyPath, tag.Key))
+				}
+				tagKeys[tag.Key] = true
+			}
+			if len(rule.Filter.Tags) > maxLifecycleRuleTags {
+				allErrs = append(allErrs, field.TooMany(rulePath.Child("filter", "tags"), len(rule.Filter.Tags), maxLifecycleRuleTags))
+			}
+		}
+
+		expiration := rule.Expiration
+		expirationPath := rulePath.Child("expiration")
+		switch {
+		case expiration == nil && len(rule.Transitions) == 0 && rule.NoncurrentVersionExpiration == nil && !rule.ExpiredObjectDeleteMarker:
+			allErrs = append(allErrs, field.Required(expirationPath, "a lifecycle rule needs an expiration, transitions, a noncurrent version expiration or expiredObjectDeleteMarker"))
+		case expiration == nil:
+			// The rule only transitions objects or cleans up previous versions.
+		case expiration.Days == 0 && expiration.Date == "":
+			allErrs = append(allErrs, field.Required(expirationPath, "one of days or date is required"))
+		case expiration.Days != 0 && expiration.Date != "":
+			allErrs = append(allErrs, field.Invalid(expirationPath, expiration, "days and date are mutually exclusive"))
+		case expiration.Days < 0:
+			allErrs = append(allErrs, field.Invalid(expirationPath.Child("days"), expiration.Days, "must be greater than 0"))
+		case expiration.Date != "" && v.Provider == ProviderCAPZ:
+			allErrs = append(allErrs, field.Forbidden(expirationPath.Child("date"), "expiration dates are not supported on Azure"))
+		case expiration.Date != "":
+			if _, err := time.Parse(time.DateOnly, expiration.Date); err != nil {
+				allErrs = append(allErrs, field.Invalid(expirationPath.Child("date"), expiration.Date, "must be formatted as YYYY-MM-DD"))
+			}
+		}
+
+		allErrs = append(allErrs, v.validateLifecycleTransitions(rulePath.Child("transitions"), rule)...)
+		allErrs = append(allErrs, v.validateLifecycleVersions(rulePath, rule)...)
+	}
+	return allErrs
+}
+
+func (v *BucketCustomValidator) validateLifecycleVersions(path *field.Path, rule objectstoragev1beta1.BucketLifecycleRule) field.ErrorList {
+	var allErrs field.ErrorList
+	if noncurrent := rule.NoncurrentVersionExpiration; noncurrent != nil {
+		noncurrentPath := path.Child("noncurrentVersionExpiration")
+		if noncurrent.Days <= 0 {
+			allErrs = append(allErrs, field.Invalid(noncurrentPath.Child("days"), noncurrent.Days, "must be greater than 0"))
+		}
+		switch {
+		case noncurrent.NewerNoncurrentVersions == 0:
+		case v.Provider == ProviderCAPZ:
+			allErrs = append(allErrs, field.Forbidden(noncurrentPath.Child("newerNoncurrentVersions"), "newer noncurrent versions are not supported on Azure"))
+		case noncurrent.NewerNoncurrentVersions < 0:
+			allErrs = append(allErrs, field.Invalid(noncurrentPath.Child("newerNoncurrentVersions"), noncurrent.NewerNoncurrentVersions, "must be greater than 0"))
+		}
+	}
+
+	if rule.ExpiredObjectDeleteMarker {
+		deleteMarkerPath := path.Child("expiredObjectDeleteMarker")
+		switch {
+		case v.Provider == ProviderCAPZ:
+			allErrs = append(allErrs, field.Forbidden(deleteMarkerPath, "Azure does not create delete markers"))
+		case rule.Expiration != nil:
+			allErrs = append(allErrs, field.Forbidden(deleteMarkerPath, "cannot be combined with an expiration on AWS"))
+		}
+	}
+	return allErrs
+}
+
+func (v *BucketCustomValidator) validateLifecycleTransitions(path *field.Path, rule objectstoragev1beta1.BucketLifecycleRule) field.ErrorList {
+	var allErrs field.ErrorList
+	supportedStorageClasses := awsStorageClasses
+	if v.Provider == ProviderCAPZ {
+		supportedStorageClasses = azureStorageClasses
+	}
+
+	storageClasses := map[objectstoragev1beta1.StorageClass]bool{}
+	for i, transition := range rule.Transitions {
+		transitionPath := path.Index(i)
+		storageClassPath := transitionPath.Child("storageClass")
+		switch {
+		case !slices.Contains(supportedStorageClasses, transition.StorageClass):
+			allErrs = append(allErrs, field.NotSupported(storageClassPath, transition.StorageClass, supportedStorageClasses))
+		case storageClasses[transition.StorageClass]:
+			allErrs = append(allErrs, field.Duplicate(storageClassPath, transition.StorageClass))
+		}
+		storageClasses[transition.StorageClass] = true
+
+		daysPath := transitionPath.Child("days")
+		switch {
+		case transition.Days <= 0:
+			allErrs = append(allErrs, field.Invalid(daysPath, transition.Days, "must be greater than 0"))
+		case transition.StorageClass == objectstoragev1beta1.StorageClassStandardIA && transition.Days < 30:
+			allErrs = append(allErrs, field.Invalid(daysPath, transition.Days, "must be at least 30 to transition to STANDARD_IA"))
+		case rule.Expiration != nil && rule.Expiration.Days > 0 && transition.Days >= rule.Expiration.Days:
+			allErrs = append(allErrs, field.Invalid(daysPath, transition.Days, "must be lower than the expiration days of the rule"))
+		}
+	}
+	return allErrs
+}
+
+func (v *BucketCustomValidator) validateObjectLock(path *field.Path, bucket *objectstoragev1beta1.Bucket) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	objectLock := bucket.Spec.ObjectLock
+
+	supportedModes := awsObjectLockModes
+	if v.Provider == ProviderCAPZ {
+		supportedModes = azureObjectLockModes
+	}
+	if !slices.Contains(supportedModes, objectLock.Mode) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("mode"), objectLock.Mode, supportedModes))
+	}
+	if objectLock.RetentionDays <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("retentionDays"), objectLock.RetentionDays, "must be greater than 0"))
+	}
+	if v.Provider == ProviderCAPA && bucket.Spec.Versioning == objectstoragev1beta1.VersioningSuspended {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "versioning"), "versioning cannot be suspended on buckets with an object lock"))
+	}
+
+	if bucket.Spec.ReclaimPolicy == objectstoragev1beta1.ReclaimPolicyDelete {
+		warnings = append(warnings, "buckets with spec.objectLock are never deleted by the operator, whatever spec.reclaimPolicy")
+	}
+	return warnings, allErrs
+}
+
+// validateObjectLockUpdate prevents weakening the retention of a bucket: object locks cannot be removed, and compliance
+// and locked retentions can neither be downgraded nor shortened.
+func validateObjectLockUpdate(path *field.Path, oldObjectLock, newObjectLock *objectstoragev1beta1.BucketObjectLock) field.ErrorList {
+	var allErrs field.ErrorList
+	if oldObjectLock == nil {
+		return nil
+	}
+	if newObjectLock == nil {
+		return append(allErrs, field.Forbidden(path, "an object lock cannot be removed"))
+	}
+
+	if oldObjectLock.Mode != objectstoragev1beta1.ObjectLockModeCompliance && oldObjectLock.Mode != objectstoragev1beta1.ObjectLockModeLocked {
+		return nil
+	}
+	if newObjectLock.Mode != oldObjectLock.Mode {
+		allErrs = append(allErrs, field.Forbidden(path.Child("mode"), fmt.Sprintf("cannot be changed once %s", oldObjectLock.Mode)))
+	}
+	if oldObjectLock.Mode == objectstoragev1beta1.ObjectLockModeLocked && newObjectLock.RetentionDays < oldObjectLock.RetentionDays {
+		allErrs = append(allErrs, field.Forbidden(path.Child("retentionDays"), "locked retentions can only be extended"))
+	}
+	return allErrs
+}
+
+func (v *BucketCustomValidator) validateAWSParameters(path *field.Path, parameters *objectstoragev1beta1.AWSBucketParameters) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	switch parameters.ObjectOwnership {
+	case "", objectstoragev1beta1.ObjectOwnershipBucketOwnerEnforced:
+	case objectstoragev1beta1.ObjectOwnershipBucketOwnerPreferred, objectstoragev1beta1.ObjectOwnershipObjectWriter:
+		if v.Provider == ProviderCAPA {
+			warnings = append(warnings, fmt.Sprintf("%s enables ACLs on the bucket", path.Child("objectOwnership")))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("objectOwnership"), parameters.ObjectOwnership,
+			[]objectstoragev1beta1.ObjectOwnership{objectstoragev1beta1.ObjectOwnershipBucketOwnerEnforced, objectstoragev1beta1.ObjectOwnershipBucketOwnerPreferred, objectstoragev1beta1.ObjectOwnershipObjectWriter}))
+	}
+
+	if v.Provider == ProviderCAPA && parameters.BlockPublicAccess != nil && !*parameters.BlockPublicAccess {
+		warnings = append(warnings, fmt.Sprintf("%s is disabled: the bucket can be made public through ACLs or its bucket policy", path.Child("blockPublicAccess")))
+	}
+	return warnings, allErrs
+}
+
+// validateBucketPolicy checks that the statements of the bucket only apply to the bucket and do not replace the
+// statements of the operator.
+func validateBucketPolicy(path *field.Path, bucketName string, bucketPolicy *objectstoragev1beta1.BucketPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+	sids := map[string]bool{aws.EnforceSSLOnlyStatementID: true}
+	validateSid := func(path *field.Path, sid string) {
+		if sid == aws.EnforceSSLOnlyStatementID {
+			allErrs = append(allErrs, field.Invalid(path, sid, "is reserved for the statement of the operator"))
+		} else if sids[sid] {
+			allErrs = append(allErrs, field.Duplicate(path, sid))
+		}
+		sids[sid] = true
+	}
+
+	for i, statement := range bucketPolicy.Statements {
+		statementPath := path.Child("statements").Index(i)
+		validateSid(statementPath.Child("sid"), statement.Sid)
+		if len(statement.Principal.AWS) == 0 && len(statement.Principal.Service) == 0 {
+			allErrs = append(allErrs, field.Required(statementPath.Child("principal"), "at least one AWS or service principal is required"))
+		}
+		allErrs = append(allErrs, validateBucketPolicyActions(statementPath.Child("actions"), statement.Actions)...)
+		allErrs = append(allErrs, validateBucketPolicyResources(statementPath.Child("resources"), bucketName, statement.Resources)...)
+		for j, condition := range statement.Conditions {
+			if condition.Operator == "" || condition.Key == "" {
+				allErrs = append(allErrs, field.Required(statementPath.Child("conditions").Index(j), "operator and key are required"))
+			}
+		}
+	}
+
+	if bucketPolicy.RawStatements != "" {
+		rawPath := path.Child("rawStatements")
+		statements, err := aws.ParseRawBucketPolicyStatements(bucketPolicy.RawStatements)
+		if err != nil {
+			return append(allErrs, field.Invalid(rawPath, bucketPolicy.RawStatements, err.Error()))
+		}
+		for i, statement := range statements {
+			if statement.Sid != "" {
+				validateSid(rawPath.Index(i).Child("Sid"), statement.Sid)
+			}
+			allErrs = append(allErrs, validateBucketPolicyActions(rawPath.Index(i).Child("Action"), statement.Action)...)
+			allErrs = append(allErrs, validateBucketPolicyResources(rawPath.Index(i).Child("Resource"), bucketName, statement.Resource)...)
+		}
+	}
+	return allErrs
+}
+
+func validateBucketPolicyActions(path *field.Path, actions []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, action := range actions {
+		if !strings.HasPrefix(strings.ToLower(action), "s3:") {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), action, "must be an S3 action"))
+		}
+	}
+	return allErrs
+}
+
+func validateBucketPolicyResources(path *field.Path, bucketName string, resources []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, resource := range resources {
+		parsed, err := arn.Parse(resource)
+		if err != nil || parsed.Service != "s3" || (parsed.Resource != bucketName && !strings.HasPrefix(parsed.Resource, bucketName+"/")) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), resource, "must be the ARN of the bucket or of objects of the bucket"))
+		}
+	}
+	return allErrs
+}
+
+// validateAzureCredentials checks the type, access level and lifetime of the credentials published for Azure containers.
+func validateAzureCredentials(path *field.Path, credentials *objectstoragev1beta1.AzureBucketCredentials) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	switch credentials.Type {
+	case "", objectstoragev1beta1.AzureCredentialsTypeAccountKey:
+		if credentials.AccessLevel != "" || credentials.Lifetime != nil {
+			warnings = append(warnings, fmt.Sprintf("%s and %s are ignored with the AccountKey credentials, which grant full access to the storage account and never expire", path.Child("accessLevel"), path.Child("lifetime")))
+		}
+	case objectstoragev1beta1.AzureCredentialsTypeServiceSAS, objectstoragev1beta1.AzureCredentialsTypeUserDelegationSAS:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("type"), credentials.Type,
+			[]objectstoragev1beta1.AzureCredentialsType{objectstoragev1beta1.AzureCredentialsTypeAccountKey, objectstoragev1beta1.AzureCredentialsTypeServiceSAS, objectstoragev1beta1.AzureCredentialsTypeUserDelegationSAS}))
+	}
+
+	switch credentials.AccessLevel {
+	case "", objectstoragev1beta1.AccessLevelReadOnly, objectstoragev1beta1.AccessLevelReadWriteNoDelete, objectstoragev1beta1.AccessLevelReadWrite, objectstoragev1beta1.AccessLevelAdmin:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("accessLevel"), credentials.AccessLevel,
+			[]objectstoragev1beta1.AccessLevel{objectstoragev1beta1.AccessLevelReadOnly, objectstoragev1beta1.AccessLevelReadWriteNoDelete, objectstoragev1beta1.AccessLevelReadWrite, objectstoragev1beta1.AccessLevelAdmin}))
+	}
+
+	if lifetime := credentials.Lifetime; lifetime != nil && (lifetime.Duration < minSASLifetime || lifetime.Duration > maxSASLifetime) {
+		allErrs = append(allErrs, field.Invalid(path.Child("lifetime"), lifetime.Duration.String(), "must be between 1h and 7 days"))
+	}
+	return warnings, allErrs
+}
+
+// validateEncryption checks that customer-managed encryption references a key of the provider of the cluster.
+func (v *BucketCustomValidator) validateEncryption(path *field.Path, encryption *objectstoragev1beta1.BucketEncryption) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	switch encryption.Mode {
+	case "", objectstoragev1beta1.EncryptionModeProviderManaged:
+		if encryption.KMS != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("kms"), "requires the CustomerManaged mode"))
+		}
+		if encryption.KeyVault != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("keyVault"), "requires the CustomerManaged mode"))
+		}
+		return warnings, allErrs
+	case objectstoragev1beta1.EncryptionModeCustomerManaged:
+	default:
+		return warnings, append(allErrs, field.NotSupported(path.Child("mode"), encryption.Mode,
+			[]objectstoragev1beta1.EncryptionMode{objectstoragev1beta1.EncryptionModeProviderManaged, objectstoragev1beta1.EncryptionModeCustomerManaged}))
+	}
+
+	switch v.Provider {
+	case ProviderCAPA:
+		if encryption.KeyVault != nil {
+			warnings = append(warnings, "spec.encryption.keyVault is ignored on AWS")
+		}
+		if encryption.KMS == nil {
+			return warnings, append(allErrs, field.Required(path.Child("kms"), "a KMS key is required with the CustomerManaged mode on AWS"))
+		}
+		keyARN := encryption.KMS.KeyARN
+		if parsed, err := arn.Parse(keyARN); err != nil || parsed.Service != "kms" || !strings.HasPrefix(parsed.Resource, "key/") {
+			allErrs = append(allErrs, field.Invalid(path.Child("kms", "keyArn"), keyARN, "must be the ARN of a KMS key, e.g. arn:aws:kms:<region>:<account>:key/<id>"))
+		}
+	case ProviderCAPZ:
+		if encryption.KMS != nil {
+			warnings = append(warnings, "spec.encryption.kms is ignored on Azure")
+		}
+		if encryption.KeyVault == nil {
+			return warnings, append(allErrs, field.Required(path.Child("keyVault"), "a Key Vault key is required with the CustomerManaged mode on Azure"))
+		}
+		keyVault := encryption.KeyVault
+		if _, err := azure.ParseKeyVaultKeyURI(keyVault.KeyURI); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("keyVault", "keyUri"), keyVault.KeyURI, "must be a key URI like https://<vault>.vault.azure.net/keys/<name>[/<version>]"))
+		}
+		if identity, err := arm.ParseResourceID(keyVault.UserAssignedIdentityID); err != nil || !strings.EqualFold(identity.ResourceType.String(), userAssignedIdentityResourceType) {
+			allErrs = append(allErrs, field.Invalid(path.Child("keyVault", "userAssignedIdentityId"), keyVault.UserAssignedIdentityID, "must be the resource ID of a user-assigned managed identity"))
+		}
+	}
+	return warnings, allErrs
+}
+
+func (v *BucketCustomValidator) validateConnectionSecretRef(path *field.Path, bucket *objectstoragev1beta1.Bucket) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	ref := bucket.Spec.WriteConnectionSecretToRef
+
+	if ref.Name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			allErrs = append(allErrs, field.Invalid(path.Child("name"), ref.Name, msg))
+		}
+	}
+	if ref.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(ref.Namespace) {
+			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), ref.Namespace, msg))
+		}
+		if !objectstorage.ConnectionSecretNamespaceAllowed(bucket, v.ConnectionSecretNamespaces) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("namespace"), fmt.Sprintf("connection secrets can only be published in the namespace of the bucket or in one of %v", v.ConnectionSecretNamespaces)))
+		} else if ref.Namespace != bucket.Namespace && bucket.Spec.ReclaimPolicy != objectstoragev1beta1.ReclaimPolicyDelete {
+			warnings = append(warnings, "the connection secret is published in another namespace and is only deleted with the bucket when spec.reclaimPolicy is Delete")
+		}
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(ref.Labels, path.Child("labels"))...)
+	if _, ok := ref.Labels[objectstorage.ManagedByLabel]; ok {
+		allErrs = append(allErrs, field.Invalid(path.Child("labels").Key(objectstorage.ManagedByLabel), ref.Labels[objectstorage.ManagedByLabel], "is reserved by the operator"))
+	}
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(ref.Annotations, path.Child("annotations"))...)
+	if _, ok := ref.Annotations[objectstorage.ConnectionSecretOwnerAnnotation]; ok {
+		allErrs = append(allErrs, field.Invalid(path.Child("annotations").Key(objectstorage.ConnectionSecretOwnerAnnotation), ref.Annotations[objectstorage.ConnectionSecretOwnerAnnotation], "is reserved by the operator"))
+	}
+
+	for key, value := range ref.Templates {
+		keyPath := path.Child("templates").Key(key)
+		for _, msg := range validation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, msg))
+		}
+		if _, err := template.New(key).Parse(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(keyPath, value, err.Error()))
+		}
+		// The operator reads the SAS token back from the Secret to renew it only ahead of its expiry.
+		if v.Provider == ProviderCAPZ && key == azure.SASTokenKey {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "is reserved for the SAS token of the container"))
+		}
+	}
+
+	return warnings, allErrs
+}
+
+func (v *BucketCustomValidator) validateBucketName(path *field.Path, name string) field.ErrorList {
+	if name == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+
+	switch v.Provider {
+	case ProviderCAPA:
+		return validateS3BucketName(path, name)
+	case ProviderCAPZ:
+		return validateAzureBucketName(path, name)
+	}
+	return nil
+}
+
+func validateS3BucketName(path *field.Path, name string) field.ErrorList {
+	var allErrs field.ErrorList
+	if !s3BucketNameRegexp.MatchString(name) {
+		allErrs = append(allErrs, field.Invalid(path, name, "must be 3 to 63 characters long, consist of lowercase letters, numbers, dots and hyphens, and begin and end with a letter or number"))
+	}
+	if strings.Contains(name, "..") {
+		allErrs = append(allErrs, field.Invalid(path, name, "must not contain two adjacent periods"))
+	}
+	if net.ParseIP(name) != nil {
+		allErrs = append(allErrs, field.Invalid(path, name, "must not be formatted as an IP address"))
+	}
+	for _, prefix := range s3ForbiddenNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			allErrs = append(allErrs, field.Invalid(path, name, fmt.Sprintf("must not start with %q", prefix)))
+		}
+	}
+	for _, suffix := range s3ForbiddenNameSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			allErrs = append(allErrs, field.Invalid(path, name, fmt.Sprintf("must not end with %q", suffix)))
+		}
+	}
+	return allErrs
+}
+
+func validateAzureBucketName(path *field.Path, name string) field.ErrorList {
+	var allErrs field.ErrorList
+	// The bucket name is used as is for the container name.
+	if !azureContainerNameRegexp.MatchString(name) {
+		allErrs = append(allErrs, field.Invalid(path, name, "must be 3 to 63 characters long, consist of lowercase letters, numbers and hyphens, and begin and end with a letter or number"))
+	}
+	if strings.Contains(name, "--") {
+		allErrs = append(allErrs, field.Invalid(path, name, "must not contain consecutive hyphens"))
+	}
+	// The bucket name is sanitized to name the storage account.
+	if len(azure.SanitizeStorageAccountName(name)) < 3 {
+		allErrs = append(allErrs, field.Invalid(path, name, "must contain at least 3 letters or numbers to name the storage account"))
+	}
+	return allErrs
+}
+
+func (v *BucketCustomValidator) validateTags(path *field.Path, tags []objectstoragev1beta1.BucketTag) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(tags) > maxTags {
+		allErrs = append(allErrs, field.TooMany(path, len(tags), maxTags))
+	}
+
+	keys := make(map[string]bool, len(tags))
+	for i, tag := range tags {
+		keyPath := path.Index(i).Child("key")
+		valuePath := path.Index(i).Child("value")
+		if tag.Key == "" {
+			allErrs = append(allErrs, field.Required(keyPath, ""))
+			continue
+		}
+
+		// Azure replaces hyphens in tag keys, so keys can collide once sanitized.
+		key := tag.Key
+		if v.Provider == ProviderCAPZ {
+			key = azure.SanitizeTagKey(tag.Key)
+		}
+		if keys[key] {
+			allErrs = append(allErrs, field.Duplicate(keyPath, tag.Key))
+		}
+		keys[key] = true
+
+		switch v.Provider {
+		case ProviderCAPA:
+			if len(tag.Key) > 128 {
+				allErrs = append(allErrs, field.TooLong(keyPath, tag.Key, 128))
+			}
+			if len(tag.Value) > 256 {
+				allErrs = append(allErrs, field.TooLong(valuePath, tag.Value, 256))
+			}
+			if strings.HasPrefix(strings.ToLower(tag.Key), "aws:") {
+				allErrs = append(allErrs, field.Invalid(keyPath, tag.Key, `must not start with "aws:"`))
+			}
+			if key == aws.OwnerTagKey {
+				allErrs = append(allErrs, field.Invalid(keyPath, tag.Key, "is reserved by the operator"))
+			}
+		case ProviderCAPZ:
+			if len(tag.Key) > 512 {
+				allErrs = append(allErrs, field.TooLong(keyPath, tag.Key, 512))
+			}
+			if len(tag.Value) > 256 {
+				allErrs = append(allErrs, field.TooLong(valuePath, tag.Value, 256))
+			}
+			if strings.ContainsAny(tag.Key, azureForbiddenTagChars) {
+				allErrs = append(allErrs, field.Invalid(keyPath, tag.Key, fmt.Sprintf("must not contain any of %q", azureForbiddenTagChars)))
+			}
+			if key == azure.OwnerTagKey {
+				allErrs = append(allErrs, field.Invalid(keyPath, tag.Key, "is reserved by the operator"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateAccessGrants(path *field.Path, accessGrants []objectstoragev1beta1.BucketAccessGrant) field.ErrorList {
+	var allErrs field.ErrorList
+	principalIDs := map[string]bool{}
+	for i, accessGrant := range accessGrants {
+		grantPath := path.Index(i)
+		principalIDPath := grantPath.Child("principalID")
+		switch principalID := strings.ToLower(accessGrant.PrincipalID); {
+		case !principalIDRegexp.MatchString(accessGrant.PrincipalID):
+			allErrs = append(allErrs, field.Invalid(principalIDPath, accessGrant.PrincipalID, "must be the object ID of a Microsoft Entra principal"))
+		case principalIDs[principalID]:
+			allErrs = append(allErrs, field.Duplicate(principalIDPath, accessGrant.PrincipalID))
+		default:
+			principalIDs[principalID] = true
+		}
+
+		switch accessGrant.PrincipalType {
+		case objectstoragev1beta1.PrincipalTypeUser, objectstoragev1beta1.PrincipalTypeGroup, objectstoragev1beta1.PrincipalTypeServicePrincipal:
+		default:
+			allErrs = append(allErrs, field.NotSupported(grantPath.Child("principalType"), accessGrant.PrincipalType,
+				[]objectstoragev1beta1.PrincipalType{objectstoragev1beta1.PrincipalTypeUser, objectstoragev1beta1.PrincipalTypeGroup, objectstoragev1beta1.PrincipalTypeServicePrincipal}))
+		}
+
+		switch accessGrant.Role {
+		case objectstoragev1beta1.AccessGrantRoleReader, objectstoragev1beta1.AccessGrantRoleContributor, objectstoragev1beta1.AccessGrantRoleOwner:
+		default:
+			allErrs = append(allErrs, field.NotSupported(grantPath.Child("role"), accessGrant.Role,
+				[]objectstoragev1beta1.AccessGrantRole{objectstoragev1beta1.AccessGrantRoleReader, objectstoragev1beta1.AccessGrantRoleContributor, objectstoragev1beta1.AccessGrantRoleOwner}))
+		}
+	}
+	return allErrs
+}
+
+func (v *BucketCustomValidator) validateAccessRole(path *field.Path, accessRole *objectstoragev1beta1.BucketAccessRole) (admission.Warnings, field.ErrorList) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	if v.Provider == ProviderCAPZ {
+		warnings = append(warnings, azureIgnoredAccessRoleFields(path, accessRole)...)
+	}
+
+	if accessRole.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+	} else if v.Provider == ProviderCAPA && !iamRoleNameRegexp.MatchString(accessRole.Name) {
+		allErrs = append(allErrs, field.Invalid(path.Child("name"), accessRole.Name, "must be 1 to 64 characters long and consist of alphanumeric characters and '+=,.@-_'"))
+	} else if v.Provider == ProviderCAPZ && !managedIdentityNameRegexp.MatchString(accessRole.Name) {
+		allErrs = append(allErrs, field.Invalid(path.Child("name"), accessRole.Name, "must be 3 to 128 characters long, start with an alphanumeric character and consist of alphanumeric characters and '-_'"))
+	}
+
+	serviceAccountPath := path.Child("serviceAccount")
+	if accessRole.ServiceAccount.Name == "" {
+		allErrs = append(allErrs, field.Required(serviceAccountPath.Child("name"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(accessRole.ServiceAccount.Name) {
+			allErrs = append(allErrs, field.Invalid(serviceAccountPath.Child("name"), accessRole.ServiceAccount.Name, msg))
+		}
+	}
+
+	if accessRole.ServiceAccount.Namespace == "" {
+		allErrs = append(allErrs, field.Required(serviceAccountPath.Child("namespace"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(accessRole.ServiceAccount.Namespace) {
+			allErrs = append(allErrs, field.Invalid(serviceAccountPath.Child("namespace"), accessRole.ServiceAccount.Namespace, msg))
+		}
+	}
+
+	switch accessRole.Mode {
+	case "", objectstoragev1beta1.AccessRoleModeIRSA:
+	case objectstoragev1beta1.AccessRoleModePodIdentity:
+		if v.Provider == ProviderCAPZ {
+			allErrs = append(allErrs, field.Invalid(path.Child("mode"), accessRole.Mode, "not supported on Azure"))
+		}
+		if len(accessRole.OIDCProviders) != 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("oidcProviders"), "only supported with the IRSA mode"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("mode"), accessRole.Mode, []objectstoragev1beta1.AccessRoleMode{
+			objectstoragev1beta1.AccessRoleModeIRSA,
+			objectstoragev1beta1.AccessRoleModePodIdentity,
+		}))
+	}
+
+	for i, serviceAccount := range accessRole.ServiceAccounts {
+		serviceAccountPath := path.Child("serviceAccounts").Index(i)
+		allErrs = append(allErrs, validateTrustedServiceAccount(serviceAccountPath, serviceAccount)...)
+		// EKS Pod Identity associates service accounts one by one, and Azure federated identity credentials match
+		// exact subjects.
+		if strings.ContainsAny(serviceAccount.Namespace+serviceAccount.Name, "*?") {
+			if accessRole.Mode == objectstoragev1beta1.AccessRoleModePodIdentity {
+				allErrs = append(allErrs, field.Invalid(serviceAccountPath, serviceAccount.Namespace+"/"+serviceAccount.Name, "wildcards are not supported with the PodIdentity mode"))
+			} else if v.Provider == ProviderCAPZ {
+				allErrs = append(allErrs, field.Invalid(serviceAccountPath, serviceAccount.Namespace+"/"+serviceAccount.Name, "wildcards are not supported on Azure"))
+			}
+		}
+	}
+
+	issuers := map[string]bool{}
+	for i, provider := range accessRole.OIDCProviders {
+		providerPath := path.Child("oidcProviders").Index(i)
+		allErrs = append(allErrs, validateOIDCProvider(providerPath, provider)...)
+		issuer := strings.TrimSuffix(provider.Issuer, "/")
+		if issuers[issuer] {
+			allErrs = append(allErrs, field.Duplicate(providerPath.Child("issuer"), provider.Issuer))
+		}
+		issuers[issuer] = true
+	}
+
+	if accessRole.Path != "" && !iamPathRegexp.MatchString(accessRole.Path) {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), accessRole.Path, "must be / or start and end with / and consist of printable ASCII characters"))
+	}
+	if accessRole.PermissionsBoundary != "" {
+		allErrs = append(allErrs, validateIAMPolicyARN(path.Child("permissionsBoundary"), accessRole.PermissionsBoundary)...)
+		if v.Provider == ProviderCAPA && !v.AccessRoleDefaults.PermissionsBoundaryAllowed(accessRole.PermissionsBoundary) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("permissionsBoundary"), fmt.Sprintf("permissions boundary %s is not allowed by the operator", accessRole.PermissionsBoundary)))
+		}
+	}
+	if accessRole.MaxSessionDuration != nil && (accessRole.MaxSessionDuration.Duration < time.Hour || accessRole.MaxSessionDuration.Duration > 12*time.Hour) {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSessionDuration"), accessRole.MaxSessionDuration.Duration.String(), "must be between 1h and 12h"))
+	}
+	for i, policyARN := range accessRole.ManagedPolicies {
+		allErrs = append(allErrs, validateIAMPolicyARN(path.Child("managedPolicies").Index(i), policyARN)...)
+		if v.Provider == ProviderCAPA && !v.AccessRoleDefaults.ManagedPolicyAllowed(policyARN) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("managedPolicies").Index(i), fmt.Sprintf("managed policy %s is not allowed by the operator", policyARN)))
+		}
+	}
+
+	allErrs = append(allErrs, validateBucketPermissions(path, accessRole.BucketPermissions)...)
+
+	for i, name := range accessRole.ExtraBucketNames {
+		allErrs = append(allErrs, v.validateBucketName(path.Child("extraBucketNames").Index(i), name)...)
+	}
+
+	extraBucketNames := map[string]bool{}
+	for _, name := range accessRole.ExtraBucketNames {
+		extraBucketNames[name] = true
+	}
+	for i, extraBucket := range accessRole.ExtraBuckets {
+		extraBucketPath := path.Child("extraBuckets").Index(i)
+		allErrs = append(allErrs, v.validateBucketName(extraBucketPath.Child("name"), extraBucket.Name)...)
+		if extraBucketNames[extraBucket.Name] {
+			allErrs = append(allErrs, field.Duplicate(extraBucketPath.Child("name"), extraBucket.Name))
+		}
+		extraBucketNames[extraBucket.Name] = true
+		allErrs = append(allErrs, validateBucketPermissions(extraBucketPath, extraBucket.BucketPermissions)...)
+	}
+
+	return warnings, allErrs
+}
+
+// azureIgnoredAccessRoleFields warns about the fields of the access role that only apply to IAM roles. Managed
+// identities get a built-in role on the container of the bucket only.
+func azureIgnoredAccessRoleFields(path *field.Path, accessRole *objectstoragev1beta1.BucketAccessRole) admission.Warnings {
+	var warnings admission.Warnings
+	ignored := map[string]bool{
+		"description":         accessRole.Description != "",
+		"path":                accessRole.Path != "",
+		"permissionsBoundary": accessRole.PermissionsBoundary != "",
+		"maxSessionDuration":  accessRole.MaxSessionDuration != nil,
+		"managedPolicies":     len(accessRole.ManagedPolicies) != 0,
+		"prefixes":            len(accessRole.Prefixes) != 0,
+		"extraActions":        len(accessRole.ExtraActions) != 0,
+		"extraBucketNames":    len(accessRole.ExtraBucketNames) != 0,
+		"extraBuckets":        len(accessRole.ExtraBuckets) != 0,
+	}
+	for _, name := range slices.Sorted(maps.Keys(ignored)) {
+		if ignored[name] {
+			warnings = append(warnings, fmt.Sprintf("%s is ignored on Azure", path.Child(name)))
+		}
+	}
+	if accessRole.AccessLevel == objectstoragev1beta1.AccessLevelReadWriteNoDelete {
+		warnings = append(warnings, fmt.Sprintf("%s %s grants the Storage Blob Data Contributor role on Azure, which allows deleting blobs", path.Child("accessLevel"), accessRole.AccessLevel))
+	}
+	return warnings
+}
+
+// validateIAMPolicyARN checks the ARN of a managed IAM policy, e.g. arn:aws:iam::aws:policy/ReadOnlyAccess.
+func validateIAMPolicyARN(path *field.Path, value string) field.ErrorList {
+	policyARN, err := arn.Parse(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if policyARN.Service != "iam" || !strings.HasPrefix(policyARN.Resource, "policy/") {
+		return field.ErrorList{field.Invalid(path, value, "must be the ARN of an IAM policy")}
+	}
+	return nil
+}
+
+// validateOIDCProvider checks an OIDC provider trusted by an access role. Its issuer must be an https URL without
+// query or fragment, as IAM OIDC providers are.
+func validateOIDCProvider(path *field.Path, provider objectstoragev1beta1.OIDCProvider) field.ErrorList {
+	var allErrs field.ErrorList
+
+	issuerPath := path.Child("issuer")
+	if provider.Issuer == "" {
+		allErrs = append(allErrs, field.Required(issuerPath, ""))
+	} else if issuer, err := url.Parse(provider.Issuer); err != nil {
+		allErrs = append(allErrs, field.Invalid(issuerPath, provider.Issuer, err.Error()))
+	} else if issuer.Scheme != "https" || issuer.Host == "" || issuer.User != nil || issuer.RawQuery != "" || issuer.Fragment != "" {
+		allErrs = append(allErrs, field.Invalid(issuerPath, provider.Issuer, "must be an https URL without user, query or fragment"))
+	}
+
+	for i, audience := range provider.Audiences {
+		if audience == "" {
+			allErrs = append(allErrs, field.Required(path.Child("audiences").Index(i), ""))
+		}
+	}
+	return allErrs
+}
+
+// validateTrustedServiceAccount checks an additional service account of an access role. Its namespace and name may
+// contain wildcards, so they are validated with the wildcards replaced by a valid character.
+func validateTrustedServiceAccount(path *field.Path, serviceAccount objectstoragev1beta1.ServiceAccountReference) field.ErrorList {
+	var allErrs field.ErrorList
+	withoutWildcards := strings.NewReplacer("*", "a", "?", "a")
+
+	if serviceAccount.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(withoutWildcards.Replace(serviceAccount.Name)) {
+			allErrs = append(allErrs, field.Invalid(path.Child("name"), serviceAccount.Name, msg))
+		}
+	}
+
+	if serviceAccount.Namespace == "" {
+		allErrs = append(allErrs, field.Required(path.Child("namespace"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(withoutWildcards.Replace(serviceAccount.Namespace)) {
+			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), serviceAccount.Namespace, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateBucketPermissions(path *field.Path, permissions objectstoragev1beta1.BucketPermissions) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch permissions.AccessLevel {
+	case "", objectstoragev1beta1.AccessLevelReadOnly, objectstoragev1beta1.AccessLevelReadWriteNoDelete, objectstoragev1beta1.AccessLevelReadWrite, objectstoragev1beta1.AccessLevelAdmin:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("accessLevel"), permissions.AccessLevel, []objectstoragev1beta1.AccessLevel{
+			objectstoragev1beta1.AccessLevelReadOnly,
+			objectstoragev1beta1.AccessLevelReadWriteNoDelete,
+			objectstoragev1beta1.AccessLevelReadWrite,
+			objectstoragev1beta1.AccessLevelAdmin,
+		}))
+	}
+
+	for i, prefix := range permissions.Prefixes {
+		if prefix == "" || strings.HasPrefix(prefix, "/") || strings.ContainsAny(prefix, "*?") {
+			allErrs = append(allErrs, field.Invalid(path.Child("prefixes").Index(i), prefix, "must be a non-empty object key prefix without leading slash nor wildcard"))
+		}
+	}
+
+	allErrs = append(allErrs, validateBucketPolicyActions(path.Child("extraActions"), permissions.ExtraActions)...)
+	return allErrs
+}
+
+// validateCollisions makes sure no other bucket targets the same cloud resources.
+func (v *BucketCustomValidator) validateCollisions(ctx context.Context, bucket *objectstoragev1beta1.Bucket) (field.ErrorList, error) {
+	var buckets objectstoragev1beta1.BucketList
+	if err := v.Client.List(ctx, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "name")
+	for _, other := range buckets.Items {
+		if other.Namespace == bucket.Namespace && other.Name == bucket.Name {
+			continue
+		}
+
+		if other.Spec.Name == bucket.Spec.Name {
+			allErrs = append(allErrs, field.Duplicate(path, fmt.Sprintf("%s is already used by bucket %s/%s", bucket.Spec.Name, other.Namespace, other.Name)))
+			continue
+		}
+
+		if v.Provider == ProviderCAPZ {
+			storageAccountName := azure.SanitizeStorageAccountName(bucket.Spec.Name)
+			if storageAccountName == azure.SanitizeStorageAccountName(other.Spec.Name) {
+				allErrs = append(allErrs, field.Duplicate(path, fmt.Sprintf("%s results in storage account %s which is already used by bucket %s/%s", bucket.Spec.Name, storageAccountName, other.Namespace, other.Name)))
+			}
+		}
+	}
+	return allErrs, nil
+}
+
+func (v *BucketCustomValidator) toError(bucket *objectstoragev1beta1.Bucket, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(objectstoragev1beta1.GroupVersion.WithKind("Bucket").GroupKind(), bucket.Name, allErrs)
+}
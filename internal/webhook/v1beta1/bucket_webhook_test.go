@@ -0,0 +1,1207 @@
+package v1beta1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	objectstoragev1beta1 "github.com/giantswarm/object-storage-operator/api/v1beta1"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/aws"
+)
+
+func newBucket(name string, spec objectstoragev1beta1.BucketSpec) *objectstoragev1beta1.Bucket {
+	return &objectstoragev1beta1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: spec,
+	}
+}
+
+func newValidator(t *testing.T, provider string, existing ...*objectstoragev1beta1.Bucket) *BucketCustomValidator {
+	scheme := runtime.NewScheme()
+	if err := objectstoragev1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, bucket := range existing {
+		builder = builder.WithObjects(bucket)
+	}
+	return &BucketCustomValidator{
+		Client:                     builder.Build(),
+		Provider:                   provider,
+		ConnectionSecretNamespaces: []string{"monitoring"},
+		AccessRoleDefaults: aws.AccessRoleDefaults{
+			PermissionsBoundary:    "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+			AllowedManagedPolicies: []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+		},
+	}
+}
+
+func Test_ValidateCreate(t *testing.T) {
+	abortIncompleteMultipartUploadDays := int32(3)
+	blockPublicAccess := false
+
+	testCases := []struct {
+		name          string
+		provider      string
+		existing      []*objectstoragev1beta1.Bucket
+		spec          objectstoragev1beta1.BucketSpec
+		expectedError string
+		expectWarning bool
+	}{
+		{
+			name:     "case 0: valid S3 bucket",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:          "giantswarm-glippy-loki",
+				Lifecycle:     &objectstoragev1beta1.BucketLifecycle{Expiration: &objectstoragev1beta1.BucketExpiration{Days: 100}},
+				ReclaimPolicy: objectstoragev1beta1.ReclaimPolicyDelete,
+				Access: &objectstoragev1beta1.BucketAccess{
+					Role: &objectstoragev1beta1.BucketAccessRole{
+						Name:             "giantswarm-glippy-loki",
+						ServiceAccount:   objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						ExtraBucketNames: []string{"giantswarm-glippy-loki-ruler"},
+					},
+				},
+				Tags: []objectstoragev1beta1.BucketTag{{Key: "app", Value: "loki"}},
+			},
+		},
+		{
+			name:          "case 1: missing name",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{},
+			expectedError: "spec.name: Required value",
+		},
+		{
+			name:          "case 2: S3 bucket name with uppercase letters",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "Giantswarm-Loki"},
+			expectedError: "must be 3 to 63 characters long",
+		},
+		{
+			name:          "case 3: S3 bucket name formatted as an IP address",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "192.168.5.4"},
+			expectedError: "must not be formatted as an IP address",
+		},
+		{
+			name:          "case 4: S3 bucket name with two adjacent periods",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm..loki"},
+			expectedError: "must not contain two adjacent periods",
+		},
+		{
+			name:          "case 5: S3 bucket name with a reserved suffix",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-loki-s3alias"},
+			expectedError: `must not end with "-s3alias"`,
+		},
+		{
+			name:          "case 6: Azure bucket name with dots",
+			provider:      ProviderCAPZ,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm.loki"},
+			expectedError: "must be 3 to 63 characters long",
+		},
+		{
+			name:          "case 7: Azure bucket name with consecutive hyphens",
+			provider:      ProviderCAPZ,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm--loki"},
+			expectedError: "must not contain consecutive hyphens",
+		},
+		{
+			name:          "case 8: Azure bucket name too short once sanitized",
+			provider:      ProviderCAPZ,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "a-b"},
+			expectedError: "must contain at least 3 letters or numbers",
+		},
+		{
+			name:          "case 9: Azure bucket name truncated to name the storage account",
+			provider:      ProviderCAPZ,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-verylonginstallationname-loki"},
+			expectWarning: true,
+		},
+		{
+			name:          "case 10: bucket name already used",
+			provider:      ProviderCAPA,
+			existing:      []*objectstoragev1beta1.Bucket{newBucket("other", objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki"})},
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki"},
+			expectedError: "is already used by bucket default/other",
+		},
+		{
+			name:          "case 11: Azure storage account name collision",
+			provider:      ProviderCAPZ,
+			existing:      []*objectstoragev1beta1.Bucket{newBucket("other", objectstoragev1beta1.BucketSpec{Name: "giantswarm-verylonginstallationname-loki"})},
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-verylonginstallationname-mimir"},
+			expectedError: "results in storage account giantswarmverylonginstal which is already used by bucket default/other",
+			expectWarning: true,
+		},
+		{
+			name:          "case 12: non positive expiration days",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki", Lifecycle: &objectstoragev1beta1.BucketLifecycle{Expiration: &objectstoragev1beta1.BucketExpiration{Days: 0}}},
+			expectedError: "spec.lifecycle.expiration.days: Invalid value: 0: must be greater than 0",
+		},
+		{
+			name:          "case 13: unsupported reclaim policy",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki", ReclaimPolicy: "Destroy"},
+			expectedError: `spec.reclaimPolicy: Unsupported value: "Destroy"`,
+		},
+		{
+			name:     "case 14: duplicate Azure tag keys once sanitized",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Tags: []objectstoragev1beta1.BucketTag{{Key: "team-name", Value: "atlas"}, {Key: "team_name", Value: "atlas"}},
+			},
+			expectedError: `spec.tags[1].key: Duplicate value: "team_name"`,
+		},
+		{
+			name:     "case 15: reserved S3 tag key",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Tags: []objectstoragev1beta1.BucketTag{{Key: "aws:team", Value: "atlas"}},
+			},
+			expectedError: `must not start with "aws:"`,
+		},
+		{
+			name:     "case 16: too many tags",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Tags: func() []objectstoragev1beta1.BucketTag {
+					tags := make([]objectstoragev1beta1.BucketTag, 0, maxTags+1)
+					for i := 0; i <= maxTags; i++ {
+						tags = append(tags, objectstoragev1beta1.BucketTag{Key: "key" + strconv.Itoa(i), Value: "value"})
+					}
+					return tags
+				}(),
+			},
+			expectedError: "spec.tags: Too many: 51: must have at most 50 items",
+		},
+		{
+			name:     "case 17: access role without service account",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:   "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{Name: "giantswarm-glippy-loki"}},
+			},
+			expectedError: "spec.access.role.serviceAccount.name: Required value",
+		},
+		{
+			name:     "case 18: access role with an invalid extra bucket name",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{
+					Role: &objectstoragev1beta1.BucketAccessRole{
+						Name:             "giantswarm-glippy-loki",
+						ServiceAccount:   objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+						ExtraBucketNames: []string{"Invalid_Bucket"},
+					},
+				},
+			},
+			expectedError: "spec.access.role.extraBucketNames[0]",
+		},
+		{
+			name:          "case 19: unsupported adoption policy",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki", AdoptionPolicy: "Import"},
+			expectedError: `spec.adoptionPolicy: Unsupported value: "Import"`,
+		},
+		{
+			name:          "case 20: adopted bucket deletion allowed without delete reclaim policy",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki", AdoptionPolicy: objectstoragev1beta1.AdoptionPolicyAdopt, AllowAdoptedBucketDeletion: true},
+			expectWarning: true,
+		},
+		{
+			name:     "case 21: S3 owner tag key",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Tags: []objectstoragev1beta1.BucketTag{{Key: "giantswarm.io/bucket", Value: "default/other"}},
+			},
+			expectedError: "is reserved by the operator",
+		},
+		{
+			name:          "case 22: invalid bucket class name",
+			provider:      ProviderCAPA,
+			spec:          objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki", BucketClassName: "Logs"},
+			expectedError: "spec.bucketClassName: Invalid value",
+		},
+		{
+			name:     "case 23: parameters of another provider",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-loki",
+				Parameters: &objectstoragev1beta1.BucketParameters{AWS: &objectstoragev1beta1.AWSBucketParameters{}},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 24: valid connection secret reference",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{
+					Name:        "loki-object-store",
+					Labels:      map[string]string{"app.kubernetes.io/name": "loki"},
+					Annotations: map[string]string{"reloader.stakater.com/match": "true"},
+					Templates:   map[string]string{"config.yaml": "bucket_name: {{ .bucketName }}\nregion: {{ .region }}"},
+				},
+			},
+		},
+		{
+			name:     "case 25: invalid connection secret template",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{
+					Templates: map[string]string{"config.yaml": "bucket_name: {{ .bucketName "},
+				},
+			},
+			expectedError: "spec.writeConnectionSecretToRef.templates[config.yaml]: Invalid value",
+		},
+		{
+			name:     "case 26: reserved connection secret label",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{
+					Labels: map[string]string{"giantswarm.io/managed-by": "flux"},
+				},
+			},
+			expectedError: "is reserved by the operator",
+		},
+		{
+			name:     "case 27: connection secret in another namespace",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:                       "giantswarm-glippy-loki",
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{Namespace: "monitoring"},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 28: invalid connection secret name",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:                       "giantswarm-glippy-loki",
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{Name: "Loki_Store"},
+			},
+			expectedError: "spec.writeConnectionSecretToRef.name: Invalid value",
+		},
+		{
+			name:     "case 29: valid lifecycle rules",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{
+						{ID: "index", Filter: &objectstoragev1beta1.BucketLifecycleFilter{Prefix: "index/"}, Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Days: 30}},
+						{ID: "tenant-a", Filter: &objectstoragev1beta1.BucketLifecycleFilter{Tags: []objectstoragev1beta1.BucketTag{{Key: "tenant", Value: "a"}}}, Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Date: "2030-01-01"}},
+					},
+				},
+			},
+		},
+		{
+			name:     "case 30: duplicated lifecycle rule ID",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{
+						{ID: "index", Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Days: 30}},
+						{ID: "index", Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Days: 7}},
+					},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[1].id: Duplicate value",
+		},
+		{
+			name:     "case 31: lifecycle rule without action",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{ID: "index"}},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[0].expiration: Required value",
+		},
+		{
+			name:     "case 32: lifecycle rule expiring on a date on Azure",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{ID: "index", Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Date: "2030-01-01"}}},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[0].expiration.date: Forbidden",
+		},
+		{
+			name:     "case 33: lifecycle rule ID not alphanumeric on Azure",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{ID: "tenant-a", Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Days: 30}}},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[0].id: Invalid value",
+		},
+		{
+			name:     "case 34: invalid lifecycle rule expiration date",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{ID: "index", Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Date: "01/01/2030"}}},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[0].expiration.date: Invalid value",
+		},
+		{
+			name:     "case 35: valid lifecycle transitions on Azure",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID: "chunks",
+						Transitions: []objectstoragev1beta1.BucketLifecycleTransition{
+							{Days: 30, StorageClass: objectstoragev1beta1.StorageClassCool},
+							{Days: 180, StorageClass: objectstoragev1beta1.StorageClassArchive},
+						},
+					}},
+				},
+			},
+		},
+		{
+			name:     "case 36: storage class of another provider",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:          "chunks",
+						Transitions: []objectstoragev1beta1.BucketLifecycleTransition{{Days: 30, StorageClass: objectstoragev1beta1.StorageClassCool}},
+					}},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[0].transitions[0].storageClass: Unsupported value",
+		},
+		{
+			name:     "case 37: transition after expiration",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:          "chunks",
+						Expiration:  &objectstoragev1beta1.BucketLifecycleExpiration{Days: 30},
+						Transitions: []objectstoragev1beta1.BucketLifecycleTransition{{Days: 60, StorageClass: objectstoragev1beta1.StorageClassGlacierIR}},
+					}},
+				},
+			},
+			expectedError: "spec.lifecycle.rules[0].transitions[0].days: Invalid value",
+		},
+		{
+			name:     "case 38: early transition to STANDARD_IA",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:          "chunks",
+						Transitions: []objectstoragev1beta1.BucketLifecycleTransition{{Days: 7, StorageClass: objectstoragev1beta1.StorageClassStandardIA}},
+					}},
+				},
+			},
+			expectedError: "must be at least 30 to transition to STANDARD_IA",
+		},
+		{
+			name:     "case 39: versioned bucket cleaning up previous versions",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-loki",
+				Versioning: objectstoragev1beta1.VersioningEnabled,
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:                          "versions",
+						NoncurrentVersionExpiration: &objectstoragev1beta1.BucketNoncurrentVersionExpiration{Days: 7, NewerNoncurrentVersions: 3},
+						ExpiredObjectDeleteMarker:   true,
+					}},
+				},
+			},
+		},
+		{
+			name:     "case 40: previous versions cleaned up without versioning",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:                          "versions",
+						NoncurrentVersionExpiration: &objectstoragev1beta1.BucketNoncurrentVersionExpiration{Days: 7},
+					}},
+				},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 41: delete markers cleaned up with an expiration",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-loki",
+				Versioning: objectstoragev1beta1.VersioningEnabled,
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:                        "versions",
+						Expiration:                &objectstoragev1beta1.BucketLifecycleExpiration{Days: 30},
+						ExpiredObjectDeleteMarker: true,
+					}},
+				},
+			},
+			expectedError: "cannot be combined with an expiration on AWS",
+		},
+		{
+			name:     "case 42: newer noncurrent versions on Azure",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarmglippyloki",
+				Versioning: objectstoragev1beta1.VersioningEnabled,
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:                          "versions",
+						NoncurrentVersionExpiration: &objectstoragev1beta1.BucketNoncurrentVersionExpiration{Days: 7, NewerNoncurrentVersions: 3},
+					}},
+				},
+			},
+			expectedError: "newer noncurrent versions are not supported on Azure",
+		},
+		{
+			name:     "case 43: abort of incomplete multipart uploads on Azure, reported in the status",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:      "giantswarmglippyloki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{AbortIncompleteMultipartUploadDays: &abortIncompleteMultipartUploadDays},
+			},
+		},
+		{
+			name:     "case 44: lifecycle rule with the ID of the multipart uploads rule",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Lifecycle: &objectstoragev1beta1.BucketLifecycle{
+					Rules: []objectstoragev1beta1.BucketLifecycleRule{{
+						ID:         aws.AbortIncompleteMultipartUploadRuleID,
+						Expiration: &objectstoragev1beta1.BucketLifecycleExpiration{Days: 7},
+					}},
+				},
+			},
+			expectedError: "is reserved",
+		},
+		{
+			name:     "case 45: bucket with an object lock",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:          "giantswarm-glippy-audit",
+				ReclaimPolicy: objectstoragev1beta1.ReclaimPolicyDelete,
+				ObjectLock:    &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeCompliance, RetentionDays: 365},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 46: object lock mode of another provider",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarmglippyaudit",
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeGovernance, RetentionDays: 365},
+			},
+			expectedError: "spec.objectLock.mode: Unsupported value",
+		},
+		{
+			name:     "case 47: object lock with suspended versioning",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-audit",
+				Versioning: objectstoragev1beta1.VersioningSuspended,
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeGovernance, RetentionDays: 30},
+			},
+			expectedError: "versioning cannot be suspended on buckets with an object lock",
+		},
+		{
+			name:     "case 48: bucket encrypted with a KMS key",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Encryption: &objectstoragev1beta1.BucketEncryption{
+					Mode: objectstoragev1beta1.EncryptionModeCustomerManaged,
+					KMS:  &objectstoragev1beta1.KMSEncryption{KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+				},
+			},
+		},
+		{
+			name:     "case 49: customer-managed encryption without KMS key",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Encryption: &objectstoragev1beta1.BucketEncryption{
+					Mode:     objectstoragev1beta1.EncryptionModeCustomerManaged,
+					KeyVault: &objectstoragev1beta1.KeyVaultEncryption{KeyURI: "https://glippy.vault.azure.net/keys/loki"},
+				},
+			},
+			expectedError: "spec.encryption.kms: Required value",
+			expectWarning: true,
+		},
+		{
+			name:     "case 50: KMS key alias instead of key ARN",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Encryption: &objectstoragev1beta1.BucketEncryption{
+					Mode: objectstoragev1beta1.EncryptionModeCustomerManaged,
+					KMS:  &objectstoragev1beta1.KMSEncryption{KeyARN: "arn:aws:kms:eu-west-1:123456789012:alias/loki"},
+				},
+			},
+			expectedError: "spec.encryption.kms.keyArn: Invalid value",
+		},
+		{
+			name:     "case 51: KMS key with provider-managed encryption",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Encryption: &objectstoragev1beta1.BucketEncryption{
+					Mode: objectstoragev1beta1.EncryptionModeProviderManaged,
+					KMS:  &objectstoragev1beta1.KMSEncryption{KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+				},
+			},
+			expectedError: "spec.encryption.kms: Forbidden",
+		},
+		{
+			name:     "case 52: storage account encrypted with a Key Vault key",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarmglippyloki",
+				Encryption: &objectstoragev1beta1.BucketEncryption{
+					Mode: objectstoragev1beta1.EncryptionModeCustomerManaged,
+					KeyVault: &objectstoragev1beta1.KeyVaultEncryption{
+						KeyURI:                 "https://glippy.vault.azure.net/keys/loki/0123456789abcdef",
+						UserAssignedIdentityID: "/subscriptions/1234/resourceGroups/glippy/providers/Microsoft.ManagedIdentity/userAssignedIdentities/loki",
+					},
+				},
+			},
+		},
+		{
+			name:     "case 53: Key Vault key with an invalid URI and identity",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarmglippyloki",
+				Encryption: &objectstoragev1beta1.BucketEncryption{
+					Mode: objectstoragev1beta1.EncryptionModeCustomerManaged,
+					KeyVault: &objectstoragev1beta1.KeyVaultEncryption{
+						KeyURI:                 "https://glippy.vault.azure.net/secrets/loki",
+						UserAssignedIdentityID: "/subscriptions/1234/resourceGroups/glippy/providers/Microsoft.Storage/storageAccounts/loki",
+					},
+				},
+			},
+			expectedError: "spec.encryption.keyVault.userAssignedIdentityId: Invalid value",
+		},
+		{
+			name:     "case 54: bucket opting out of the public access block",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-website",
+				Parameters: &objectstoragev1beta1.BucketParameters{
+					AWS: &objectstoragev1beta1.AWSBucketParameters{BlockPublicAccess: &blockPublicAccess},
+				},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 55: unknown object ownership",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-website",
+				Parameters: &objectstoragev1beta1.BucketParameters{
+					AWS: &objectstoragev1beta1.AWSBucketParameters{ObjectOwnership: "BucketOwner"},
+				},
+			},
+			expectedError: "spec.parameters.aws.objectOwnership: Unsupported value",
+		},
+		{
+			name:     "case 56: bucket policy with structured and raw statements",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				BucketPolicy: &objectstoragev1beta1.BucketPolicy{
+					Statements: []objectstoragev1beta1.BucketPolicyStatement{{
+						Sid:       "CrossAccountRead",
+						Effect:    objectstoragev1beta1.PolicyEffectAllow,
+						Principal: objectstoragev1beta1.BucketPolicyPrincipal{AWS: []string{"210987654321"}},
+						Actions:   []string{"s3:GetObject"},
+						Resources: []string{"arn:aws:s3:::giantswarm-glippy-loki/shared/*"},
+					}},
+					RawStatements: `[{"Sid": "DenyUnencryptedUploads", "Effect": "Deny", "Principal": "*", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::giantswarm-glippy-loki/*"}]`,
+				},
+			},
+		},
+		{
+			name:     "case 57: bucket policy statement replacing the statement of the operator",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				BucketPolicy: &objectstoragev1beta1.BucketPolicy{
+					RawStatements: `[{"Sid": "EnforceSSLOnly", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject"}]`,
+				},
+			},
+			expectedError: "is reserved for the statement of the operator",
+		},
+		{
+			name:     "case 58: bucket policy statement on another bucket",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				BucketPolicy: &objectstoragev1beta1.BucketPolicy{
+					Statements: []objectstoragev1beta1.BucketPolicyStatement{{
+						Sid:       "CrossAccountRead",
+						Effect:    objectstoragev1beta1.PolicyEffectAllow,
+						Principal: objectstoragev1beta1.BucketPolicyPrincipal{AWS: []string{"210987654321"}},
+						Actions:   []string{"s3:GetObject"},
+						Resources: []string{"arn:aws:s3:::giantswarm-glippy-loki-other/*"},
+					}},
+				},
+			},
+			expectedError: "spec.bucketPolicy.statements[0].resources[0]: Invalid value",
+		},
+		{
+			name:     "case 59: invalid raw bucket policy statements",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				BucketPolicy: &objectstoragev1beta1.BucketPolicy{
+					RawStatements: `{"Effect": "Allow"}`,
+				},
+			},
+			expectedError: "spec.bucketPolicy.rawStatements: Invalid value",
+		},
+		{
+			name:     "case 60: access role with permission levels",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					BucketPermissions: objectstoragev1beta1.BucketPermissions{
+						AccessLevel:  objectstoragev1beta1.AccessLevelReadWriteNoDelete,
+						Prefixes:     []string{"chunks/"},
+						ExtraActions: []string{"s3:ListBucketMultipartUploads"},
+					},
+					ExtraBuckets: []objectstoragev1beta1.ExtraBucketPermissions{{
+						Name:              "giantswarm-glippy-archive",
+						BucketPermissions: objectstoragev1beta1.BucketPermissions{AccessLevel: objectstoragev1beta1.AccessLevelReadOnly},
+					}},
+				}},
+			},
+		},
+		{
+			name:     "case 61: access role with wildcard prefix and non-S3 extra action",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					BucketPermissions: objectstoragev1beta1.BucketPermissions{
+						Prefixes:     []string{"chunks/*"},
+						ExtraActions: []string{"iam:PassRole"},
+					},
+				}},
+			},
+			expectedError: "spec.access.role.extraActions[0]: Invalid value",
+		},
+		{
+			name:     "case 62: extra bucket listed twice",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:             "giantswarm-glippy-loki",
+					ServiceAccount:   objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ExtraBucketNames: []string{"giantswarm-glippy-archive"},
+					ExtraBuckets:     []objectstoragev1beta1.ExtraBucketPermissions{{Name: "giantswarm-glippy-archive"}},
+				}},
+			},
+			expectedError: "spec.access.role.extraBuckets[0].name: Duplicate value",
+		},
+		{
+			name:     "case 63: access role with additional service accounts",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-grafana-postgresql",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-grafana-postgresql",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "grafana-postgresql", Namespace: "monitoring"},
+					ServiceAccounts: []objectstoragev1beta1.ServiceAccountReference{
+						{Name: "grafana-postgresql-recovery-test", Namespace: "monitoring"},
+						{Name: "*", Namespace: "backup-*"},
+					},
+				}},
+			},
+		},
+		{
+			name:     "case 64: access role with an invalid additional service account",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-grafana-postgresql",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-grafana-postgresql",
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "grafana-postgresql", Namespace: "monitoring"},
+					ServiceAccounts: []objectstoragev1beta1.ServiceAccountReference{{Name: "Plugin_Barman", Namespace: "monitoring"}},
+				}},
+			},
+			expectedError: "spec.access.role.serviceAccounts[0].name: Invalid value",
+		},
+		{
+			name:     "case 65: access role trusting two OIDC providers",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					OIDCProviders: []objectstoragev1beta1.OIDCProvider{
+						{Issuer: "https://irsa.glippy.example.com"},
+						{Issuer: "https://oidc.eks.eu-west-1.amazonaws.com/id/EXAMPLE", Audiences: []string{"sts.amazonaws.com"}},
+					},
+				}},
+			},
+		},
+		{
+			name:     "case 66: access role with an invalid OIDC issuer",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					OIDCProviders:  []objectstoragev1beta1.OIDCProvider{{Issuer: "https://irsa.glippy.example.com?client=loki"}},
+				}},
+			},
+			expectedError: "spec.access.role.oidcProviders[0].issuer: Invalid value",
+		},
+		{
+			name:     "case 67: access role with a duplicate OIDC issuer",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					OIDCProviders: []objectstoragev1beta1.OIDCProvider{
+						{Issuer: "https://irsa.glippy.example.com"},
+						{Issuer: "https://irsa.glippy.example.com/"},
+					},
+				}},
+			},
+			expectedError: "spec.access.role.oidcProviders[1].issuer: Duplicate value",
+		},
+		{
+			name:     "case 68: access role in the PodIdentity mode",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-loki",
+					Mode:            objectstoragev1beta1.AccessRoleModePodIdentity,
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ServiceAccounts: []objectstoragev1beta1.ServiceAccountReference{{Name: "loki-canary", Namespace: "loki"}},
+				}},
+			},
+		},
+		{
+			name:     "case 69: access role in the PodIdentity mode with a wildcard service account",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-loki",
+					Mode:            objectstoragev1beta1.AccessRoleModePodIdentity,
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ServiceAccounts: []objectstoragev1beta1.ServiceAccountReference{{Name: "loki-*", Namespace: "loki"}},
+				}},
+			},
+			expectedError: "spec.access.role.serviceAccounts[0]: Invalid value",
+		},
+		{
+			name:     "case 70: access role in the PodIdentity mode with OIDC providers",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					Mode:           objectstoragev1beta1.AccessRoleModePodIdentity,
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					OIDCProviders:  []objectstoragev1beta1.OIDCProvider{{Issuer: "https://irsa.glippy.example.com"}},
+				}},
+			},
+			expectedError: "spec.access.role.oidcProviders: Forbidden",
+		},
+		{
+			name:     "case 71: access role with a path, permissions boundary, session duration and managed policies",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:                "giantswarm-glippy-loki",
+					ServiceAccount:      objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					Description:         "Loki chunks and index",
+					Path:                "/giantswarm/observability/",
+					PermissionsBoundary: "arn:aws:iam::123456789012:policy/giantswarm-boundary",
+					MaxSessionDuration:  &metav1.Duration{Duration: 4 * time.Hour},
+					ManagedPolicies:     []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+				}},
+			},
+		},
+		{
+			name:     "case 72: access role with an invalid path",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm-glippy-loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					Path:           "giantswarm",
+				}},
+			},
+			expectedError: "spec.access.role.path: Invalid value",
+		},
+		{
+			name:     "case 73: access role with a too long session duration",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:               "giantswarm-glippy-loki",
+					ServiceAccount:     objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					MaxSessionDuration: &metav1.Duration{Duration: 24 * time.Hour},
+				}},
+			},
+			expectedError: "spec.access.role.maxSessionDuration: Invalid value",
+		},
+		{
+			name:     "case 74: access role with a permissions boundary that is not a policy",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:                "giantswarm-glippy-loki",
+					ServiceAccount:      objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					PermissionsBoundary: "arn:aws:iam::123456789012:role/giantswarm-boundary",
+				}},
+			},
+			expectedError: "spec.access.role.permissionsBoundary: Invalid value",
+		},
+		{
+			name:     "case 75: Azure access role",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-loki",
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ServiceAccounts: []objectstoragev1beta1.ServiceAccountReference{{Name: "loki-canary", Namespace: "loki"}},
+					OIDCProviders:   []objectstoragev1beta1.OIDCProvider{{Issuer: "https://oidc.glippy.example.com/"}},
+				}},
+			},
+		},
+		{
+			name:     "case 76: Azure access role with IAM settings",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-loki",
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ManagedPolicies: []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess"},
+				}},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 77: Azure access role with an invalid managed identity name",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:           "giantswarm.glippy.loki",
+					ServiceAccount: objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+				}},
+			},
+			expectedError: "spec.access.role.name: Invalid value",
+		},
+		{
+			name:     "case 78: Azure access role trusting service accounts with wildcards",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-loki",
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ServiceAccounts: []objectstoragev1beta1.ServiceAccountReference{{Name: "loki-*", Namespace: "loki"}},
+				}},
+			},
+			expectedError: "spec.access.role.serviceAccounts[0]: Invalid value",
+		},
+		{
+			name:     "case 79: Azure access grants",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				AccessGrants: []objectstoragev1beta1.BucketAccessGrant{
+					{PrincipalID: "6e5b0f3c-8c1a-4b5e-9d2f-0a1b2c3d4e5f", PrincipalType: objectstoragev1beta1.PrincipalTypeGroup, Role: objectstoragev1beta1.AccessGrantRoleReader},
+					{PrincipalID: "7f6c1e4d-9d2b-4c6f-8e3a-1b2c3d4e5f60", PrincipalType: objectstoragev1beta1.PrincipalTypeServicePrincipal, Role: objectstoragev1beta1.AccessGrantRoleContributor},
+				},
+			},
+		},
+		{
+			name:     "case 80: access grants are ignored on AWS",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				AccessGrants: []objectstoragev1beta1.BucketAccessGrant{
+					{PrincipalID: "6e5b0f3c-8c1a-4b5e-9d2f-0a1b2c3d4e5f", PrincipalType: objectstoragev1beta1.PrincipalTypeGroup, Role: objectstoragev1beta1.AccessGrantRoleReader},
+				},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 81: access grant with an invalid principal ID",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				AccessGrants: []objectstoragev1beta1.BucketAccessGrant{
+					{PrincipalID: "loki@example.com", PrincipalType: objectstoragev1beta1.PrincipalTypeUser, Role: objectstoragev1beta1.AccessGrantRoleReader},
+				},
+			},
+			expectedError: "spec.accessGrants[0].principalID: Invalid value",
+		},
+		{
+			name:     "case 82: access grants of the same principal",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				AccessGrants: []objectstoragev1beta1.BucketAccessGrant{
+					{PrincipalID: "6e5b0f3c-8c1a-4b5e-9d2f-0a1b2c3d4e5f", PrincipalType: objectstoragev1beta1.PrincipalTypeGroup, Role: objectstoragev1beta1.AccessGrantRoleReader},
+					{PrincipalID: "6E5B0F3C-8C1A-4B5E-9D2F-0A1B2C3D4E5F", PrincipalType: objectstoragev1beta1.PrincipalTypeGroup, Role: objectstoragev1beta1.AccessGrantRoleOwner},
+				},
+			},
+			expectedError: "spec.accessGrants[1].principalID: Duplicate value",
+		},
+		{
+			name:     "case 83: access grant with an unsupported role",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				AccessGrants: []objectstoragev1beta1.BucketAccessGrant{
+					{PrincipalID: "6e5b0f3c-8c1a-4b5e-9d2f-0a1b2c3d4e5f", PrincipalType: objectstoragev1beta1.PrincipalTypeGroup, Role: "Administrator"},
+				},
+			},
+			expectedError: "spec.accessGrants[0].role: Unsupported value",
+		},
+		{
+			name:     "case 84: Azure user delegation SAS credentials",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Parameters: &objectstoragev1beta1.BucketParameters{
+					Azure: &objectstoragev1beta1.AzureBucketParameters{Credentials: &objectstoragev1beta1.AzureBucketCredentials{
+						Type:        objectstoragev1beta1.AzureCredentialsTypeUserDelegationSAS,
+						AccessLevel: objectstoragev1beta1.AccessLevelReadOnly,
+						Lifetime:    &metav1.Duration{Duration: 12 * time.Hour},
+					}},
+				},
+			},
+		},
+		{
+			name:     "case 85: SAS lifetime longer than 7 days",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Parameters: &objectstoragev1beta1.BucketParameters{
+					Azure: &objectstoragev1beta1.AzureBucketParameters{Credentials: &objectstoragev1beta1.AzureBucketCredentials{
+						Type:     objectstoragev1beta1.AzureCredentialsTypeServiceSAS,
+						Lifetime: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					}},
+				},
+			},
+			expectedError: "spec.parameters.azure.credentials.lifetime: Invalid value",
+		},
+		{
+			name:     "case 86: SAS settings are ignored with the account key",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Parameters: &objectstoragev1beta1.BucketParameters{
+					Azure: &objectstoragev1beta1.AzureBucketParameters{Credentials: &objectstoragev1beta1.AzureBucketCredentials{
+						AccessLevel: objectstoragev1beta1.AccessLevelReadOnly,
+					}},
+				},
+			},
+			expectWarning: true,
+		},
+		{
+			name:     "case 87: template replacing the SAS token",
+			provider: ProviderCAPZ,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{
+					Templates: map[string]string{"sasToken": "?{{ .sasToken }}"},
+				},
+			},
+			expectedError: "spec.writeConnectionSecretToRef.templates[sasToken]: Invalid value",
+		},
+		{
+			name:     "case 88: connection secret in a namespace that is not allowed",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name:                       "giantswarm-glippy-loki",
+				ReclaimPolicy:              objectstoragev1beta1.ReclaimPolicyDelete,
+				WriteConnectionSecretToRef: &objectstoragev1beta1.ConnectionSecretReference{Namespace: "kube-system"},
+			},
+			expectedError: "spec.writeConnectionSecretToRef.namespace: Forbidden",
+		},
+		{
+			name:     "case 89: access role with a permissions boundary that is not allowed",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:                "giantswarm-glippy-loki",
+					ServiceAccount:      objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					PermissionsBoundary: "arn:aws:iam::aws:policy/AdministratorAccess",
+				}},
+			},
+			expectedError: "spec.access.role.permissionsBoundary: Forbidden",
+		},
+		{
+			name:     "case 90: access role with a managed policy that is not allowed",
+			provider: ProviderCAPA,
+			spec: objectstoragev1beta1.BucketSpec{
+				Name: "giantswarm-glippy-loki",
+				Access: &objectstoragev1beta1.BucketAccess{Role: &objectstoragev1beta1.BucketAccessRole{
+					Name:            "giantswarm-glippy-loki",
+					ServiceAccount:  objectstoragev1beta1.ServiceAccountReference{Name: "loki", Namespace: "loki"},
+					ManagedPolicies: []string{"arn:aws:iam::aws:policy/AmazonSSMReadOnlyAccess", "arn:aws:iam::aws:policy/AdministratorAccess"},
+				}},
+			},
+			expectedError: "spec.access.role.managedPolicies[1]: Forbidden",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			validator := newValidator(t, tc.provider, tc.existing...)
+			warnings, err := validator.ValidateCreate(context.Background(), newBucket("bucket", tc.spec))
+
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedError)) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+			if tc.expectWarning != (len(warnings) > 0) {
+				t.Fatalf("unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func Test_ValidateUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		oldSpec       objectstoragev1beta1.BucketSpec
+		newSpec       objectstoragev1beta1.BucketSpec
+		expectedError string
+	}{
+		{
+			name:    "case 0: valid update",
+			oldSpec: objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki"},
+			newSpec: objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki", ReclaimPolicy: objectstoragev1beta1.ReclaimPolicyDelete},
+		},
+		{
+			name:          "case 1: name changed",
+			oldSpec:       objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-loki"},
+			newSpec:       objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-mimir"},
+			expectedError: "spec.name: Forbidden: field is immutable",
+		},
+		{
+			name:    "case 2: unchanged invalid spec is not blocked",
+			oldSpec: objectstoragev1beta1.BucketSpec{Name: "Giantswarm_Loki"},
+			newSpec: objectstoragev1beta1.BucketSpec{Name: "Giantswarm_Loki"},
+		},
+		{
+			name: "case 3: object lock removed",
+			oldSpec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-audit",
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeGovernance, RetentionDays: 30},
+			},
+			newSpec:       objectstoragev1beta1.BucketSpec{Name: "giantswarm-glippy-audit"},
+			expectedError: "spec.objectLock: Forbidden: an object lock cannot be removed",
+		},
+		{
+			name: "case 4: governance retention extended and turned into compliance",
+			oldSpec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-audit",
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeGovernance, RetentionDays: 30},
+			},
+			newSpec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-audit",
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeCompliance, RetentionDays: 365},
+			},
+		},
+		{
+			name: "case 5: compliance retention downgraded",
+			oldSpec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-audit",
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeCompliance, RetentionDays: 365},
+			},
+			newSpec: objectstoragev1beta1.BucketSpec{
+				Name:       "giantswarm-glippy-audit",
+				ObjectLock: &objectstoragev1beta1.BucketObjectLock{Mode: objectstoragev1beta1.ObjectLockModeGovernance, RetentionDays: 365},
+			},
+			expectedError: "spec.objectLock.mode: Forbidden: cannot be changed once COMPLIANCE",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log(tc.name)
+
+			validator := newValidator(t, ProviderCAPA)
+			_, err := validator.ValidateUpdate(context.Background(), newBucket("bucket", tc.oldSpec), newBucket("bucket", tc.newSpec))
+
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedError)) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -20,12 +20,15 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -36,14 +39,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/giantswarm/object-storage-operator/api/v1alpha1"
+	"github.com/giantswarm/object-storage-operator/api/v1beta1"
 	"github.com/giantswarm/object-storage-operator/internal/controller"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/cluster"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/cosi"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/flags"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/aws"
 	"github.com/giantswarm/object-storage-operator/internal/pkg/service/objectstorage/cloud/azure"
+	"github.com/giantswarm/object-storage-operator/internal/pkg/storageversion"
+	webhookv1beta1 "github.com/giantswarm/object-storage-operator/internal/webhook/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -54,8 +62,10 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -63,13 +73,51 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var enableWebhooks bool
+	var webhookPort int
+	var webhookCertDir string
+	var abortIncompleteMultipartUploadDays int
+	var accessRoleDefaults aws.AccessRoleDefaults
+	var accessRoleManagedPolicies string
+	var accessRoleAllowedPermissionsBoundaries string
+	var accessRoleAllowedManagedPolicies string
+	var connectionSecretNamespaces string
 	var managementCluster = flags.ManagementCluster{}
+	var cosiEndpoint string
+	var cosiDriverName string
+	var cosiNamespace string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Enable the admission webhooks.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server listens on.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "", "The directory containing the webhook server certificate. Defaults to <temp-dir>/k8s-webhook-server/serving-certs.")
 	// Get all management cluster specific configs.
+	flag.IntVar(&abortIncompleteMultipartUploadDays, "abort-incomplete-multipart-upload-days", 7,
+		"Default number of days after which incomplete multipart uploads to S3 buckets are aborted. 0 keeps them forever.")
+	flag.StringVar(&accessRoleDefaults.Description, "access-role-description", "",
+		"Default description of the access roles. Defaults to a description naming the bucket.")
+	flag.StringVar(&accessRoleDefaults.Path, "access-role-path", "/", "Default path of the new access roles.")
+	flag.StringVar(&accessRoleDefaults.PermissionsBoundary, "access-role-permissions-boundary", "",
+		"Default ARN of the permissions boundary of the access roles.")
+	flag.DurationVar(&accessRoleDefaults.MaxSessionDuration, "access-role-max-session-duration", time.Hour,
+		"Default maximum session duration of the access roles, between 1h and 12h.")
+	flag.StringVar(&accessRoleManagedPolicies, "access-role-managed-policies", "",
+		"Default comma-separated ARNs of the managed policies attached to the access roles.")
+	flag.StringVar(&accessRoleAllowedPermissionsBoundaries, "access-role-allowed-permissions-boundaries", "",
+		"Comma-separated ARNs of the permissions boundaries Buckets may set on their access role instead of the default one.")
+	flag.StringVar(&accessRoleAllowedManagedPolicies, "access-role-allowed-managed-policies", "",
+		"Comma-separated ARNs of the managed policies Buckets may attach to their access role besides the default ones.")
+	flag.StringVar(&connectionSecretNamespaces, "connection-secret-namespaces", "",
+		"Comma-separated namespaces connection Secrets may be published in besides the namespace of their Bucket.")
+	flag.StringVar(&cosiEndpoint, "cosi-endpoint", "",
+		"Endpoint the COSI driver is served on to the COSI sidecar, e.g. unix:///var/lib/cosi/cosi.sock. The driver is not served when empty.")
+	flag.StringVar(&cosiDriverName, "cosi-driver-name", "objectstorage.giantswarm.io",
+		"Name of the COSI driver, referenced by the driverName of COSI BucketClasses.")
+	flag.StringVar(&cosiNamespace, "cosi-namespace", "giantswarm",
+		"Namespace recorded as the owner of the buckets created through COSI.")
 	flag.StringVar(&managementCluster.BaseDomain, "management-cluster-base-domain", "", "Management cluster base domain.")
 	flag.StringVar(&managementCluster.Name, "management-cluster-name", "", "Management cluster CR name.")
 	flag.StringVar(&managementCluster.Namespace, "management-cluster-namespace", "", "Management cluster CR namespace.")
@@ -83,6 +131,19 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if accessRoleDefaults.MaxSessionDuration < time.Hour || accessRoleDefaults.MaxSessionDuration > 12*time.Hour {
+		setupLog.Error(nil, "access role maximum session duration must be between 1h and 12h", "maxSessionDuration", accessRoleDefaults.MaxSessionDuration)
+		os.Exit(1)
+	}
+	accessRoleDefaults.ManagedPolicies = splitList(accessRoleManagedPolicies)
+	accessRoleDefaults.AllowedPermissionsBoundaries = splitList(accessRoleAllowedPermissionsBoundaries)
+	accessRoleDefaults.AllowedManagedPolicies = splitList(accessRoleAllowedManagedPolicies)
+	// Access roles could otherwise be granted any permission through the managed policies attached to them.
+	if managementCluster.Provider == "capa" && accessRoleDefaults.PermissionsBoundary == "" {
+		setupLog.Error(nil, "access role permissions boundary is required on capa")
+		os.Exit(1)
+	}
+
 	discardHelmSecretsSelector, err := labels.Parse("owner notin (helm,Helm)")
 	if err != nil {
 		setupLog.Error(err, "failed to parse label selector")
@@ -94,6 +155,10 @@ func main() {
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "objectstorage.giantswarm.io",
@@ -131,7 +196,10 @@ func main() {
 			Client:            mgr.GetClient(),
 			ManagementCluster: managementCluster,
 		}
-		objectStorage = aws.AWSObjectStorageService{}
+		objectStorage = aws.AWSObjectStorageService{
+			AbortIncompleteMultipartUploadDays: int32(abortIncompleteMultipartUploadDays),
+			AccessRoleDefaults:                 accessRoleDefaults,
+		}
 	case "capz":
 		clusterGetter = azure.AzureClusterGetter{
 			Client:            mgr.GetClient(),
@@ -147,11 +215,45 @@ func main() {
 		ClusterGetter:               clusterGetter,
 		ObjectStorageServiceFactory: objectStorage,
 		ManagementCluster:           managementCluster,
+		ConnectionSecretNamespaces:  splitList(connectionSecretNamespaces),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Bucket")
 		os.Exit(1)
 	}
 
+	if enableWebhooks {
+		if err = webhookv1beta1.SetupBucketWebhookWithManager(mgr, webhookv1beta1.BucketCustomValidator{
+			Provider:                   managementCluster.Provider,
+			ConnectionSecretNamespaces: splitList(connectionSecretNamespaces),
+			AccessRoleDefaults:         accessRoleDefaults,
+		}); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Bucket")
+			os.Exit(1)
+		}
+	}
+	if cosiEndpoint != "" {
+		if err = mgr.Add(cosi.Server{
+			Driver: cosi.Driver{
+				ClusterGetter:               clusterGetter,
+				ObjectStorageServiceFactory: objectStorage,
+				Client:                      mgr.GetClient(),
+				Logger:                      ctrl.Log.WithName("cosi"),
+				Name:                        cosiDriverName,
+				Namespace:                   cosiNamespace,
+			},
+			Endpoint: cosiEndpoint,
+		}); err != nil {
+			setupLog.Error(err, "unable to add COSI driver")
+			os.Exit(1)
+		}
+	}
+	if err = mgr.Add(storageversion.BucketMigrator{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+	}); err != nil {
+		setupLog.Error(err, "unable to add storage version migrator")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -169,3 +271,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList returns the non-empty values of a comma-separated flag.
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}